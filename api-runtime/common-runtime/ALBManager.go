@@ -0,0 +1,889 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package commonruntime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	iidm "github.com/cloud-barista/cb-spider/cloud-control-manager/iid-manager"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+// ====================================================================
+// type for GORM
+
+type ALBIIDInfo VPCDependentIIDInfo
+
+func (ALBIIDInfo) TableName() string {
+	return "alb_iid_infos"
+}
+
+//====================================================================
+
+func init() {
+	db, err := infostore.Open()
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	db.AutoMigrate(&ALBIIDInfo{})
+	infostore.Close(db)
+}
+
+const (
+	DEFAULT_ALB_STICKY_DURATION = 86400 // secs, 1 day
+	MAX_ALB_RULE_PRIORITY       = 50000
+)
+
+//================ ALB Handler
+
+func transformALBArgsToUpper(albInfo *cres.ALBInfo) {
+	albInfo.Type = strings.ToUpper(albInfo.Type)
+
+	for idx := range albInfo.Listeners {
+		albInfo.Listeners[idx].Protocol = strings.ToUpper(albInfo.Listeners[idx].Protocol)
+	}
+	for idx := range albInfo.TargetGroups {
+		tg := &albInfo.TargetGroups[idx]
+		tg.Protocol = strings.ToUpper(tg.Protocol)
+		tg.HealthChecker.Protocol = strings.ToUpper(tg.HealthChecker.Protocol)
+		tg.StickySession.Type = strings.ToUpper(tg.StickySession.Type)
+	}
+}
+
+func checkALBPort(name string, port string) error {
+	num, err := strconv.Atoi(port)
+	if err != nil || num < 1 || num > 65535 {
+		return fmt.Errorf("%s Port '%s' is invalid, it should be 1-65535!", name, port)
+	}
+	return nil
+}
+
+func checkALBProtocol(name string, protocol string) error {
+	if protocol != "HTTP" && protocol != "HTTPS" {
+		return fmt.Errorf("%s Protocol '%s' is not supported, it should be HTTP or HTTPS!", name, protocol)
+	}
+	return nil
+}
+
+// validate the ALB request and set the default values
+//   - Listener: HTTP|HTTPS, HTTPS requires CertificateRef, unique Port
+//   - Rule: Host or PathPattern, unique Priority in a Listener
+//   - Target Group: unique Name, referenced by Listeners and Rules
+//   - Sticky Session: LB_COOKIE(default) | APP_COOKIE with CookieName
+func validateALBReqInfo(reqInfo *cres.ALBInfo) error {
+	if reqInfo.Type == "" {
+		reqInfo.Type = "PUBLIC"
+	}
+	if reqInfo.Type != "PUBLIC" && reqInfo.Type != "INTERNAL" {
+		return fmt.Errorf("ALB Type '%s' is not supported, it should be PUBLIC or INTERNAL!", reqInfo.Type)
+	}
+	if len(reqInfo.Listeners) == 0 {
+		return fmt.Errorf("ALB requires at least one Listener!")
+	}
+	if len(reqInfo.TargetGroups) == 0 {
+		return fmt.Errorf("ALB requires at least one Target Group!")
+	}
+
+	tgNames := map[string]bool{}
+	for idx := range reqInfo.TargetGroups {
+		tg := &reqInfo.TargetGroups[idx]
+		tg.Name = strings.TrimSpace(tg.Name)
+		if tg.Name == "" {
+			return fmt.Errorf("Target Group Name is empty!")
+		}
+		if tgNames[tg.Name] {
+			return fmt.Errorf("Target Group '%s' is duplicated!", tg.Name)
+		}
+		tgNames[tg.Name] = true
+
+		if err := checkALBProtocol("Target Group '"+tg.Name+"'", tg.Protocol); err != nil {
+			return err
+		}
+		if err := checkALBPort("Target Group '"+tg.Name+"'", tg.Port); err != nil {
+			return err
+		}
+		if tg.VMs == nil {
+			tg.VMs = &[]cres.IID{}
+		}
+
+		// health checker follows the Target Group by default
+		if tg.HealthChecker.Protocol == "" {
+			tg.HealthChecker.Protocol = tg.Protocol
+		}
+		if tg.HealthChecker.Port == "" {
+			tg.HealthChecker.Port = tg.Port
+		}
+
+		sticky := &tg.StickySession
+		if sticky.Enabled {
+			if sticky.Type == "" {
+				sticky.Type = "LB_COOKIE"
+			}
+			switch sticky.Type {
+			case "LB_COOKIE":
+				if sticky.Duration <= 0 {
+					sticky.Duration = DEFAULT_ALB_STICKY_DURATION
+				}
+			case "APP_COOKIE":
+				if sticky.CookieName == "" {
+					return fmt.Errorf("Target Group '%s': APP_COOKIE Sticky Session requires CookieName!", tg.Name)
+				}
+			default:
+				return fmt.Errorf("Target Group '%s': Sticky Session Type '%s' is not supported, it should be LB_COOKIE or APP_COOKIE!",
+					tg.Name, sticky.Type)
+			}
+		} else {
+			*sticky = cres.StickySessionInfo{}
+		}
+	}
+
+	listenerPorts := map[string]bool{}
+	for _, listener := range reqInfo.Listeners {
+		name := "Listener '" + listener.Protocol + ":" + listener.Port + "'"
+		if err := checkALBProtocol(name, listener.Protocol); err != nil {
+			return err
+		}
+		if err := checkALBPort(name, listener.Port); err != nil {
+			return err
+		}
+		if listenerPorts[listener.Port] {
+			return fmt.Errorf("%s: Port is duplicated!", name)
+		}
+		listenerPorts[listener.Port] = true
+
+		if listener.Protocol == "HTTPS" && strings.TrimSpace(listener.CertificateRef) == "" {
+			return fmt.Errorf("%s: HTTPS Listener requires CertificateRef!", name)
+		}
+		if !tgNames[listener.DefaultTargetGroup] {
+			return fmt.Errorf("%s: DefaultTargetGroup '%s' does not exist!", name, listener.DefaultTargetGroup)
+		}
+
+		priorities := map[int]bool{}
+		for _, rule := range listener.Rules {
+			if rule.Priority < 1 || rule.Priority > MAX_ALB_RULE_PRIORITY {
+				return fmt.Errorf("%s: Rule Priority '%d' is invalid, it should be 1-%d!", name, rule.Priority, MAX_ALB_RULE_PRIORITY)
+			}
+			if priorities[rule.Priority] {
+				return fmt.Errorf("%s: Rule Priority '%d' is duplicated!", name, rule.Priority)
+			}
+			priorities[rule.Priority] = true
+
+			if rule.Host == "" && rule.PathPattern == "" {
+				return fmt.Errorf("%s: Rule(Priority %d) requires Host or PathPattern!", name, rule.Priority)
+			}
+			if rule.PathPattern != "" && !strings.HasPrefix(rule.PathPattern, "/") {
+				return fmt.Errorf("%s: Rule(Priority %d) PathPattern '%s' should start with '/'!", name, rule.Priority, rule.PathPattern)
+			}
+			if !tgNames[rule.TargetGroup] {
+				return fmt.Errorf("%s: Rule(Priority %d) TargetGroup '%s' does not exist!", name, rule.Priority, rule.TargetGroup)
+			}
+		}
+	}
+
+	return nil
+}
+
+// convert Target VM NameIds to driver IIDs
+func setTargetVMDriverIIDs(connectionName string, vmList *[]cres.IID) error {
+	for idx, vmIID := range *vmList {
+		var vmIIDInfo VMIIDInfo
+		err := infostore.GetByConditions(&vmIIDInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, vmIID.NameId)
+		if err != nil {
+			return fmt.Errorf("The %s '%s' does not exist!", RSTypeString(VM), vmIID.NameId)
+		}
+		(*vmList)[idx] = getDriverIID(cres.IID{NameId: vmIIDInfo.NameId, SystemId: vmIIDInfo.SystemId})
+	}
+	return nil
+}
+
+// set UserIIDs of ALB, VPC and Target VMs with the info-store
+func setALBUserIIDs(iidInfo *ALBIIDInfo, info *cres.ALBInfo) error {
+	info.IId = getUserIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+
+	var vpcIIDInfo VPCIIDInfo
+	err := infostore.GetByConditions(&vpcIIDInfo, CONNECTION_NAME_COLUMN, iidInfo.ConnectionName, NAME_ID_COLUMN, iidInfo.OwnerVPCName)
+	if err != nil {
+		return err
+	}
+	info.VpcIID = getUserIID(cres.IID{NameId: vpcIIDInfo.NameId, SystemId: vpcIIDInfo.SystemId})
+
+	for _, tg := range info.TargetGroups {
+		if tg.VMs == nil {
+			continue
+		}
+		for idx, vmIID := range *tg.VMs {
+			var vmIIDInfo VMIIDInfo
+			err := infostore.GetByContain(&vmIIDInfo, CONNECTION_NAME_COLUMN, iidInfo.ConnectionName, SYSTEM_ID_COLUMN, vmIID.SystemId)
+			if err != nil {
+				return err
+			}
+			(*tg.VMs)[idx].NameId = vmIIDInfo.NameId
+		}
+	}
+	return nil
+}
+
+// check whether a VPC is used by any ALB
+func checkALBUsingVPC(connectionName string, vpcName string) (bool, error) {
+	return infostore.HasByConditions(&ALBIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, OWNER_VPC_NAME_COLUMN, vpcName)
+}
+
+// get the name of an ALB with the VM in its Target Groups, "" if not used.
+// The Target VMs are kept only in the CSP, so each ALB of the connection is fetched.
+func getALBUsingVM(connectionName string, vmDriverIID cres.IID) (string, error) {
+	var iidInfoList []*ALBIIDInfo
+	err := infostore.ListByCondition(&iidInfoList, CONNECTION_NAME_COLUMN, connectionName)
+	if err != nil {
+		return "", err
+	}
+	if len(iidInfoList) == 0 {
+		return "", nil
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		return "", err
+	}
+	handler, err := cldConn.CreateALBHandler()
+	if err != nil {
+		return "", err
+	}
+
+	for _, iidInfo := range iidInfoList {
+		albSPLock.RLock(connectionName, iidInfo.NameId)
+		info, err := handler.GetALB(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}))
+		albSPLock.RUnlock(connectionName, iidInfo.NameId)
+		if err != nil {
+			if checkNotFoundError(err) {
+				cblog.Info(err)
+				continue
+			}
+			return "", err
+		}
+		for _, tg := range info.TargetGroups {
+			if tg.VMs == nil {
+				continue
+			}
+			for _, vmIID := range *tg.VMs {
+				if vmIID.SystemId == vmDriverIID.SystemId {
+					return iidInfo.NameId, nil
+				}
+			}
+		}
+	}
+	return "", nil
+}
+
+func getALBIIDInfo(connectionName string, nameID string) (*ALBIIDInfo, error) {
+	var iidInfo ALBIIDInfo
+	err := infostore.GetByConditions(&iidInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+	if err != nil {
+		return nil, fmt.Errorf("The %s '%s' does not exist!", RSTypeString(ALB), nameID)
+	}
+	return &iidInfo, nil
+}
+
+// (1) check the VPC and Target VMs are registered in Spider
+// (2) check exist(NameID)
+// (3) generate SP-XID and create reqIID, driverIID
+// (4) create Resource
+// (5) insert spiderIID
+// (6) create userIID
+func CreateALB(connectionName string, rsType string, reqInfo cres.ALBInfo, IDTransformMode string) (*cres.ALBInfo, error) {
+	cblog.Info("call CreateALB()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	reqInfo.IId.NameId, err = EmptyCheckAndTrim("reqInfo.IId.NameId", reqInfo.IId.NameId)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// Protocol, Type: to upper
+	transformALBArgsToUpper(&reqInfo)
+
+	err = validateALBReqInfo(&reqInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	vpcSPLock.RLock(connectionName, reqInfo.VpcIID.NameId)
+	defer vpcSPLock.RUnlock(connectionName, reqInfo.VpcIID.NameId)
+
+	// (1) check the VPC and Target VMs are registered in Spider
+	var vpcIIDInfo VPCIIDInfo
+	err = infostore.GetByConditions(&vpcIIDInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, reqInfo.VpcIID.NameId)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	reqInfo.VpcIID = getDriverIID(cres.IID{NameId: vpcIIDInfo.NameId, SystemId: vpcIIDInfo.SystemId})
+
+	for _, tg := range reqInfo.TargetGroups {
+		err = setTargetVMDriverIIDs(connectionName, tg.VMs)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateALBHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	albSPLock.Lock(connectionName, reqInfo.IId.NameId)
+	defer albSPLock.Unlock(connectionName, reqInfo.IId.NameId)
+
+	// (2) check exist(NameID)
+	bool_ret, err := infostore.HasByConditions(&ALBIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, reqInfo.IId.NameId)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if bool_ret {
+		err := fmt.Errorf(rsType + "-" + reqInfo.IId.NameId + " already exists!")
+		cblog.Error(err)
+		return nil, err
+	}
+
+	spUUID := ""
+	if GetID_MGMT(IDTransformMode) == "ON" { // Use IID Management
+		// (3) generate SP-XID and create reqIID, driverIID
+		spUUID, err = iidm.New(connectionName, rsType, reqInfo.IId.NameId)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	} else { // No Use IID Management
+		spUUID = reqInfo.IId.NameId
+	}
+
+	// reqIID
+	reqIId := cres.IID{NameId: reqInfo.IId.NameId, SystemId: spUUID}
+	// driverIID
+	driverIId := cres.IID{NameId: spUUID, SystemId: ""}
+	reqInfo.IId = driverIId
+
+	// get Provider Name
+	providerName, err := ccm.GetProviderNameByConnectionName(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	// set default configuration of HealthCheckers
+	for idx := range reqInfo.TargetGroups {
+		setDefaultHealthCheckerConfig(providerName, &reqInfo.TargetGroups[idx].HealthChecker)
+	}
+
+	// (4) create Resource
+	info, err := handler.CreateALB(reqInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	transformALBArgsToUpper(&info)
+
+	// create spiderIID: {reqNameID, "driverNameID:driverSystemID"}
+	spiderIId := cres.IID{NameId: reqIId.NameId, SystemId: spUUID + ":" + info.IId.SystemId}
+
+	// (5) insert spiderIID
+	iidInfo := ALBIIDInfo{ConnectionName: connectionName, NameId: spiderIId.NameId, SystemId: spiderIId.SystemId,
+		OwnerVPCName: vpcIIDInfo.NameId}
+	err = infostore.Insert(&iidInfo)
+	if err != nil {
+		cblog.Error(err)
+		// rollback
+		cblog.Info("<<ROLLBACK:TRY:ALB-CSP>> " + info.IId.SystemId)
+		_, err2 := handler.DeleteALB(info.IId)
+		if err2 != nil {
+			cblog.Error(err2)
+			return nil, fmt.Errorf(err.Error() + ", " + err2.Error())
+		}
+		return nil, err
+	}
+
+	// (6) create userIID: {reqNameID, driverSystemID}
+	err = setALBUserIIDs(&iidInfo, &info)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// register the ALB's DNSName(or IP) with the DNSName tag
+	albTarget := info.DNSName
+	if albTarget == "" {
+		albTarget = info.IP
+	}
+	autoUpsertDNSRecord(connectionName, reqInfo.TagList, albTarget)
+
+	return &info, nil
+}
+
+func ListALB(connectionName string, rsType string) ([]*cres.ALBInfo, error) {
+	cblog.Info("call ListALB()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateALBHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (1) get IID:list
+	var iidInfoList []*ALBIIDInfo
+	err = infostore.ListByCondition(&iidInfoList, CONNECTION_NAME_COLUMN, connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	infoList := []*cres.ALBInfo{}
+	if len(iidInfoList) <= 0 {
+		return infoList, nil
+	}
+
+	// (2) Get ALBInfo-list with IID-list
+	for _, iidInfo := range iidInfoList {
+
+		albSPLock.RLock(connectionName, iidInfo.NameId)
+
+		info, err := handler.GetALB(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}))
+		if err != nil {
+			albSPLock.RUnlock(connectionName, iidInfo.NameId)
+			if checkNotFoundError(err) {
+				cblog.Info(err)
+				continue
+			}
+			cblog.Error(err)
+			return nil, err
+		}
+		albSPLock.RUnlock(connectionName, iidInfo.NameId)
+		transformALBArgsToUpper(&info)
+
+		// (3) set ResourceInfo(IID.NameId)
+		err = setALBUserIIDs(iidInfo, &info)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+
+		infoList = append(infoList, &info)
+	}
+
+	return infoList, nil
+}
+
+// (1) get IID(NameId)
+// (2) get resource(SystemId)
+// (3) set ResourceInfo(IID.NameId)
+func GetALB(connectionName string, rsType string, nameID string) (*cres.ALBInfo, error) {
+	cblog.Info("call GetALB()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateALBHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	albSPLock.RLock(connectionName, nameID)
+	defer albSPLock.RUnlock(connectionName, nameID)
+
+	// (1) get IID(NameId)
+	iidInfo, err := getALBIIDInfo(connectionName, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) get resource(SystemId)
+	info, err := handler.GetALB(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}))
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	transformALBArgsToUpper(&info)
+
+	// (3) set ResourceInfo(IID.NameId)
+	err = setALBUserIIDs(iidInfo, &info)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// (1) check exist(NameID) and VMs
+// (2) add VMs to the Target Group
+// (3) Get ALBInfo
+func AddALBTargetVMs(connectionName string, albName string, targetGroupName string, vmNames []string) (*cres.ALBInfo, error) {
+	cblog.Info("call AddALBTargetVMs()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	albName, err = EmptyCheckAndTrim("albName", albName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	targetGroupName, err = EmptyCheckAndTrim("targetGroupName", targetGroupName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if len(vmNames) == 0 {
+		err := fmt.Errorf("VM list is empty!")
+		cblog.Error(err)
+		return nil, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateALBHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	albSPLock.Lock(connectionName, albName)
+	defer albSPLock.Unlock(connectionName, albName)
+
+	// (1) check exist(NameID) and VMs
+	iidInfo, err := getALBIIDInfo(connectionName, albName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	vmIIDs := []cres.IID{}
+	for _, one := range vmNames {
+		vmIIDs = append(vmIIDs, cres.IID{NameId: one})
+	}
+	err = setTargetVMDriverIIDs(connectionName, &vmIIDs)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) add VMs to the Target Group
+	albDriverIID := getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+	_, err = handler.AddTargetVMs(albDriverIID, targetGroupName, &vmIIDs)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (3) Get ALBInfo
+	info, err := handler.GetALB(albDriverIID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	transformALBArgsToUpper(&info)
+
+	err = setALBUserIIDs(iidInfo, &info)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// (1) check exist(NameID) and VMs
+// (2) remove VMs from the Target Group
+func RemoveALBTargetVMs(connectionName string, albName string, targetGroupName string, vmNames []string) (bool, error) {
+	cblog.Info("call RemoveALBTargetVMs()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	albName, err = EmptyCheckAndTrim("albName", albName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	targetGroupName, err = EmptyCheckAndTrim("targetGroupName", targetGroupName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	if len(vmNames) == 0 {
+		err := fmt.Errorf("VM list is empty!")
+		cblog.Error(err)
+		return false, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	handler, err := cldConn.CreateALBHandler()
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	albSPLock.Lock(connectionName, albName)
+	defer albSPLock.Unlock(connectionName, albName)
+
+	// (1) check exist(NameID) and VMs
+	iidInfo, err := getALBIIDInfo(connectionName, albName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	vmIIDs := []cres.IID{}
+	for _, one := range vmNames {
+		vmIIDs = append(vmIIDs, cres.IID{NameId: one})
+	}
+	err = setTargetVMDriverIIDs(connectionName, &vmIIDs)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	// (2) remove VMs from the Target Group
+	result, err := handler.RemoveTargetVMs(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}),
+		targetGroupName, &vmIIDs)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	return result, nil
+}
+
+// (1) get IID(NameId)
+// (2) get HealthInfo of the Target Group
+// (3) set VM's UserIID
+func GetALBTargetGroupHealthInfo(connectionName string, albName string, targetGroupName string) (*cres.HealthInfo, error) {
+	cblog.Info("call GetALBTargetGroupHealthInfo()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	albName, err = EmptyCheckAndTrim("albName", albName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	targetGroupName, err = EmptyCheckAndTrim("targetGroupName", targetGroupName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateALBHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	albSPLock.RLock(connectionName, albName)
+	defer albSPLock.RUnlock(connectionName, albName)
+
+	// (1) get IID(NameId)
+	iidInfo, err := getALBIIDInfo(connectionName, albName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) get HealthInfo of the Target Group
+	healthInfo, err := handler.GetTargetGroupHealthInfo(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}),
+		targetGroupName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (3) set VM's UserIID
+	err = setVMUserIIDwithSystemId(connectionName, albName, &healthInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return &healthInfo, nil
+}
+
+// (1) get spiderIID for creating driverIID
+// (2) delete Resource(SystemId)
+// (3) delete IID
+func DeleteALB(connectionName string, rsType string, nameID string, force string) (bool, error) {
+	cblog.Info("call DeleteALB()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	handler, err := cldConn.CreateALBHandler()
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	albSPLock.Lock(connectionName, nameID)
+	defer albSPLock.Unlock(connectionName, nameID)
+
+	// (1) get spiderIID for creating driverIID
+	iidInfo, err := getALBIIDInfo(connectionName, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	// (2) delete Resource(SystemId)
+	driverIId := getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+	result, err := handler.DeleteALB(driverIId)
+	if err != nil {
+		cblog.Error(err)
+		if force != "true" {
+			return false, err
+		}
+	}
+
+	if force != "true" {
+		if !result {
+			return result, nil
+		}
+	}
+
+	// (3) delete IID
+	_, err = infostore.DeleteByConditions(&ALBIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+	if err != nil {
+		cblog.Error(err)
+		if force != "true" {
+			return false, err
+		}
+	}
+
+	return result, nil
+}
+
+func CountAllALBs() (int64, error) {
+	var info ALBIIDInfo
+	count, err := infostore.CountAllNameIDs(&info)
+	if err != nil {
+		cblog.Error(err)
+		return count, err
+	}
+
+	return count, nil
+}
+
+func CountALBsByConnection(connectionName string) (int64, error) {
+	var info ALBIIDInfo
+	count, err := infostore.CountNameIDsByConnection(&info, connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return count, err
+	}
+
+	return count, nil
+}
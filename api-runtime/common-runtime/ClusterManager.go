@@ -958,6 +958,7 @@ func AddNodeGroup(connectionName string, rsType string, clusterName string, reqI
 		cblog.Error(err)
 		return nil, err
 	}
+	invalidateResourceSnapshot(connectionName, CLUSTER, clusterName)
 
 	ngSpiderIId := cres.IID{NameId: nodeGroupNameId, SystemId: nodeGroupUUID + ":" + ngInfo.IId.SystemId}
 	err2 := infostore.Insert(&NodeGroupIIDInfo{ConnectionName: connectionName, NameId: ngSpiderIId.NameId, SystemId: ngSpiderIId.SystemId,
@@ -1091,6 +1092,7 @@ func SetNodeGroupAutoScaling(connectionName string, clusterName string, nodeGrou
 		cblog.Error(err)
 		return false, err
 	}
+	invalidateResourceSnapshot(connectionName, CLUSTER, clusterName)
 
 	return boolRet, nil
 }
@@ -1208,6 +1210,7 @@ func ChangeNodeGroupScaling(connectionName string, clusterName string, nodeGroup
 		cblog.Error(err)
 		return cres.NodeGroupInfo{}, err
 	}
+	invalidateResourceSnapshot(connectionName, CLUSTER, clusterName)
 
 	// ++++++++++++++++++
 	// (1) NodeGroup IID
@@ -1286,6 +1289,7 @@ func RemoveNodeGroup(connectionName string, clusterName string, nodeGroupName st
 			return false, err
 		}
 	}
+	invalidateResourceSnapshot(connectionName, CLUSTER, clusterName)
 
 	if force != "true" {
 		if !result {
@@ -1356,6 +1360,7 @@ func RemoveCSPNodeGroup(connectionName string, clusterName string, systemID stri
 		cblog.Error(err)
 		return false, err
 	}
+	invalidateResourceSnapshot(connectionName, CLUSTER, clusterName)
 
 	return result, nil
 }
@@ -1410,6 +1415,7 @@ func UpgradeCluster(connectionName string, clusterName string, newVersion string
 		cblog.Error(err)
 		return cres.ClusterInfo{}, err
 	}
+	invalidateResourceSnapshot(connectionName, CLUSTER, clusterName)
 
 	// ++++++++++++++++++
 	// set ClusterIID
@@ -44,6 +44,12 @@ const (
 	MYIMAGE   string = string(cres.MYIMAGE)
 	CLUSTER   string = string(cres.CLUSTER)
 	NODEGROUP string = string(cres.NODEGROUP)
+
+	VPCPEERING string = string(cres.VPCPEERING)
+	NATGATEWAY string = string(cres.NATGATEWAY)
+	DNSZONE    string = string(cres.DNSZONE)
+	ALB        string = string(cres.ALB)
+	VPNGATEWAY string = string(cres.VPNGATEWAY)
 )
 
 func RSTypeString(rsType string) string {
@@ -59,6 +65,11 @@ var nlbSPLock = splock.New()
 var diskSPLock = splock.New()
 var myImageSPLock = splock.New()
 var clusterSPLock = splock.New()
+var vpcPeeringSPLock = splock.New()
+var natGatewaySPLock = splock.New()
+var dnsZoneSPLock = splock.New()
+var albSPLock = splock.New()
+var vpnSPLock = splock.New()
 
 // ====================================================================
 // Common column name and struct for GORM
@@ -331,6 +342,7 @@ func UnregisterResource(connectionName string, rsType string, nameId string) (bo
 			cblog.Error(err)
 			return false, err
 		}
+		deleteVPCCIDRs(connectionName, nameId)
 		return true, nil
 
 	case KEY:
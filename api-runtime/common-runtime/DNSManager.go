@@ -0,0 +1,731 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package commonruntime
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	iidm "github.com/cloud-barista/cb-spider/cloud-control-manager/iid-manager"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+// ====================================================================
+// type for GORM
+
+type DNSZoneIIDInfo FirstIIDInfo
+
+func (DNSZoneIIDInfo) TableName() string {
+	return "dns_zone_iid_infos"
+}
+
+//====================================================================
+
+// Records are not registered in Spider.
+// They are handled as a part of the Zone, so the Zone SPLock is used.
+
+// VMs and NLBs created with this tag get a record in the Zone of the tag value's domain,
+// when SPIDER_DNS_AUTO_RECORD=ON. ex) {"Key": "DNSName", "Value": "web.example.com"}
+const DNS_NAME_TAG_KEY = "DNSName"
+
+const DEFAULT_DNS_RECORD_TTL = 300
+
+func init() {
+	db, err := infostore.Open()
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	db.AutoMigrate(&DNSZoneIIDInfo{})
+	infostore.Close(db)
+}
+
+//================ DNS Handler
+
+func normalizeDomainName(domainName string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domainName)), ".")
+}
+
+// get a FQDN of the record name, "@" or "" means the apex of the Zone.
+// ex) "www" => "www.example.com", "www.example.com." => "www.example.com"
+func normalizeDNSRecordName(domainName string, name string) (string, error) {
+	name = normalizeDomainName(name)
+	if name == "" || name == "@" {
+		return domainName, nil
+	}
+	if name == domainName || strings.HasSuffix(name, "."+domainName) {
+		return name, nil
+	}
+	if strings.Contains(name, ".") {
+		return "", fmt.Errorf("The record name '%s' is not in the Zone '%s'!", name, domainName)
+	}
+	return name + "." + domainName, nil
+}
+
+// check the record and set up the default values
+func validateDNSRecord(domainName string, record *cres.DNSRecordInfo) error {
+	name, err := normalizeDNSRecordName(domainName, record.Name)
+	if err != nil {
+		return err
+	}
+	record.Name = name
+	record.Type = cres.DNSRecordType(strings.ToUpper(strings.TrimSpace(string(record.Type))))
+
+	if record.TTL == 0 {
+		record.TTL = DEFAULT_DNS_RECORD_TTL
+	}
+	if record.TTL < 0 {
+		return fmt.Errorf("Invalid TTL '%d': TTL must be a positive number!", record.TTL)
+	}
+
+	if len(record.Values) == 0 {
+		return fmt.Errorf("The record '%s' must have at least one value!", record.Name)
+	}
+
+	for idx, value := range record.Values {
+		value = strings.TrimSpace(value)
+		record.Values[idx] = value
+		switch record.Type {
+		case cres.DNSRecordA:
+			ip := net.ParseIP(value)
+			if ip == nil || ip.To4() == nil {
+				return fmt.Errorf("Invalid A record value '%s': must be an IPv4 address!", value)
+			}
+		case cres.DNSRecordAAAA:
+			ip := net.ParseIP(value)
+			if ip == nil || ip.To4() != nil {
+				return fmt.Errorf("Invalid AAAA record value '%s': must be an IPv6 address!", value)
+			}
+		case cres.DNSRecordCNAME:
+			if len(record.Values) != 1 {
+				return fmt.Errorf("The CNAME record '%s' must have only one value!", record.Name)
+			}
+			if record.Name == domainName {
+				return fmt.Errorf("The CNAME record can not be set to the apex of the Zone '%s'!", domainName)
+			}
+			record.Values[idx] = normalizeDomainName(value)
+		case cres.DNSRecordTXT:
+			if value == "" {
+				return fmt.Errorf("The TXT record '%s' has an empty value!", record.Name)
+			}
+		default:
+			return fmt.Errorf("'%s' is not a valid record Type! (A | AAAA | CNAME | TXT)", record.Type)
+		}
+	}
+	return nil
+}
+
+// (1) check exist(NameID)
+// (2) generate SP-XID and create reqIID, driverIID
+// (3) create Resource
+// (4) insert spiderIID
+// (5) create userIID
+func CreateDNSZone(connectionName string, rsType string, reqInfo cres.DNSZoneReqInfo, IDTransformMode string) (*cres.DNSZoneInfo, error) {
+	cblog.Info("call CreateDNSZone()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	emptyPermissionList := []string{
+		"resources.IID:SystemId",
+	}
+
+	err = ValidateStruct(reqInfo, emptyPermissionList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	reqInfo.DomainName = normalizeDomainName(reqInfo.DomainName)
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateDNSHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	dnsZoneSPLock.Lock(connectionName, reqInfo.IId.NameId)
+	defer dnsZoneSPLock.Unlock(connectionName, reqInfo.IId.NameId)
+
+	// (1) check exist(NameID)
+	bool_ret, err := infostore.HasByConditions(&DNSZoneIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, reqInfo.IId.NameId)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if bool_ret {
+		err := fmt.Errorf(rsType + "-" + reqInfo.IId.NameId + " already exists!")
+		cblog.Error(err)
+		return nil, err
+	}
+
+	spUUID := ""
+	if GetID_MGMT(IDTransformMode) == "ON" { // Use IID Management
+		// (2) generate SP-XID and create reqIID, driverIID
+		spUUID, err = iidm.New(connectionName, rsType, reqInfo.IId.NameId)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	} else { // No Use IID Management
+		spUUID = reqInfo.IId.NameId
+	}
+
+	// reqIID
+	reqIId := cres.IID{NameId: reqInfo.IId.NameId, SystemId: spUUID}
+	// driverIID
+	driverIId := cres.IID{NameId: spUUID, SystemId: ""}
+	reqInfo.IId = driverIId
+
+	// (3) create Resource
+	info, err := handler.CreateZone(reqInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// create spiderIID: {reqNameID, "driverNameID:driverSystemID"}
+	spiderIId := cres.IID{NameId: reqIId.NameId, SystemId: spUUID + ":" + info.IId.SystemId}
+
+	// (4) insert spiderIID
+	iidInfo := DNSZoneIIDInfo{ConnectionName: connectionName, NameId: spiderIId.NameId, SystemId: spiderIId.SystemId}
+	err = infostore.Insert(&iidInfo)
+	if err != nil {
+		cblog.Error(err)
+		// rollback
+		cblog.Info("<<ROLLBACK:TRY:DNSZONE-CSP>> " + info.IId.SystemId)
+		_, err2 := handler.DeleteZone(info.IId)
+		if err2 != nil {
+			cblog.Error(err2)
+			return nil, fmt.Errorf(err.Error() + ", " + err2.Error())
+		}
+		return nil, err
+	}
+
+	// (5) create userIID: {reqNameID, driverSystemID}
+	info.IId = getUserIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+
+	return &info, nil
+}
+
+func ListDNSZone(connectionName string, rsType string) ([]*cres.DNSZoneInfo, error) {
+	cblog.Info("call ListDNSZone()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateDNSHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (1) get IID:list
+	var iidInfoList []*DNSZoneIIDInfo
+	err = infostore.ListByCondition(&iidInfoList, CONNECTION_NAME_COLUMN, connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	infoList := []*cres.DNSZoneInfo{}
+	if len(iidInfoList) <= 0 {
+		return infoList, nil
+	}
+
+	// (2) Get DNSZoneInfo-list with IID-list
+	for _, iidInfo := range iidInfoList {
+
+		dnsZoneSPLock.RLock(connectionName, iidInfo.NameId)
+
+		info, err := handler.GetZone(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}))
+		if err != nil {
+			dnsZoneSPLock.RUnlock(connectionName, iidInfo.NameId)
+			if checkNotFoundError(err) {
+				cblog.Info(err)
+				continue
+			}
+			cblog.Error(err)
+			return nil, err
+		}
+		dnsZoneSPLock.RUnlock(connectionName, iidInfo.NameId)
+
+		// (3) set ResourceInfo(IID.NameId)
+		info.IId = getUserIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+
+		infoList = append(infoList, &info)
+	}
+
+	return infoList, nil
+}
+
+// (1) get IID(NameId)
+// (2) get resource(SystemId)
+// (3) set ResourceInfo(IID.NameId)
+func GetDNSZone(connectionName string, rsType string, nameID string) (*cres.DNSZoneInfo, error) {
+	cblog.Info("call GetDNSZone()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateDNSHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	dnsZoneSPLock.RLock(connectionName, nameID)
+	defer dnsZoneSPLock.RUnlock(connectionName, nameID)
+
+	// (1) get IID(NameId)
+	var iidInfo DNSZoneIIDInfo
+	err = infostore.GetByConditions(&iidInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) get resource(SystemId)
+	info, err := handler.GetZone(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}))
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (3) set ResourceInfo(IID.NameId)
+	info.IId = getUserIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+
+	return &info, nil
+}
+
+// (1) get spiderIID for creating driverIID
+// (2) delete Resource(SystemId)
+// (3) delete IID
+func DeleteDNSZone(connectionName string, rsType string, nameID string, force string) (bool, error) {
+	cblog.Info("call DeleteDNSZone()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	handler, err := cldConn.CreateDNSHandler()
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	dnsZoneSPLock.Lock(connectionName, nameID)
+	defer dnsZoneSPLock.Unlock(connectionName, nameID)
+
+	// (1) get spiderIID for creating driverIID
+	var iidInfo DNSZoneIIDInfo
+	err = infostore.GetByConditions(&iidInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	// (2) delete Resource(SystemId)
+	driverIId := getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+	result, err := handler.DeleteZone(driverIId)
+	if err != nil {
+		cblog.Error(err)
+		if force != "true" {
+			return false, err
+		}
+	}
+
+	if force != "true" {
+		if !result {
+			return result, nil
+		}
+	}
+
+	// (3) delete IID
+	_, err = infostore.DeleteByConditions(&DNSZoneIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+	if err != nil {
+		cblog.Error(err)
+		if force != "true" {
+			return false, err
+		}
+	}
+
+	return result, nil
+}
+
+// get the DNS handler, the Zone's driver IID and the Zone's domain name.
+// The caller must hold the Zone SPLock.
+func getDNSZoneForRecord(connectionName string, zoneName string) (cres.DNSHandler, cres.IID, string, error) {
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		return nil, cres.IID{}, "", err
+	}
+
+	handler, err := cldConn.CreateDNSHandler()
+	if err != nil {
+		return nil, cres.IID{}, "", err
+	}
+
+	var iidInfo DNSZoneIIDInfo
+	err = infostore.GetByConditions(&iidInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, zoneName)
+	if err != nil {
+		return nil, cres.IID{}, "", err
+	}
+
+	zoneIID := getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+	zoneInfo, err := handler.GetZone(zoneIID)
+	if err != nil {
+		return nil, cres.IID{}, "", err
+	}
+	return handler, zoneIID, normalizeDomainName(zoneInfo.DomainName), nil
+}
+
+func ListDNSRecord(connectionName string, zoneName string) ([]*cres.DNSRecordInfo, error) {
+	cblog.Info("call ListDNSRecord()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	zoneName, err = EmptyCheckAndTrim("zoneName", zoneName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	dnsZoneSPLock.RLock(connectionName, zoneName)
+	defer dnsZoneSPLock.RUnlock(connectionName, zoneName)
+
+	handler, zoneIID, _, err := getDNSZoneForRecord(connectionName, zoneName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	infoList, err := handler.ListRecord(zoneIID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if infoList == nil {
+		infoList = []*cres.DNSRecordInfo{}
+	}
+	return infoList, nil
+}
+
+func GetDNSRecord(connectionName string, zoneName string, recordName string, recordType string) (*cres.DNSRecordInfo, error) {
+	cblog.Info("call GetDNSRecord()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	zoneName, err = EmptyCheckAndTrim("zoneName", zoneName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	recordType, err = EmptyCheckAndTrim("recordType", recordType)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	dnsZoneSPLock.RLock(connectionName, zoneName)
+	defer dnsZoneSPLock.RUnlock(connectionName, zoneName)
+
+	handler, zoneIID, domainName, err := getDNSZoneForRecord(connectionName, zoneName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	recordName, err = normalizeDNSRecordName(domainName, recordName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	info, err := handler.GetRecord(zoneIID, recordName, cres.DNSRecordType(strings.ToUpper(recordType)))
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// (1) check the record: name in the Zone, type, values and TTL
+// (2) create or replace the record
+func UpsertDNSRecord(connectionName string, zoneName string, record cres.DNSRecordInfo) (*cres.DNSRecordInfo, error) {
+	cblog.Info("call UpsertDNSRecord()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	zoneName, err = EmptyCheckAndTrim("zoneName", zoneName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	dnsZoneSPLock.Lock(connectionName, zoneName)
+	defer dnsZoneSPLock.Unlock(connectionName, zoneName)
+
+	handler, zoneIID, domainName, err := getDNSZoneForRecord(connectionName, zoneName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (1) check the record: name in the Zone, type, values and TTL
+	err = validateDNSRecord(domainName, &record)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) create or replace the record
+	info, err := handler.UpsertRecord(zoneIID, record)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+func DeleteDNSRecord(connectionName string, zoneName string, recordName string, recordType string) (bool, error) {
+	cblog.Info("call DeleteDNSRecord()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	zoneName, err = EmptyCheckAndTrim("zoneName", zoneName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	recordType, err = EmptyCheckAndTrim("recordType", recordType)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	dnsZoneSPLock.Lock(connectionName, zoneName)
+	defer dnsZoneSPLock.Unlock(connectionName, zoneName)
+
+	handler, zoneIID, domainName, err := getDNSZoneForRecord(connectionName, zoneName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	recordName, err = normalizeDNSRecordName(domainName, recordName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	result, err := handler.DeleteRecord(zoneIID, recordName, cres.DNSRecordType(strings.ToUpper(recordType)))
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	return result, nil
+}
+
+func CountAllDNSZones() (int64, error) {
+	var info DNSZoneIIDInfo
+	count, err := infostore.CountAllNameIDs(&info)
+	if err != nil {
+		cblog.Error(err)
+		return count, err
+	}
+
+	return count, nil
+}
+
+func CountDNSZonesByConnection(connectionName string) (int64, error) {
+	var info DNSZoneIIDInfo
+	count, err := infostore.CountNameIDsByConnection(&info, connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return count, err
+	}
+
+	return count, nil
+}
+
+//================ DNS Auto Record
+
+func isDNSAutoRecordOn() bool {
+	return strings.ToUpper(os.Getenv("SPIDER_DNS_AUTO_RECORD")) == "ON"
+}
+
+// get the value of the DNSName tag
+func getDNSNameTag(tagList []cres.KeyValue) string {
+	for _, tag := range tagList {
+		if strings.EqualFold(tag.Key, DNS_NAME_TAG_KEY) {
+			return normalizeDomainName(tag.Value)
+		}
+	}
+	return ""
+}
+
+// find the Zone of the connection with the longest domain matched with the dnsName
+func findDNSZoneNameByDNSName(connectionName string, dnsName string) (string, error) {
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		return "", err
+	}
+
+	handler, err := cldConn.CreateDNSHandler()
+	if err != nil {
+		return "", err
+	}
+
+	var iidInfoList []*DNSZoneIIDInfo
+	err = infostore.ListByCondition(&iidInfoList, CONNECTION_NAME_COLUMN, connectionName)
+	if err != nil {
+		return "", err
+	}
+
+	zoneName := ""
+	matchedDomain := ""
+	for _, iidInfo := range iidInfoList {
+		zoneInfo, err := handler.GetZone(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}))
+		if err != nil {
+			cblog.Info(err)
+			continue
+		}
+		domainName := normalizeDomainName(zoneInfo.DomainName)
+		if dnsName != domainName && !strings.HasSuffix(dnsName, "."+domainName) {
+			continue
+		}
+		if len(domainName) > len(matchedDomain) {
+			zoneName = iidInfo.NameId
+			matchedDomain = domainName
+		}
+	}
+
+	if zoneName == "" {
+		return "", fmt.Errorf("There is no DNS Zone for the DNSName '%s' in the connection '%s'!", dnsName, connectionName)
+	}
+	return zoneName, nil
+}
+
+// Upsert a record for a new VM or NLB with the DNSName tag, when SPIDER_DNS_AUTO_RECORD=ON.
+// The record type is decided by the target: IPv4 => A, IPv6 => AAAA, domain name => CNAME.
+// The resource was already created, so errors are only logged.
+func autoUpsertDNSRecord(connectionName string, tagList []cres.KeyValue, target string) {
+	if !isDNSAutoRecordOn() {
+		return
+	}
+
+	dnsName := getDNSNameTag(tagList)
+	if dnsName == "" || target == "" {
+		return
+	}
+
+	recordType := cres.DNSRecordCNAME
+	if ip := net.ParseIP(target); ip != nil {
+		recordType = cres.DNSRecordA
+		if ip.To4() == nil {
+			recordType = cres.DNSRecordAAAA
+		}
+	}
+
+	zoneName, err := findDNSZoneNameByDNSName(connectionName, dnsName)
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+
+	record := cres.DNSRecordInfo{Name: dnsName, Type: recordType, TTL: DEFAULT_DNS_RECORD_TTL, Values: []string{target}}
+	_, err = UpsertDNSRecord(connectionName, zoneName, record)
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	cblog.Infof("DNS Record %s(%s) => %s is upserted in the Zone '%s'", dnsName, recordType, target, zoneName)
+}
@@ -453,6 +453,7 @@ func ChangeDiskSize(connectionName string, diskName string, size string) (bool,
 		cblog.Error(err)
 		return false, err
 	}
+	invalidateResourceSnapshot(connectionName, DISK, diskName)
 
 	return info, nil
 }
@@ -528,6 +529,8 @@ func AttachDisk(connectionName string, diskName string, ownerVMName string) (*cr
 		cblog.Error(err)
 		return nil, err
 	}
+	invalidateResourceSnapshot(connectionName, DISK, diskName)
+	invalidateResourceSnapshot(connectionName, VM, ownerVMName)
 
 	// (3) set ResourceInfo(userIID)
 	info.IId = getUserIID(cres.IID{NameId: diskIIDInfo.NameId, SystemId: diskIIDInfo.SystemId})
@@ -602,6 +605,8 @@ func DetachDisk(connectionName string, diskName string, ownerVMName string) (boo
 		cblog.Error(err)
 		return false, err
 	}
+	invalidateResourceSnapshot(connectionName, DISK, diskName)
+	invalidateResourceSnapshot(connectionName, VM, ownerVMName)
 
 	return info, nil
 }
@@ -0,0 +1,478 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package commonruntime
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+// ====================================================================
+// type for GORM
+
+// ResourceSnapshotInfo keeps the last-known *Info of a managed resource.
+type ResourceSnapshotInfo struct {
+	ConnectionName string `gorm:"primaryKey"` // ex) "aws-seoul-config"
+	ResourceType   string `gorm:"primaryKey"` // ex) "vm"
+	NameId         string `gorm:"primaryKey"` // ex) "my_resource"
+	SystemId       string // ID in CSP of the snapshot, a new resource with the same name is re-baselined
+	Snapshot       string // JSON of the *Info, ex) cres.VMInfo
+	SnapshotTime   time.Time
+}
+
+func (ResourceSnapshotInfo) TableName() string {
+	return "resource_snapshot_infos"
+}
+
+//====================================================================
+
+func init() {
+	db, err := infostore.Open()
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	db.AutoMigrate(&ResourceSnapshotInfo{})
+	infostore.Close(db)
+}
+
+const RESOURCE_TYPE_COLUMN = "resource_type"
+
+// Resource types to detect the drift
+var DRIFT_RESOURCE_TYPES = []string{VPC, SG, KEY, VM, DISK, MYIMAGE, NLB, CLUSTER}
+
+// volatile fields which can be changed without a user's change, not compared
+var driftIgnoreFields = map[string]bool{
+	"KeyValueList":    true, // CSP's raw info
+	"Status":          true,
+	"CreatedTime":     true,
+	"StartTime":       true,
+	"ReadinessStatus": true,
+	"AccessInfo":      true, // Cluster's endpoint and kubeconfig
+	"Nodes":           true, // NodeGroup's nodes by auto-scaling
+}
+
+// Drift status of a resource
+const (
+	DRIFT_NONE      = "NoDrift"
+	DRIFT_DETECTED  = "Drifted"
+	DRIFT_BASELINED = "Baselined" // no snapshot yet, the current state is saved as the snapshot
+	DRIFT_ERROR     = "Error"
+)
+
+// DriftFieldInfo represents a changed field, values are JSON strings.
+type DriftFieldInfo struct {
+	Field    string `json:"Field" validate:"required" example:"SecurityRules"` // ex) "VMSpecName", "HealthChecker.Port"
+	Snapshot string `json:"Snapshot" validate:"required" example:"\"t3.micro\""`
+	Current  string `json:"Current" validate:"required" example:"\"t3.small\""`
+}
+
+// DriftResourceInfo represents the drift of a managed resource.
+type DriftResourceInfo struct {
+	ResourceType string           `json:"ResourceType" validate:"required" example:"vm"`
+	NameId       string           `json:"NameId" validate:"required" example:"vm-01"`
+	Status       string           `json:"Status" validate:"required" example:"Drifted"` // NoDrift | Drifted | Baselined | Error
+	SnapshotTime time.Time        `json:"SnapshotTime,omitempty" example:"2024-10-20T10:00:00Z"`
+	FieldList    []DriftFieldInfo `json:"FieldList,omitempty" validate:"omitempty"`
+	ErrorMSG     string           `json:"ErrorMSG,omitempty" validate:"omitempty"`
+}
+
+// DriftReportInfo represents the drift report of a connection.
+type DriftReportInfo struct {
+	ConnectionName string               `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	CheckedTime    time.Time            `json:"CheckedTime" validate:"required" example:"2024-10-20T10:00:00Z"`
+	HasDrift       bool                 `json:"HasDrift" validate:"required" example:"true"`
+	ResourceList   []*DriftResourceInfo `json:"ResourceList" validate:"required"`
+}
+
+// DetectDrift diffs the current CSP state of managed resources against the snapshots field by field.
+// (1) get the NameId list of managed resources
+// (2) get the current *Info of each resource
+// (3) save the current *Info as the snapshot, if no snapshot or a new resource(SystemId)
+// (4) diff the current *Info against the snapshot
+// (5) delete the snapshots of unmanaged resources
+func DetectDrift(connectionName string, rsTypeList []string) (*DriftReportInfo, error) {
+	cblog.Info("call DetectDrift()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if len(rsTypeList) == 0 {
+		rsTypeList = DRIFT_RESOURCE_TYPES
+	}
+	for _, rsType := range rsTypeList {
+		if getDriftIIDInfo(rsType) == nil {
+			err := fmt.Errorf("%s is not a supported Resource Type to detect the drift, use one of %s!", rsType, strings.Join(DRIFT_RESOURCE_TYPES, ", "))
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+
+	report := DriftReportInfo{ConnectionName: connectionName, CheckedTime: time.Now(), ResourceList: []*DriftResourceInfo{}}
+	for _, rsType := range rsTypeList {
+		// (1) get the NameId list of managed resources
+		nameIdList, err := infostore.ListNameIDByConnection(getDriftIIDInfo(rsType), connectionName)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+
+		for _, nameId := range nameIdList {
+			rsInfo := detectResourceDrift(connectionName, rsType, nameId)
+			if rsInfo.Status == DRIFT_DETECTED {
+				report.HasDrift = true
+			}
+			report.ResourceList = append(report.ResourceList, rsInfo)
+		}
+
+		// (5) delete the snapshots of unmanaged resources
+		err = pruneResourceSnapshots(connectionName, rsType, nameIdList)
+		if err != nil {
+			cblog.Error(err)
+		}
+	}
+
+	return &report, nil
+}
+
+func detectResourceDrift(connectionName string, rsType string, nameId string) *DriftResourceInfo {
+	rsInfo := DriftResourceInfo{ResourceType: rsType, NameId: nameId}
+
+	// (2) get the current *Info of each resource
+	current, systemId, err := getCurrentResourceInfo(connectionName, rsType, nameId)
+	if err != nil {
+		cblog.Error(err)
+		rsInfo.Status = DRIFT_ERROR
+		rsInfo.ErrorMSG = err.Error()
+		return &rsInfo
+	}
+
+	// (3) save the current *Info as the snapshot, if no snapshot or a new resource(SystemId)
+	var snapshot ResourceSnapshotInfo
+	err = infostore.GetBy3Conditions(&snapshot, CONNECTION_NAME_COLUMN, connectionName, RESOURCE_TYPE_COLUMN, rsType, NAME_ID_COLUMN, nameId)
+	if err != nil || snapshot.SystemId != systemId {
+		snapshotTime, err := saveResourceSnapshot(connectionName, rsType, nameId, systemId, current)
+		if err != nil {
+			cblog.Error(err)
+			rsInfo.Status = DRIFT_ERROR
+			rsInfo.ErrorMSG = err.Error()
+			return &rsInfo
+		}
+		rsInfo.Status = DRIFT_BASELINED
+		rsInfo.SnapshotTime = snapshotTime
+		return &rsInfo
+	}
+
+	// (4) diff the current *Info against the snapshot
+	rsInfo.SnapshotTime = snapshot.SnapshotTime
+	fieldList, err := diffResourceSnapshot(snapshot.Snapshot, current)
+	if err != nil {
+		cblog.Error(err)
+		rsInfo.Status = DRIFT_ERROR
+		rsInfo.ErrorMSG = err.Error()
+		return &rsInfo
+	}
+	if len(fieldList) > 0 {
+		rsInfo.Status = DRIFT_DETECTED
+		rsInfo.FieldList = fieldList
+	} else {
+		rsInfo.Status = DRIFT_NONE
+	}
+	return &rsInfo
+}
+
+// UpdateResourceSnapshot accepts the current CSP state of a resource as the new snapshot.
+func UpdateResourceSnapshot(connectionName string, rsType string, nameId string) (*DriftResourceInfo, error) {
+	cblog.Info("call UpdateResourceSnapshot()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	nameId, err = EmptyCheckAndTrim("nameId", nameId)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if getDriftIIDInfo(rsType) == nil {
+		err := fmt.Errorf("%s is not a supported Resource Type to detect the drift, use one of %s!", rsType, strings.Join(DRIFT_RESOURCE_TYPES, ", "))
+		cblog.Error(err)
+		return nil, err
+	}
+
+	current, systemId, err := getCurrentResourceInfo(connectionName, rsType, nameId)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	snapshotTime, err := saveResourceSnapshot(connectionName, rsType, nameId, systemId, current)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return &DriftResourceInfo{ResourceType: rsType, NameId: nameId, Status: DRIFT_BASELINED, SnapshotTime: snapshotTime}, nil
+}
+
+// invalidateResourceSnapshot deletes the snapshot of a resource changed by Spider,
+// the next DetectDrift() saves the changed state as the snapshot.
+func invalidateResourceSnapshot(connectionName string, rsType string, nameId string) {
+	_, err := infostore.DeleteBy3Conditions(&ResourceSnapshotInfo{}, CONNECTION_NAME_COLUMN, connectionName,
+		RESOURCE_TYPE_COLUMN, rsType, NAME_ID_COLUMN, nameId)
+	if err != nil {
+		// no snapshot is not an error
+		cblog.Info(err)
+	}
+}
+
+func getDriftIIDInfo(rsType string) interface{} {
+	switch rsType {
+	case VPC:
+		return &VPCIIDInfo{}
+	case SG:
+		return &SGIIDInfo{}
+	case KEY:
+		return &KeyIIDInfo{}
+	case VM:
+		return &VMIIDInfo{}
+	case DISK:
+		return &DiskIIDInfo{}
+	case MYIMAGE:
+		return &MyImageIIDInfo{}
+	case NLB:
+		return &NLBIIDInfo{}
+	case CLUSTER:
+		return &ClusterIIDInfo{}
+	}
+	return nil
+}
+
+// returns the current *Info and the SystemId of a managed resource
+func getCurrentResourceInfo(connectionName string, rsType string, nameId string) (interface{}, string, error) {
+	var info interface{}
+	var iid cres.IID
+	switch rsType {
+	case VPC:
+		vpcInfo, err := GetVPC(connectionName, rsType, nameId)
+		if err != nil {
+			return nil, "", err
+		}
+		info, iid = vpcInfo, vpcInfo.IId
+	case SG:
+		sgInfo, err := GetSecurity(connectionName, rsType, nameId)
+		if err != nil {
+			return nil, "", err
+		}
+		info, iid = sgInfo, sgInfo.IId
+	case KEY:
+		keyInfo, err := GetKey(connectionName, rsType, nameId)
+		if err != nil {
+			return nil, "", err
+		}
+		info, iid = keyInfo, keyInfo.IId
+	case VM:
+		vmInfo, err := GetVM(connectionName, rsType, nameId)
+		if err != nil {
+			return nil, "", err
+		}
+		info, iid = vmInfo, vmInfo.IId
+	case DISK:
+		diskInfo, err := GetDisk(connectionName, rsType, nameId)
+		if err != nil {
+			return nil, "", err
+		}
+		info, iid = diskInfo, diskInfo.IId
+	case MYIMAGE:
+		myImageInfo, err := GetMyImage(connectionName, rsType, nameId)
+		if err != nil {
+			return nil, "", err
+		}
+		info, iid = myImageInfo, myImageInfo.IId
+	case NLB:
+		nlbInfo, err := GetNLB(connectionName, rsType, nameId)
+		if err != nil {
+			return nil, "", err
+		}
+		info, iid = nlbInfo, nlbInfo.IId
+	case CLUSTER:
+		clusterInfo, err := GetCluster(connectionName, rsType, nameId)
+		if err != nil {
+			return nil, "", err
+		}
+		info, iid = clusterInfo, clusterInfo.IId
+	default:
+		return nil, "", fmt.Errorf(rsType + " is not supported Resource!!")
+	}
+	return info, iid.SystemId, nil
+}
+
+func saveResourceSnapshot(connectionName string, rsType string, nameId string, systemId string, info interface{}) (time.Time, error) {
+	jsonBytes, err := json.Marshal(info)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	snapshot := ResourceSnapshotInfo{ConnectionName: connectionName, ResourceType: rsType, NameId: nameId,
+		SystemId: systemId, Snapshot: string(jsonBytes), SnapshotTime: time.Now()}
+	err = infostore.Insert(&snapshot)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return snapshot.SnapshotTime, nil
+}
+
+func pruneResourceSnapshots(connectionName string, rsType string, nameIdList []string) error {
+	managed := map[string]bool{}
+	for _, nameId := range nameIdList {
+		managed[nameId] = true
+	}
+
+	var snapshotList []*ResourceSnapshotInfo
+	err := infostore.ListByConditions(&snapshotList, CONNECTION_NAME_COLUMN, connectionName, RESOURCE_TYPE_COLUMN, rsType)
+	if err != nil {
+		return err
+	}
+	for _, snapshot := range snapshotList {
+		if !managed[snapshot.NameId] {
+			invalidateResourceSnapshot(connectionName, rsType, snapshot.NameId)
+		}
+	}
+	return nil
+}
+
+// diffResourceSnapshot returns the changed fields between the snapshot(JSON) and the current *Info.
+func diffResourceSnapshot(snapshotJSON string, current interface{}) ([]DriftFieldInfo, error) {
+	var snapshotValue interface{}
+	if err := json.Unmarshal([]byte(snapshotJSON), &snapshotValue); err != nil {
+		return nil, err
+	}
+
+	currentBytes, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+	var currentValue interface{}
+	if err := json.Unmarshal(currentBytes, &currentValue); err != nil {
+		return nil, err
+	}
+
+	fieldList := []DriftFieldInfo{}
+	diffDriftValue("", snapshotValue, currentValue, &fieldList)
+	return fieldList, nil
+}
+
+// compare objects field by field, and lists as a set, because the CSP does not keep the order of lists
+func diffDriftValue(path string, snapshot interface{}, current interface{}, fieldList *[]DriftFieldInfo) {
+	snapshotMap, isSnapshotMap := snapshot.(map[string]interface{})
+	currentMap, isCurrentMap := current.(map[string]interface{})
+	if isSnapshotMap && isCurrentMap {
+		keyMap := map[string]bool{}
+		for key := range snapshotMap {
+			keyMap[key] = true
+		}
+		for key := range currentMap {
+			keyMap[key] = true
+		}
+		keyList := []string{}
+		for key := range keyMap {
+			if !driftIgnoreFields[key] {
+				keyList = append(keyList, key)
+			}
+		}
+		sort.Strings(keyList)
+
+		for _, key := range keyList {
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+			diffDriftValue(fieldPath, snapshotMap[key], currentMap[key], fieldList)
+		}
+		return
+	}
+
+	snapshotList, isSnapshotList := snapshot.([]interface{})
+	currentList, isCurrentList := current.([]interface{})
+	if isSnapshotList && isCurrentList {
+		if reflect.DeepEqual(getSortedDriftList(snapshotList), getSortedDriftList(currentList)) {
+			return
+		}
+	} else if reflect.DeepEqual(snapshot, current) {
+		return
+	}
+
+	// an empty list and null are the same
+	if isEmptyDriftValue(snapshot) && isEmptyDriftValue(current) {
+		return
+	}
+
+	*fieldList = append(*fieldList, DriftFieldInfo{Field: path, Snapshot: toDriftJSON(snapshot), Current: toDriftJSON(current)})
+}
+
+// returns the sorted JSON strings of a list, ignoring the volatile fields of the elements
+func getSortedDriftList(list []interface{}) []string {
+	jsonList := []string{}
+	for _, value := range list {
+		jsonList = append(jsonList, toDriftJSON(removeDriftIgnoreFields(value)))
+	}
+	sort.Strings(jsonList)
+	return jsonList
+}
+
+func removeDriftIgnoreFields(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		newMap := map[string]interface{}{}
+		for key, one := range v {
+			if !driftIgnoreFields[key] {
+				newMap[key] = removeDriftIgnoreFields(one)
+			}
+		}
+		return newMap
+	case []interface{}:
+		newList := []interface{}{}
+		for _, one := range v {
+			newList = append(newList, removeDriftIgnoreFields(one))
+		}
+		return newList
+	}
+	return value
+}
+
+func isEmptyDriftValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	list, ok := value.([]interface{})
+	return ok && len(list) == 0
+}
+
+func toDriftJSON(value interface{}) string {
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(jsonBytes)
+}
@@ -0,0 +1,407 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package commonruntime
+
+import (
+	"fmt"
+	"strings"
+
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	iidm "github.com/cloud-barista/cb-spider/cloud-control-manager/iid-manager"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+// DryRunCheckInfo represents the result of a check of a create request.
+type DryRunCheckInfo struct {
+	Name     string `json:"Name" validate:"required" example:"NameUniqueness"`
+	Passed   bool   `json:"Passed" validate:"required" example:"true"`
+	ErrorMSG string `json:"ErrorMSG,omitempty" validate:"omitempty"`
+}
+
+// DryRunReportInfo represents the report of a create request checked without calling the CSP create API.
+type DryRunReportInfo struct {
+	ResourceType string            `json:"ResourceType" validate:"required" example:"vm"`
+	Name         string            `json:"Name" validate:"required" example:"vm-01"`
+	Valid        bool              `json:"Valid" validate:"required" example:"true"`                    // true, if all checks are passed
+	DriverNameId string            `json:"DriverNameId,omitempty" example:"vm-01-csr3a0adu2e8c7ch1cgg"` // only an example, see getDryRunDriverNameId()
+	Checks       []DryRunCheckInfo `json:"Checks" validate:"required"`
+}
+
+func newDryRunReport(rsType string, nameId string) *DryRunReportInfo {
+	return &DryRunReportInfo{ResourceType: rsType, Name: nameId, Valid: true, Checks: []DryRunCheckInfo{}}
+}
+
+// addCheck appends the result of a check and returns true if the check is passed.
+func (report *DryRunReportInfo) addCheck(name string, err error) bool {
+	check := DryRunCheckInfo{Name: name, Passed: err == nil}
+	if err != nil {
+		check.ErrorMSG = err.Error()
+		report.Valid = false
+	}
+	report.Checks = append(report.Checks, check)
+	return err == nil
+}
+
+// returns the failed checks as a message, ex) "VPC: vpc-01: does not exist!; VMSpec: ..."
+func (report *DryRunReportInfo) failedChecks() string {
+	msgList := []string{}
+	for _, check := range report.Checks {
+		if !check.Passed {
+			msgList = append(msgList, check.Name+": "+check.ErrorMSG)
+		}
+	}
+	return strings.Join(msgList, "; ")
+}
+
+// returns an error if the NameId is already used in the IID table
+func checkDryRunNotExist(info interface{}, connectionName string, rsType string, nameId string) error {
+	bool_ret, err := infostore.HasByConditions(info, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameId)
+	if err != nil {
+		return err
+	}
+	if bool_ret {
+		return fmt.Errorf(rsType + "-" + nameId + " already exists!")
+	}
+	return nil
+}
+
+// returns an error if the referenced NameId does not exist in the IID table
+func checkDryRunExist(info interface{}, connectionName string, nameId string) error {
+	bool_ret, err := infostore.HasByConditions(info, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameId)
+	if err != nil {
+		return err
+	}
+	if !bool_ret {
+		return fmt.Errorf(nameId + ": does not exist!")
+	}
+	return nil
+}
+
+// returns an example driver NameId like the create request, and checks the max ID length of cloudos_meta.yaml.
+// The example is not reserved: the create request generates a new ID with a new random suffix,
+// and some resources have a random ID in the CSP, ex) Azure NodeGroup, NHN Cluster.
+func getDryRunDriverNameId(connectionName string, rsType string, nameId string, IDTransformMode string) (string, error) {
+	if GetID_MGMT(IDTransformMode) == "ON" { // Use IID Management
+		return iidm.New(connectionName, rsType, nameId)
+	}
+
+	maxLength, err := iidm.GetIdMaxLength(connectionName, rsType)
+	if err != nil {
+		return "", err
+	}
+	if maxLength > 0 && len(nameId) > maxLength {
+		return "", fmt.Errorf("%s exceeds the max ID length(%d) of the %s!", nameId, maxLength, rsType)
+	}
+	return nameId, nil
+}
+
+// check empty connectionName and the Cloud Connection
+func startDryRun(connectionName string, rsType string, nameId string) (string, *DryRunReportInfo, error) {
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return "", nil, err
+	}
+
+	report := newDryRunReport(rsType, nameId)
+	_, err = ccm.GetCloudConnection(connectionName)
+	report.addCheck("CloudConnection", err)
+	return connectionName, report, nil
+}
+
+// DryRunCreateVPC checks a VPC create request without calling the CSP create API.
+func DryRunCreateVPC(connectionName string, rsType string, reqInfo cres.VPCReqInfo, IDTransformMode string) (*DryRunReportInfo, error) {
+	cblog.Info("call DryRunCreateVPC()")
+
+	connectionName, report, err := startDryRun(connectionName, rsType, reqInfo.IId.NameId)
+	if err != nil || !report.Valid {
+		return report, err
+	}
+
+	report.addCheck("RequestValidation", ValidateStruct(reqInfo, vpcReqEmptyPermissionList))
+	report.addCheck("NameUniqueness", checkDryRunNotExist(&VPCIIDInfo{}, connectionName, rsType, reqInfo.IId.NameId))
+
+	// the Cloud Connection can have only 1 VPC, when the CSP supports only 1 VPC.
+	drv, err := ccm.GetCloudDriver(connectionName)
+	if err == nil && drv.GetDriverCapability().SINGLE_VPC {
+		var vpcIIDInfoList []*VPCIIDInfo
+		err = infostore.ListByCondition(&vpcIIDInfoList, CONNECTION_NAME_COLUMN, connectionName)
+		if err == nil && len(vpcIIDInfoList) > 0 {
+			err = fmt.Errorf(rsType + "-" + connectionName + " can have only 1 VPC, but already have a VPC " + vpcIIDInfoList[0].NameId)
+		}
+	}
+	report.addCheck("SingleVPC", err)
+	report.addCheck("IPv6", validateIPv6CIDRs(connectionName, reqInfo.IPv6_CIDR, reqInfo.SubnetInfoList))
+
+	// allocate the omitted CIDRs on a copy, nothing is reserved
+	ipamReqInfo := reqInfo
+	ipamReqInfo.SubnetInfoList = append([]cres.SubnetInfo{}, reqInfo.SubnetInfoList...)
+	ipamAllocLock.Lock()
+	_, err = prepareVPCCIDRs(connectionName, &ipamReqInfo)
+	ipamAllocLock.Unlock()
+	report.addCheck("IPAM", err)
+
+	driverNameId, err := getDryRunDriverNameId(connectionName, rsType, reqInfo.IId.NameId, IDTransformMode)
+	if report.addCheck("IDLength", err) {
+		report.DriverNameId = driverNameId
+	}
+	for _, subnetInfo := range reqInfo.SubnetInfoList {
+		_, err := getDryRunDriverNameId(connectionName, SUBNET, subnetInfo.IId.NameId, IDTransformMode)
+		report.addCheck("SubnetIDLength", err)
+	}
+
+	return report, nil
+}
+
+// DryRunCreateSecurity checks a SecurityGroup create request without calling the CSP create API.
+func DryRunCreateSecurity(connectionName string, rsType string, reqInfo cres.SecurityReqInfo, templateRefList []SGTemplateRefInfo, IDTransformMode string) (*DryRunReportInfo, error) {
+	cblog.Info("call DryRunCreateSecurity()")
+
+	connectionName, report, err := startDryRun(connectionName, rsType, reqInfo.IId.NameId)
+	if err != nil || !report.Valid {
+		return report, err
+	}
+
+	report.addCheck("VPC", checkDryRunExist(&VPCIIDInfo{}, connectionName, reqInfo.VpcIID.NameId))
+	report.addCheck("NameUniqueness", checkDryRunNotExist(&SGIIDInfo{}, connectionName, rsType, reqInfo.IId.NameId))
+
+	// rule templates
+	if len(templateRefList) > 0 {
+		ruleList, err := appendSGTemplateRules(reqInfo.SecurityRules, templateRefList)
+		if report.addCheck("RuleTemplate", err) {
+			reqInfo.SecurityRules = ruleList
+		}
+	}
+
+	// Direction: to lower
+	// IPProtocol: to upper
+	// no Action: "allow"
+	if reqInfo.SecurityRules != nil {
+		transformArgs(reqInfo.SecurityRules)
+	}
+	report.addCheck("IPv6", validateRuleIPv6CIDRs(connectionName, reqInfo.SecurityRules))
+	report.addCheck("RuleActionPriority", validateRuleActionPriority(connectionName, reqInfo.SecurityRules))
+
+	// rules referencing other SGs
+	if reqInfo.SecurityRules != nil && hasRuleSGReference(*reqInfo.SecurityRules) {
+		if report.addCheck("SecurityGroupReference", checkSGReferenceCapability(connectionName)) {
+			for _, rule := range *reqInfo.SecurityRules {
+				if rule.SecurityGroupIID != nil {
+					_, err := getRuleSGDriverIID(connectionName, rule)
+					report.addCheck("SecurityGroupReference", err)
+				}
+			}
+		}
+	}
+
+	driverNameId, err := getDryRunDriverNameId(connectionName, rsType, reqInfo.IId.NameId, IDTransformMode)
+	if report.addCheck("IDLength", err) {
+		report.DriverNameId = driverNameId
+	}
+
+	return report, nil
+}
+
+// DryRunCreateKey checks a KeyPair create request without calling the CSP create API.
+func DryRunCreateKey(connectionName string, rsType string, reqInfo cres.KeyPairReqInfo, IDTransformMode string) (*DryRunReportInfo, error) {
+	cblog.Info("call DryRunCreateKey()")
+
+	connectionName, report, err := startDryRun(connectionName, rsType, reqInfo.IId.NameId)
+	if err != nil || !report.Valid {
+		return report, err
+	}
+
+	report.addCheck("RequestValidation", ValidateStruct(reqInfo, keyReqEmptyPermissionList))
+	report.addCheck("NameUniqueness", checkDryRunNotExist(&KeyIIDInfo{}, connectionName, rsType, reqInfo.IId.NameId))
+
+	driverNameId, err := getDryRunDriverNameId(connectionName, rsType, reqInfo.IId.NameId, IDTransformMode)
+	if report.addCheck("IDLength", err) {
+		report.DriverNameId = driverNameId
+	}
+
+	return report, nil
+}
+
+// DryRunStartVM checks a VM start request without calling the CSP create API.
+func DryRunStartVM(connectionName string, rsType string, reqInfo cres.VMReqInfo, IDTransformMode string) (*DryRunReportInfo, error) {
+	cblog.Info("call DryRunStartVM()")
+
+	connectionName, report, err := startDryRun(connectionName, rsType, reqInfo.IId.NameId)
+	if err != nil || !report.Valid {
+		return report, err
+	}
+
+	report.addCheck("RequestValidation", ValidateStruct(reqInfo, vmReqEmptyPermissionList))
+	report.addCheck("ImageType", checkImageType(&reqInfo))
+	report.addCheck("NameUniqueness", checkDryRunNotExist(&VMIIDInfo{}, connectionName, rsType, reqInfo.IId.NameId))
+
+	// referenced resources
+	refPassed := report.addCheck("VPC", checkDryRunExist(&VPCIIDInfo{}, connectionName, reqInfo.VpcIID.NameId))
+	var subnetIIDInfo SubnetIIDInfo
+	err = infostore.GetBy3Conditions(&subnetIIDInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, reqInfo.SubnetIID.NameId,
+		OWNER_VPC_NAME_COLUMN, reqInfo.VpcIID.NameId)
+	refPassed = report.addCheck("Subnet", err) && refPassed
+	for _, sgIID := range reqInfo.SecurityGroupIIDs {
+		refPassed = report.addCheck("SecurityGroup", checkDryRunExist(&SGIIDInfo{}, connectionName, sgIID.NameId)) && refPassed
+	}
+	if reqInfo.KeyPairIID.NameId != "" {
+		refPassed = report.addCheck("KeyPair", checkDryRunExist(&KeyIIDInfo{}, connectionName, reqInfo.KeyPairIID.NameId)) && refPassed
+	}
+	for _, diskIID := range reqInfo.DataDiskIIDs {
+		refPassed = report.addCheck("DataDisk", checkDryRunExist(&DiskIIDInfo{}, connectionName, diskIID.NameId)) && refPassed
+	}
+	if reqInfo.ImageType == cres.MyImage {
+		refPassed = report.addCheck("Image", checkDryRunExist(&MyImageIIDInfo{}, connectionName, reqInfo.ImageIID.NameId)) && refPassed
+	} else {
+		_, err = GetImage(connectionName, IMAGE, reqInfo.ImageIID.NameId)
+		refPassed = report.addCheck("Image", err) && refPassed
+	}
+	_, err = GetVMSpec(connectionName, reqInfo.VMSpecName)
+	report.addCheck("VMSpec", err)
+
+	providerName, err := ccm.GetProviderNameByConnectionName(connectionName)
+	if !report.addCheck("Provider", err) {
+		return report, nil
+	}
+	report.addCheck("RootDisk", translateRootDiskSetupInfo(providerName, &reqInfo))
+	report.addCheck("UserData", validateUserData(connectionName, providerName, reqInfo.UserData))
+	report.addCheck("PurchaseOption", validatePurchaseOption(connectionName, &reqInfo.PurchaseOption))
+	report.addCheck("ReadinessProbe", validateReadinessProbe(&reqInfo.ReadinessProbe))
+
+	// Windows GuestOS can be used only with the Administrator
+	isWindowsOS := false
+	if refPassed {
+		isWindowsOS, err = checkDryRunWindowsVMUser(connectionName, subnetIIDInfo.ZoneId, reqInfo)
+		report.addCheck("VMUser", err)
+	}
+
+	if isWindowsOS {
+		rsType = "windowsvm" // be used for NCP and NCPVPC in IIDManager.New()
+	}
+	driverNameId, err := getDryRunDriverNameId(connectionName, rsType, reqInfo.IId.NameId, IDTransformMode)
+	if report.addCheck("IDLength", err) {
+		report.DriverNameId = driverNameId
+	}
+
+	return report, nil
+}
+
+// returns true if the image is a Windows GuestOS, and checks the VMUserId for Windows
+func checkDryRunWindowsVMUser(connectionName string, zoneId string, reqInfo cres.VMReqInfo) (bool, error) {
+	cldConn, err := ccm.GetZoneLevelCloudConnection(connectionName, zoneId)
+	if err != nil {
+		return false, err
+	}
+	reqInfoForDriver, err := cloneReqInfoWithDriverIID(connectionName, reqInfo)
+	if err != nil {
+		return false, err
+	}
+
+	isWindowsOS, err := checkImageWindowsOS(cldConn, reqInfoForDriver.ImageType, reqInfoForDriver.ImageIID)
+	if err != nil {
+		if strings.Contains(err.Error(), "yet!") {
+			return false, nil
+		}
+		return false, err
+	}
+	if isWindowsOS && reqInfo.VMUserId != "" && reqInfo.VMUserId != "Administrator" {
+		return true, fmt.Errorf(reqInfo.VMUserId + ": cannot be used for Windows GuestOS UserID!")
+	}
+	return isWindowsOS, nil
+}
+
+// DryRunCreateDisk checks a Disk create request without calling the CSP create API.
+func DryRunCreateDisk(connectionName string, rsType string, reqInfo cres.DiskInfo, IDTransformMode string) (*DryRunReportInfo, error) {
+	cblog.Info("call DryRunCreateDisk()")
+
+	connectionName, report, err := startDryRun(connectionName, rsType, reqInfo.IId.NameId)
+	if err != nil || !report.Valid {
+		return report, err
+	}
+
+	report.addCheck("NameUniqueness", checkDryRunNotExist(&DiskIIDInfo{}, connectionName, rsType, reqInfo.IId.NameId))
+
+	driverNameId, err := getDryRunDriverNameId(connectionName, rsType, reqInfo.IId.NameId, IDTransformMode)
+	if report.addCheck("IDLength", err) {
+		report.DriverNameId = driverNameId
+	}
+
+	return report, nil
+}
+
+// DryRunCreateNLB checks a NLB create request without calling the CSP create API.
+func DryRunCreateNLB(connectionName string, rsType string, reqInfo cres.NLBInfo, IDTransformMode string) (*DryRunReportInfo, error) {
+	cblog.Info("call DryRunCreateNLB()")
+
+	connectionName, report, err := startDryRun(connectionName, rsType, reqInfo.IId.NameId)
+	if err != nil || !report.Valid {
+		return report, err
+	}
+
+	report.addCheck("VPC", checkDryRunExist(&VPCIIDInfo{}, connectionName, reqInfo.VpcIID.NameId))
+	if reqInfo.VMGroup.VMs != nil {
+		for _, vmIID := range *reqInfo.VMGroup.VMs {
+			report.addCheck("VM", checkDryRunExist(&VMIIDInfo{}, connectionName, vmIID.NameId))
+		}
+	}
+
+	bool_ret, err := infostore.HasBy3Conditions(&NLBIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, reqInfo.IId.NameId,
+		OWNER_VPC_NAME_COLUMN, reqInfo.VpcIID.NameId)
+	if err == nil && bool_ret {
+		err = fmt.Errorf(rsType + "-" + reqInfo.IId.NameId + " already exists!")
+	}
+	report.addCheck("NameUniqueness", err)
+
+	driverNameId, err := getDryRunDriverNameId(connectionName, rsType, reqInfo.IId.NameId, IDTransformMode)
+	if report.addCheck("IDLength", err) {
+		report.DriverNameId = driverNameId
+	}
+
+	return report, nil
+}
+
+// DryRunCreateCluster checks a Cluster create request without calling the CSP create API.
+func DryRunCreateCluster(connectionName string, rsType string, reqInfo cres.ClusterInfo, IDTransformMode string) (*DryRunReportInfo, error) {
+	cblog.Info("call DryRunCreateCluster()")
+
+	connectionName, report, err := startDryRun(connectionName, rsType, reqInfo.IId.NameId)
+	if err != nil || !report.Valid {
+		return report, err
+	}
+
+	// referenced resources
+	netReqInfo := reqInfo.Network
+	report.addCheck("VPC", checkDryRunExist(&VPCIIDInfo{}, connectionName, netReqInfo.VpcIID.NameId))
+	for _, subnetIID := range netReqInfo.SubnetIIDs {
+		var subnetIIdInfo SubnetIIDInfo
+		err := infostore.GetBy3Conditions(&subnetIIdInfo, CONNECTION_NAME_COLUMN, connectionName,
+			NAME_ID_COLUMN, subnetIID.NameId, OWNER_VPC_NAME_COLUMN, netReqInfo.VpcIID.NameId)
+		report.addCheck("Subnet", err)
+	}
+	for _, sgIID := range netReqInfo.SecurityGroupIIDs {
+		report.addCheck("SecurityGroup", checkDryRunExist(&SGIIDInfo{}, connectionName, sgIID.NameId))
+	}
+	for _, ngInfo := range reqInfo.NodeGroupList {
+		report.addCheck("KeyPair", checkDryRunExist(&KeyIIDInfo{}, connectionName, ngInfo.KeyPairIID.NameId))
+	}
+
+	report.addCheck("NameUniqueness", checkDryRunNotExist(&ClusterIIDInfo{}, connectionName, rsType, reqInfo.IId.NameId))
+
+	driverNameId, err := getDryRunDriverNameId(connectionName, rsType, reqInfo.IId.NameId, IDTransformMode)
+	if report.addCheck("IDLength", err) {
+		report.DriverNameId = driverNameId
+	}
+	for _, ngInfo := range reqInfo.NodeGroupList {
+		_, err := getDryRunDriverNameId(connectionName, NODEGROUP, ngInfo.IId.NameId, IDTransformMode)
+		report.addCheck("NodeGroupIDLength", err)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,781 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package commonruntime
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+// ====================================================================
+// type for GORM
+
+// IPAMPoolInfo is an IPv4 address pool for the automatic allocation of VPC and Subnet CIDRs.
+type IPAMPoolInfo struct {
+	Name               string `gorm:"primaryKey" json:"Name" validate:"required" example:"pool-01"`
+	CIDR               string `json:"CIDR" validate:"required" example:"10.0.0.0/8"`
+	VPCPrefixLength    int    `json:"VPCPrefixLength" validate:"required" example:"16"`
+	SubnetPrefixLength int    `json:"SubnetPrefixLength" validate:"required" example:"24"`
+}
+
+func (IPAMPoolInfo) TableName() string {
+	return "ipam_pool_infos"
+}
+
+// IPAMCIDRInfo keeps the IPv4 CIDR of a VPC or a Subnet, OwnerVPCName is "" for a VPC.
+type IPAMCIDRInfo struct {
+	ConnectionName string `gorm:"primaryKey"` // ex) "aws-seoul-config"
+	NameId         string `gorm:"primaryKey"` // ex) "vpc-01"
+	OwnerVPCName   string `gorm:"primaryKey"` // ex) "vpc-01" for Subnet
+	CIDR           string // ex) "10.0.0.0/16"
+	PoolName       string // "" if not allocated from a pool
+}
+
+func (IPAMCIDRInfo) TableName() string {
+	return "ipam_cidr_infos"
+}
+
+//====================================================================
+
+func init() {
+	db, err := infostore.Open()
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	db.AutoMigrate(&IPAMPoolInfo{})
+	db.AutoMigrate(&IPAMCIDRInfo{})
+	infostore.Close(db)
+}
+
+const (
+	IPAM_DEFAULT_VPC_PREFIX    = 16
+	IPAM_DEFAULT_SUBNET_PREFIX = 24
+	IPAM_MAX_PREFIX            = 29
+)
+
+// serialize the allocations and the overlap checks of VPC CIDRs across all connections,
+// held until the CIDRs are reserved, not during the CSP calls.
+var ipamAllocLock sync.Mutex
+
+const IPAM_BACKFILL_RETRY_INTERVAL = 300 // secs, retry of the VPCs which failed in the backfill
+
+// VPCCIDRInfo represents an address block used by a VPC.
+type VPCCIDRInfo struct {
+	ConnectionName string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	VPCName        string `json:"VPCName" validate:"required" example:"vpc-01"`
+	SubnetName     string `json:"SubnetName,omitempty" validate:"omitempty" example:"subnet-01"` // set if the VPC has no CIDR
+	CIDR           string `json:"CIDR" validate:"required" example:"10.0.0.0/16"`
+}
+
+// IPAMPoolUsageInfo represents the utilization of an IPAM pool.
+type IPAMPoolUsageInfo struct {
+	Pool               IPAMPoolInfo  `json:"Pool" validate:"required"`
+	TotalAddresses     uint64        `json:"TotalAddresses" validate:"required" example:"16777216"`
+	UsedAddresses      uint64        `json:"UsedAddresses" validate:"required" example:"65536"`
+	UtilizationPercent float64       `json:"UtilizationPercent" validate:"required" example:"0.39"`
+	VPCCIDRList        []VPCCIDRInfo `json:"VPCCIDRList" validate:"required"`
+}
+
+// IPAMOverlapInfo represents two address blocks of different VPCs which overlap.
+type IPAMOverlapInfo struct {
+	A VPCCIDRInfo `json:"A" validate:"required"`
+	B VPCCIDRInfo `json:"B" validate:"required"`
+}
+
+// IPAMUsageInfo represents the utilization of all pools and the overlaps of all VPCs.
+type IPAMUsageInfo struct {
+	PoolUsageList []IPAMPoolUsageInfo `json:"PoolUsageList" validate:"required"`
+	OverlapList   []IPAMOverlapInfo   `json:"OverlapList" validate:"required"`
+}
+
+func isIPAMOverlapCheckOn() bool {
+	return strings.ToUpper(os.Getenv("SPIDER_IPAM_OVERLAP_CHECK")) == "ON"
+}
+
+//================ IPAM Pool
+
+// (1) check the CIDR and the prefix lengths
+// (2) check the pool does not overlap the other pools
+// (3) insert the pool
+func CreateIPAMPool(reqInfo IPAMPoolInfo) (*IPAMPoolInfo, error) {
+	cblog.Info("call CreateIPAMPool()")
+
+	// check empty and trim user inputs
+	name, err := EmptyCheckAndTrim("Name", reqInfo.Name)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	reqInfo.Name = name
+
+	// (1) check the CIDR and the prefix lengths
+	poolNet, err := parseIPv4CIDR(reqInfo.CIDR)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	reqInfo.CIDR = poolNet.String()
+	poolPrefix, _ := poolNet.Mask.Size()
+
+	if reqInfo.VPCPrefixLength == 0 {
+		reqInfo.VPCPrefixLength = IPAM_DEFAULT_VPC_PREFIX
+	}
+	if reqInfo.SubnetPrefixLength == 0 {
+		reqInfo.SubnetPrefixLength = IPAM_DEFAULT_SUBNET_PREFIX
+	}
+	if reqInfo.VPCPrefixLength < poolPrefix || reqInfo.VPCPrefixLength > IPAM_MAX_PREFIX {
+		err := fmt.Errorf("The VPCPrefixLength %d must be between %d and %d!", reqInfo.VPCPrefixLength, poolPrefix, IPAM_MAX_PREFIX)
+		cblog.Error(err)
+		return nil, err
+	}
+	if reqInfo.SubnetPrefixLength < reqInfo.VPCPrefixLength || reqInfo.SubnetPrefixLength > IPAM_MAX_PREFIX {
+		err := fmt.Errorf("The SubnetPrefixLength %d must be between %d and %d!", reqInfo.SubnetPrefixLength, reqInfo.VPCPrefixLength, IPAM_MAX_PREFIX)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	ipamAllocLock.Lock()
+	defer ipamAllocLock.Unlock()
+
+	// (2) check the pool does not overlap the other pools
+	poolList, err := listIPAMPools()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	for _, pool := range poolList {
+		if pool.Name == reqInfo.Name {
+			err := fmt.Errorf("The IPAM Pool '%s' already exists!", reqInfo.Name)
+			cblog.Error(err)
+			return nil, err
+		}
+		if isOverlapCIDR(pool.CIDR, reqInfo.CIDR) {
+			err := fmt.Errorf("The CIDR %s overlaps the IPAM Pool '%s'(%s)!", reqInfo.CIDR, pool.Name, pool.CIDR)
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+
+	// (3) insert the pool
+	err = infostore.Insert(&reqInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return &reqInfo, nil
+}
+
+func ListIPAMPool() ([]*IPAMPoolInfo, error) {
+	cblog.Info("call ListIPAMPool()")
+
+	poolList, err := listIPAMPools()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return poolList, nil
+}
+
+// (1) check the pool has no allocated VPC
+// (2) delete the pool
+func DeleteIPAMPool(name string) (bool, error) {
+	cblog.Info("call DeleteIPAMPool()")
+
+	// check empty and trim user inputs
+	name, err := EmptyCheckAndTrim("Name", name)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	ipamAllocLock.Lock()
+	defer ipamAllocLock.Unlock()
+
+	bool_ret, err := infostore.Has(&IPAMPoolInfo{}, "name", name)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+	if !bool_ret {
+		err := fmt.Errorf("The IPAM Pool '%s' does not exist!", name)
+		cblog.Error(err)
+		return false, err
+	}
+
+	// (1) check the pool has no allocated VPC
+	vpcCIDRList, err := listVPCCIDRs()
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+	for _, vpcCIDR := range vpcCIDRList {
+		if vpcCIDR.PoolName == name {
+			err := fmt.Errorf("The IPAM Pool '%s' is used by the VPC '%s' of '%s'!", name, vpcCIDR.VPCName, vpcCIDR.ConnectionName)
+			cblog.Error(err)
+			return false, err
+		}
+	}
+
+	// (2) delete the pool
+	_, err = infostore.Delete(&IPAMPoolInfo{}, "name", name)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+	return true, nil
+}
+
+// (1) get the address blocks of all VPCs
+// (2) sum the used addresses of each pool
+// (3) find the overlaps between VPCs
+func GetIPAMUsage() (*IPAMUsageInfo, error) {
+	cblog.Info("call GetIPAMUsage()")
+
+	poolList, err := listIPAMPools()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (1) get the address blocks of all VPCs
+	vpcCIDRList, err := listVPCCIDRs()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) sum the used addresses of each pool
+	usageInfo := IPAMUsageInfo{PoolUsageList: []IPAMPoolUsageInfo{}, OverlapList: []IPAMOverlapInfo{}}
+	for _, pool := range poolList {
+		poolUsage := IPAMPoolUsageInfo{Pool: *pool, VPCCIDRList: []VPCCIDRInfo{}}
+		poolStart, poolEnd, _ := getIPv4Range(pool.CIDR)
+		poolUsage.TotalAddresses = uint64(poolEnd) - uint64(poolStart) + 1
+
+		rangeList := [][2]uint32{}
+		for _, vpcCIDR := range vpcCIDRList {
+			start, end, err := getIPv4Range(vpcCIDR.CIDR)
+			if err != nil || end < poolStart || start > poolEnd {
+				continue
+			}
+			poolUsage.VPCCIDRList = append(poolUsage.VPCCIDRList, vpcCIDR.VPCCIDRInfo)
+			rangeList = append(rangeList, [2]uint32{max(start, poolStart), min(end, poolEnd)})
+		}
+		poolUsage.UsedAddresses = countMergedAddresses(rangeList)
+		poolUsage.UtilizationPercent = float64(poolUsage.UsedAddresses) * 100 / float64(poolUsage.TotalAddresses)
+		usageInfo.PoolUsageList = append(usageInfo.PoolUsageList, poolUsage)
+	}
+
+	// (3) find the overlaps between VPCs
+	for i := range vpcCIDRList {
+		for j := 0; j < i; j++ {
+			a, b := vpcCIDRList[j].VPCCIDRInfo, vpcCIDRList[i].VPCCIDRInfo
+			if a.ConnectionName == b.ConnectionName && a.VPCName == b.VPCName {
+				continue
+			}
+			if isOverlapCIDR(a.CIDR, b.CIDR) {
+				usageInfo.OverlapList = append(usageInfo.OverlapList, IPAMOverlapInfo{A: a, B: b})
+			}
+		}
+	}
+
+	return &usageInfo, nil
+}
+
+func listIPAMPools() ([]*IPAMPoolInfo, error) {
+	var poolList []*IPAMPoolInfo
+	err := infostore.List(&poolList)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(poolList, func(i, j int) bool { return poolList[i].Name < poolList[j].Name })
+	return poolList, nil
+}
+
+//================ VPC and Subnet CIDRs
+
+type vpcCIDRWithPool struct {
+	VPCCIDRInfo
+	PoolName string
+}
+
+// get the address blocks of all recorded VPCs in all connections, the VPC CIDR or the Subnet CIDRs if the VPC has no CIDR.
+// the records include the CIDRs reserved by the VPCs in creation.
+func listVPCCIDRs() ([]vpcCIDRWithPool, error) {
+	var cidrInfoList []*IPAMCIDRInfo
+	err := infostore.List(&cidrInfoList)
+	if err != nil {
+		return nil, err
+	}
+
+	vpcCIDRList := []vpcCIDRWithPool{}
+	for _, vpcRecord := range cidrInfoList {
+		if vpcRecord.OwnerVPCName != "" {
+			continue
+		}
+		if vpcRecord.CIDR != "" {
+			vpcCIDRList = append(vpcCIDRList, vpcCIDRWithPool{VPCCIDRInfo: VPCCIDRInfo{ConnectionName: vpcRecord.ConnectionName,
+				VPCName: vpcRecord.NameId, CIDR: vpcRecord.CIDR}, PoolName: vpcRecord.PoolName})
+			continue
+		}
+		for _, subnetRecord := range cidrInfoList {
+			if subnetRecord.ConnectionName != vpcRecord.ConnectionName || subnetRecord.OwnerVPCName != vpcRecord.NameId {
+				continue
+			}
+			vpcCIDRList = append(vpcCIDRList, vpcCIDRWithPool{VPCCIDRInfo: VPCCIDRInfo{ConnectionName: vpcRecord.ConnectionName,
+				VPCName: vpcRecord.NameId, SubnetName: subnetRecord.NameId, CIDR: subnetRecord.CIDR}, PoolName: vpcRecord.PoolName})
+		}
+	}
+	sort.SliceStable(vpcCIDRList, func(i, j int) bool {
+		if vpcCIDRList[i].ConnectionName != vpcCIDRList[j].ConnectionName {
+			return vpcCIDRList[i].ConnectionName < vpcCIDRList[j].ConnectionName
+		}
+		return vpcCIDRList[i].VPCName < vpcCIDRList[j].VPCName
+	})
+	return vpcCIDRList, nil
+}
+
+// StartIPAMBackfill records the CIDRs of the VPCs created before IPAM in the background, it is called at the server start.
+// The VPCs which failed, ex) an unreachable connection, are retried every IPAM_BACKFILL_RETRY_INTERVAL
+// until all VPCs are recorded, and they are skipped in the overlap checks and the usages until then.
+func StartIPAMBackfill() {
+	cblog.Info("call StartIPAMBackfill()")
+
+	go func() {
+		for backfillVPCCIDRs() > 0 {
+			time.Sleep(IPAM_BACKFILL_RETRY_INTERVAL * time.Second)
+		}
+	}()
+}
+
+// record the CIDRs of the VPCs without IPAM record from the CSP, returns the count of the VPCs which failed.
+// the caller must not hold ipamAllocLock, because it calls the CSPs.
+func backfillVPCCIDRs() int {
+	var vpcIIDInfoList []*VPCIIDInfo
+	err := infostore.List(&vpcIIDInfoList)
+	if err != nil {
+		cblog.Error(err)
+		return 1
+	}
+
+	failedCount := 0
+	for _, vpcIIDInfo := range vpcIIDInfoList {
+		if !backfillVPCCIDR(vpcIIDInfo) {
+			failedCount++
+		}
+	}
+	return failedCount
+}
+
+// returns false if the CIDRs of the VPC should be retried
+func backfillVPCCIDR(vpcIIDInfo *VPCIIDInfo) bool {
+	// the VPC is not deleted while its CIDRs are recorded
+	vpcSPLock.RLock(vpcIIDInfo.ConnectionName, vpcIIDInfo.NameId)
+	defer vpcSPLock.RUnlock(vpcIIDInfo.ConnectionName, vpcIIDInfo.NameId)
+
+	bool_ret, err := infostore.HasBy3Conditions(&IPAMCIDRInfo{}, CONNECTION_NAME_COLUMN, vpcIIDInfo.ConnectionName,
+		NAME_ID_COLUMN, vpcIIDInfo.NameId, OWNER_VPC_NAME_COLUMN, "")
+	if err != nil {
+		cblog.Error(err)
+		return false
+	}
+	if bool_ret {
+		return true
+	}
+
+	// the VPC deleted after the list has nothing to record
+	bool_ret, err = infostore.HasByConditions(&VPCIIDInfo{}, CONNECTION_NAME_COLUMN, vpcIIDInfo.ConnectionName, NAME_ID_COLUMN, vpcIIDInfo.NameId)
+	if err != nil || !bool_ret {
+		return err == nil
+	}
+
+	_, _, err = recordCSPVPCCIDRs(vpcIIDInfo)
+	if err != nil {
+		cblog.Info(fmt.Sprintf("retry the CIDRs of the VPC '%s' of '%s' later: %v", vpcIIDInfo.NameId, vpcIIDInfo.ConnectionName, err))
+		return false
+	}
+	return true
+}
+
+// record the CIDRs of a VPC and its Subnets from the CSP
+func recordCSPVPCCIDRs(vpcIIDInfo *VPCIIDInfo) (*IPAMCIDRInfo, []*IPAMCIDRInfo, error) {
+	cldConn, err := ccm.GetCloudConnection(vpcIIDInfo.ConnectionName)
+	if err != nil {
+		return nil, nil, err
+	}
+	handler, err := cldConn.CreateVPCHandler()
+	if err != nil {
+		return nil, nil, err
+	}
+	vpcInfo, err := handler.GetVPC(getDriverIID(cres.IID{NameId: vpcIIDInfo.NameId, SystemId: vpcIIDInfo.SystemId}))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var subnetIIDInfoList []*SubnetIIDInfo
+	err = infostore.ListByConditions(&subnetIIDInfoList, CONNECTION_NAME_COLUMN, vpcIIDInfo.ConnectionName, OWNER_VPC_NAME_COLUMN, vpcIIDInfo.NameId)
+	if err != nil {
+		return nil, nil, err
+	}
+	for n, subnetInfo := range vpcInfo.SubnetInfoList {
+		// unmanaged Subnets are recorded with the CSP ID
+		vpcInfo.SubnetInfoList[n].IId.NameId = subnetInfo.IId.SystemId
+		for _, one := range subnetIIDInfoList {
+			if getDriverSystemId(cres.IID{NameId: one.NameId, SystemId: one.SystemId}) == subnetInfo.IId.SystemId {
+				vpcInfo.SubnetInfoList[n].IId.NameId = one.NameId
+				break
+			}
+		}
+	}
+
+	return recordVPCCIDRs(vpcIIDInfo.ConnectionName, vpcIIDInfo.NameId, vpcInfo.IPv4_CIDR, vpcInfo.SubnetInfoList, "")
+}
+
+// record the CIDRs of a VPC and its Subnets, the Subnet IIDs must be user IIDs.
+// If a Subnet fails, all records of the VPC are deleted.
+func recordVPCCIDRs(connectionName string, vpcName string, vpcCIDR string, subnetInfoList []cres.SubnetInfo, poolName string) (*IPAMCIDRInfo, []*IPAMCIDRInfo, error) {
+	vpcRecord := IPAMCIDRInfo{ConnectionName: connectionName, NameId: vpcName, OwnerVPCName: "", CIDR: vpcCIDR, PoolName: poolName}
+	err := infostore.Insert(&vpcRecord)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	subnetRecordList := []*IPAMCIDRInfo{}
+	for _, subnetInfo := range subnetInfoList {
+		subnetRecord, err := recordSubnetCIDR(connectionName, vpcName, subnetInfo.IId.NameId, subnetInfo.IPv4_CIDR)
+		if err != nil {
+			deleteVPCCIDRs(connectionName, vpcName)
+			return nil, nil, err
+		}
+		subnetRecordList = append(subnetRecordList, subnetRecord)
+	}
+	return &vpcRecord, subnetRecordList, nil
+}
+
+func recordSubnetCIDR(connectionName string, vpcName string, subnetName string, cidr string) (*IPAMCIDRInfo, error) {
+	subnetRecord := IPAMCIDRInfo{ConnectionName: connectionName, NameId: subnetName, OwnerVPCName: vpcName, CIDR: cidr}
+	err := infostore.Insert(&subnetRecord)
+	if err != nil {
+		return nil, err
+	}
+	return &subnetRecord, nil
+}
+
+// delete the CIDR records of a VPC and its Subnets
+func deleteVPCCIDRs(connectionName string, vpcName string) {
+	_, err := infostore.DeleteBy3Conditions(&IPAMCIDRInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, vpcName, OWNER_VPC_NAME_COLUMN, "")
+	if err != nil {
+		cblog.Error(err)
+	}
+	_, err = infostore.DeleteByConditions(&IPAMCIDRInfo{}, CONNECTION_NAME_COLUMN, connectionName, OWNER_VPC_NAME_COLUMN, vpcName)
+	if err != nil {
+		cblog.Error(err)
+	}
+}
+
+func deleteSubnetCIDR(connectionName string, vpcName string, subnetName string) {
+	_, err := infostore.DeleteBy3Conditions(&IPAMCIDRInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, subnetName, OWNER_VPC_NAME_COLUMN, vpcName)
+	if err != nil {
+		cblog.Error(err)
+	}
+}
+
+// reserveVPCCIDRs allocates the omitted CIDRs of a VPC request, checks the overlap with the other VPCs
+// and records the CIDRs before the VPC is created, the caller must delete the records if the creation fails.
+// ipamAllocLock is held only if a VPC CIDR is allocated or an overlap is rejected.
+func reserveVPCCIDRs(connectionName string, reqInfo *cres.VPCReqInfo) error {
+	if isIPAMOverlapCheckOn() || (reqInfo.IPv4_CIDR == "" && hasOmittedSubnetCIDR(reqInfo.SubnetInfoList)) {
+		ipamAllocLock.Lock()
+		defer ipamAllocLock.Unlock()
+	}
+
+	poolName, err := prepareVPCCIDRs(connectionName, reqInfo)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = recordVPCCIDRs(connectionName, reqInfo.IId.NameId, reqInfo.IPv4_CIDR, reqInfo.SubnetInfoList, poolName)
+	return err
+}
+
+// reserveSubnetCIDR allocates the omitted CIDR of a Subnet request, checks the overlap with the other VPCs
+// if the VPC has no CIDR and records the CIDR before the Subnet is added,
+// the caller must hold the lock of the VPC and delete the record if the addition fails.
+func reserveSubnetCIDR(connectionName string, vpcName string, vpcInfo cres.VPCInfo, reqInfo *cres.SubnetInfo) error {
+	if vpcInfo.IPv4_CIDR == "" && isIPAMOverlapCheckOn() {
+		ipamAllocLock.Lock()
+		defer ipamAllocLock.Unlock()
+	}
+
+	err := prepareSubnetCIDR(connectionName, vpcName, vpcInfo, reqInfo)
+	if err != nil {
+		return err
+	}
+
+	_, err = recordSubnetCIDR(connectionName, vpcName, reqInfo.IId.NameId, reqInfo.IPv4_CIDR)
+	return err
+}
+
+// allocate the omitted VPC and Subnet CIDRs and check the overlap of the VPC address blocks,
+// returns the pool name if the VPC CIDR is allocated from a pool.
+func prepareVPCCIDRs(connectionName string, reqInfo *cres.VPCReqInfo) (string, error) {
+	vpcCIDRList, err := listVPCCIDRs()
+	if err != nil {
+		return "", err
+	}
+
+	// (1) allocate the VPC CIDR from the pools
+	poolName := ""
+	subnetPrefix := IPAM_DEFAULT_SUBNET_PREFIX
+	if reqInfo.IPv4_CIDR == "" && hasOmittedSubnetCIDR(reqInfo.SubnetInfoList) {
+		poolList, err := listIPAMPools()
+		if err != nil {
+			return "", err
+		}
+		if len(poolList) == 0 {
+			return "", fmt.Errorf("The IPv4_CIDR is omitted, but there is no IPAM Pool to allocate it!")
+		}
+		usedCIDRList := []string{}
+		for _, vpcCIDR := range vpcCIDRList {
+			usedCIDRList = append(usedCIDRList, vpcCIDR.CIDR)
+		}
+		for _, pool := range poolList {
+			cidr, err := allocateCIDR(pool.CIDR, pool.VPCPrefixLength, usedCIDRList)
+			if err == nil {
+				reqInfo.IPv4_CIDR = cidr
+				poolName = pool.Name
+				subnetPrefix = pool.SubnetPrefixLength
+				break
+			}
+		}
+		if poolName == "" {
+			return "", fmt.Errorf("All IPAM Pools are exhausted, can not allocate a VPC CIDR!")
+		}
+	}
+
+	// (2) allocate the Subnet CIDRs in the VPC CIDR
+	if hasOmittedSubnetCIDR(reqInfo.SubnetInfoList) {
+		if reqInfo.IPv4_CIDR == "" {
+			return "", fmt.Errorf("The IPv4_CIDR of the Subnet is omitted, but the VPC has no IPv4_CIDR!")
+		}
+		usedCIDRList := []string{}
+		for _, subnetInfo := range reqInfo.SubnetInfoList {
+			if subnetInfo.IPv4_CIDR != "" {
+				usedCIDRList = append(usedCIDRList, subnetInfo.IPv4_CIDR)
+			}
+		}
+		for n, subnetInfo := range reqInfo.SubnetInfoList {
+			if subnetInfo.IPv4_CIDR != "" {
+				continue
+			}
+			cidr, err := allocateCIDR(reqInfo.IPv4_CIDR, getSubnetPrefix(reqInfo.IPv4_CIDR, subnetPrefix), usedCIDRList)
+			if err != nil {
+				return "", err
+			}
+			reqInfo.SubnetInfoList[n].IPv4_CIDR = cidr
+			usedCIDRList = append(usedCIDRList, cidr)
+		}
+	}
+
+	// (3) check the overlap of the VPC address blocks
+	blockList := []string{reqInfo.IPv4_CIDR}
+	if reqInfo.IPv4_CIDR == "" {
+		blockList = []string{}
+		for _, subnetInfo := range reqInfo.SubnetInfoList {
+			blockList = append(blockList, subnetInfo.IPv4_CIDR)
+		}
+	}
+	for _, block := range blockList {
+		err = checkVPCCIDROverlap(connectionName, reqInfo.IId.NameId, block, vpcCIDRList)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return poolName, nil
+}
+
+// allocate the omitted Subnet CIDR in the VPC CIDR, or check the overlap of the Subnet CIDR if the VPC has no CIDR.
+func prepareSubnetCIDR(connectionName string, vpcName string, vpcInfo cres.VPCInfo, reqInfo *cres.SubnetInfo) error {
+	if reqInfo.IPv4_CIDR == "" {
+		if vpcInfo.IPv4_CIDR == "" {
+			return fmt.Errorf("The IPv4_CIDR of the Subnet is omitted, but the VPC '%s' has no IPv4_CIDR!", vpcName)
+		}
+
+		subnetPrefix := IPAM_DEFAULT_SUBNET_PREFIX
+		var vpcRecord IPAMCIDRInfo
+		err := infostore.GetBy3Conditions(&vpcRecord, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, vpcName, OWNER_VPC_NAME_COLUMN, "")
+		if err == nil && vpcRecord.PoolName != "" {
+			var pool IPAMPoolInfo
+			if err := infostore.Get(&pool, "name", vpcRecord.PoolName); err == nil {
+				subnetPrefix = pool.SubnetPrefixLength
+			}
+		}
+
+		usedCIDRList := []string{}
+		for _, subnetInfo := range vpcInfo.SubnetInfoList {
+			usedCIDRList = append(usedCIDRList, subnetInfo.IPv4_CIDR)
+		}
+		cidr, err := allocateCIDR(vpcInfo.IPv4_CIDR, getSubnetPrefix(vpcInfo.IPv4_CIDR, subnetPrefix), usedCIDRList)
+		if err != nil {
+			return err
+		}
+		reqInfo.IPv4_CIDR = cidr
+		return nil
+	}
+
+	// the Subnet CIDRs are the address blocks of a VPC without CIDR
+	if vpcInfo.IPv4_CIDR == "" {
+		vpcCIDRList, err := listVPCCIDRs()
+		if err != nil {
+			return err
+		}
+		return checkVPCCIDROverlap(connectionName, vpcName, reqInfo.IPv4_CIDR, vpcCIDRList)
+	}
+	return nil
+}
+
+func hasOmittedSubnetCIDR(subnetInfoList []cres.SubnetInfo) bool {
+	for _, subnetInfo := range subnetInfoList {
+		if subnetInfo.IPv4_CIDR == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// the Subnet prefix length must not be shorter than the VPC prefix length
+func getSubnetPrefix(vpcCIDR string, subnetPrefix int) int {
+	_, vpcNet, err := net.ParseCIDR(vpcCIDR)
+	if err != nil {
+		return subnetPrefix
+	}
+	vpcPrefix, _ := vpcNet.Mask.Size()
+	if subnetPrefix < vpcPrefix {
+		return vpcPrefix
+	}
+	return subnetPrefix
+}
+
+// reject or log the overlap of an address block with the other VPCs by SPIDER_IPAM_OVERLAP_CHECK
+func checkVPCCIDROverlap(connectionName string, vpcName string, cidr string, vpcCIDRList []vpcCIDRWithPool) error {
+	for _, vpcCIDR := range vpcCIDRList {
+		if vpcCIDR.ConnectionName == connectionName && vpcCIDR.VPCName == vpcName {
+			continue
+		}
+		if !isOverlapCIDR(vpcCIDR.CIDR, cidr) {
+			continue
+		}
+		err := fmt.Errorf("The CIDR %s overlaps the CIDR %s of the VPC '%s' of '%s'!", cidr, vpcCIDR.CIDR, vpcCIDR.VPCName, vpcCIDR.ConnectionName)
+		if isIPAMOverlapCheckOn() {
+			return err
+		}
+		cblog.Info(err)
+	}
+	return nil
+}
+
+//================ IPv4 CIDR utilities
+
+func parseIPv4CIDR(cidr string) (*net.IPNet, error) {
+	ip, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+	if err != nil || ip.To4() == nil {
+		return nil, fmt.Errorf("%s is not a valid IPv4 CIDR!", cidr)
+	}
+	return ipNet, nil
+}
+
+// get the first and the last addresses of an IPv4 CIDR
+func getIPv4Range(cidr string) (uint32, uint32, error) {
+	ipNet, err := parseIPv4CIDR(cidr)
+	if err != nil {
+		return 0, 0, err
+	}
+	ip := ipNet.IP.To4()
+	start := uint32(ip[0])<<24 | uint32(ip[1])<<16 | uint32(ip[2])<<8 | uint32(ip[3])
+	ones, _ := ipNet.Mask.Size()
+	end := start | uint32((uint64(1)<<(32-ones))-1)
+	return start, end, nil
+}
+
+func isOverlapCIDR(a string, b string) bool {
+	aStart, aEnd, err := getIPv4Range(a)
+	if err != nil {
+		return false
+	}
+	bStart, bEnd, err := getIPv4Range(b)
+	if err != nil {
+		return false
+	}
+	return aStart <= bEnd && bStart <= aEnd
+}
+
+// allocate the first block of the prefix length in the parent CIDR which does not overlap the used CIDRs
+func allocateCIDR(parentCIDR string, prefixLength int, usedCIDRList []string) (string, error) {
+	parentStart, parentEnd, err := getIPv4Range(parentCIDR)
+	if err != nil {
+		return "", err
+	}
+	if prefixLength < 0 || prefixLength > 32 {
+		return "", fmt.Errorf("%d is not a valid prefix length!", prefixLength)
+	}
+	blockSize := uint64(1) << (32 - prefixLength)
+	for start := uint64(parentStart); start+blockSize-1 <= uint64(parentEnd); start += blockSize {
+		ip := net.IPv4(byte(start>>24), byte(start>>16), byte(start>>8), byte(start))
+		cidr := fmt.Sprintf("%s/%d", ip.String(), prefixLength)
+		used := false
+		for _, usedCIDR := range usedCIDRList {
+			if isOverlapCIDR(usedCIDR, cidr) {
+				used = true
+				break
+			}
+		}
+		if !used {
+			return cidr, nil
+		}
+	}
+	return "", fmt.Errorf("There is no free /%d block in %s!", prefixLength, parentCIDR)
+}
+
+// count the addresses of the merged ranges
+func countMergedAddresses(rangeList [][2]uint32) uint64 {
+	sort.Slice(rangeList, func(i, j int) bool { return rangeList[i][0] < rangeList[j][0] })
+	var count uint64
+	var curStart, curEnd uint64
+	started := false
+	for _, r := range rangeList {
+		start, end := uint64(r[0]), uint64(r[1])
+		if !started {
+			curStart, curEnd, started = start, end, true
+			continue
+		}
+		if start <= curEnd+1 {
+			if end > curEnd {
+				curEnd = end
+			}
+			continue
+		}
+		count += curEnd - curStart + 1
+		curStart, curEnd = start, end
+	}
+	if started {
+		count += curEnd - curStart + 1
+	}
+	return count
+}
@@ -0,0 +1,215 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package commonruntime
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+// ====================================================================
+// type for GORM
+
+// IdempotencyKeyInfo keeps the final result of a create request with an Idempotency-Key.
+type IdempotencyKeyInfo struct {
+	IdempotencyKey string `gorm:"primaryKey"` // ex) "9f1c0a7e-..."
+	RequestHash    string // hash of the method, path and body of the first request
+	StatusCode     int
+	ResponseBody   string // the secrets are hidden, ex) PrivateKey
+	CreatedTime    time.Time
+}
+
+func (IdempotencyKeyInfo) TableName() string {
+	return "idempotency_key_infos"
+}
+
+//====================================================================
+
+func init() {
+	db, err := infostore.Open()
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	db.AutoMigrate(&IdempotencyKeyInfo{})
+	infostore.Close(db)
+}
+
+const IDEMPOTENCY_KEY_COLUMN = "idempotency_key"
+const MAX_IDEMPOTENCY_KEY_LENGTH = 255
+const IDEMPOTENCY_KEY_TTL = 24 * time.Hour
+
+// secret fields of the create results, hidden in the stored result like the Get APIs
+var idempotencySecretFields = map[string]bool{
+	"PrivateKey":   true,
+	"PublicKey":    true,
+	"VMUserPasswd": true,
+	"PreSharedKey": true,
+}
+
+// in-flight requests by Idempotency-Key, closed when the first request is done
+var idempotencyInFlight = map[string]chan struct{}{}
+var idempotencyLock sync.Mutex
+
+// IdempotencyConflictError is returned when a key is reused with a different request.
+type IdempotencyConflictError struct {
+	Key string
+}
+
+func (e *IdempotencyConflictError) Error() string {
+	return fmt.Sprintf("Idempotency-Key %s is already used by a different request!", e.Key)
+}
+
+// DoIdempotent runs the request only once for a key and returns the final result of the first run.
+// (1) a finished key returns the stored result (replayed=true)
+// (2) an in-flight key waits for the first request and returns its result (replayed=true)
+// (3) a new key runs the request and stores the result without the secrets.
+// The result is stored for every status code returned by the request, including a failure(4xx, 5xx).
+// The result is not stored only if the request is not finished(finished=false or panic, ex: a canceled request),
+// so a retry of the key runs the request again.
+func DoIdempotent(key string, requestHash string, run func() (statusCode int, body string, finished bool)) (statusCode int, body string, replayed bool, err error) {
+	cblog.Info("call DoIdempotent()")
+
+	key, err = EmptyCheckAndTrim("Idempotency-Key", key)
+	if err != nil {
+		cblog.Error(err)
+		return 0, "", false, err
+	}
+	if len(key) > MAX_IDEMPOTENCY_KEY_LENGTH {
+		err := fmt.Errorf("The Idempotency-Key should be at most %d characters!", MAX_IDEMPOTENCY_KEY_LENGTH)
+		cblog.Error(err)
+		return 0, "", false, err
+	}
+
+	for {
+		idempotencyLock.Lock()
+
+		// (1) a finished key
+		info, err := getIdempotencyKeyInfo(key)
+		if err != nil {
+			idempotencyLock.Unlock()
+			cblog.Error(err)
+			return 0, "", false, err
+		}
+		if info != nil {
+			idempotencyLock.Unlock()
+			if info.RequestHash != requestHash {
+				err := &IdempotencyConflictError{Key: key}
+				cblog.Error(err)
+				return 0, "", false, err
+			}
+			return info.StatusCode, info.ResponseBody, true, nil
+		}
+
+		// (2) an in-flight key
+		if done, ok := idempotencyInFlight[key]; ok {
+			idempotencyLock.Unlock()
+			<-done
+			continue
+		}
+
+		// (3) a new key
+		done := make(chan struct{})
+		idempotencyInFlight[key] = done
+		idempotencyLock.Unlock()
+
+		statusCode, body = runIdempotent(key, requestHash, done, run)
+		return statusCode, body, false, nil
+	}
+}
+
+// runs the request of a new key and stores the result if the request is finished.
+// The in-flight key is released even on a panic, then the waiters retry the key.
+func runIdempotent(key string, requestHash string, done chan struct{}, run func() (int, string, bool)) (int, string) {
+	defer func() {
+		idempotencyLock.Lock()
+		delete(idempotencyInFlight, key)
+		close(done)
+		idempotencyLock.Unlock()
+	}()
+
+	statusCode, body, finished := run()
+	if !finished {
+		return statusCode, body
+	}
+
+	err := infostore.Insert(&IdempotencyKeyInfo{
+		IdempotencyKey: key,
+		RequestHash:    requestHash,
+		StatusCode:     statusCode,
+		ResponseBody:   hideIdempotentSecretInfo(body),
+		CreatedTime:    time.Now(),
+	})
+	if err != nil {
+		// the request is done, so only log the failure of keeping the result
+		cblog.Error(err)
+	}
+	return statusCode, body
+}
+
+// returns nil if the key does not exist or is expired
+func getIdempotencyKeyInfo(key string) (*IdempotencyKeyInfo, error) {
+	var info IdempotencyKeyInfo
+	err := infostore.Get(&info, IDEMPOTENCY_KEY_COLUMN, key)
+	if err != nil {
+		if strings.Contains(err.Error(), "does not exist") {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if time.Since(info.CreatedTime) > IDEMPOTENCY_KEY_TTL {
+		if _, err := infostore.Delete(&IdempotencyKeyInfo{}, IDEMPOTENCY_KEY_COLUMN, key); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	return &info, nil
+}
+
+// hides the secret fields in a JSON body, a body which is not JSON is returned as it is.
+func hideIdempotentSecretInfo(body string) string {
+	// keep the numbers as they are
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	var result interface{}
+	if err := decoder.Decode(&result); err != nil {
+		return body
+	}
+	hideSecretFields(result)
+	hidden, err := json.Marshal(result)
+	if err != nil {
+		return body
+	}
+	return string(hidden)
+}
+
+func hideSecretFields(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, field := range v {
+			if idempotencySecretFields[name] {
+				if str, ok := field.(string); ok && str != "" {
+					v[name] = "Hidden for security."
+				}
+				continue
+			}
+			hideSecretFields(field)
+		}
+	case []interface{}:
+		for _, item := range v {
+			hideSecretFields(item)
+		}
+	}
+}
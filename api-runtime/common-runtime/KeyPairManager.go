@@ -122,6 +122,11 @@ func RegisterKey(connectionName string, userIID cres.IID) (*cres.KeyPairInfo, er
 	return &getInfo, nil
 }
 
+// fields of KeyPairReqInfo which can be empty, used by CreateKey() and DryRunCreateKey()
+var keyReqEmptyPermissionList = []string{
+	"resources.IID:SystemId",
+}
+
 // (1) check exist(NameID)
 // (2) generate SP-XID and create reqIID, driverIID
 // (3) create Resource
@@ -138,11 +143,7 @@ func CreateKey(connectionName string, rsType string, reqInfo cres.KeyPairReqInfo
 		return nil, err
 	}
 
-	emptyPermissionList := []string{
-		"resources.IID:SystemId",
-	}
-
-	err = ValidateStruct(reqInfo, emptyPermissionList)
+	err = ValidateStruct(reqInfo, keyReqEmptyPermissionList)
 	if err != nil {
 		cblog.Error(err)
 		return nil, err
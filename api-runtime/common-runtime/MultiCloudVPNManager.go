@@ -0,0 +1,652 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package commonruntime
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	iidm "github.com/cloud-barista/cb-spider/cloud-control-manager/iid-manager"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+// ====================================================================
+// type for GORM
+
+// A Multi-Cloud VPN is a site-to-site VPN between two Spider VPCs in different connections.
+// It is made of a VPN Gateway and a Tunnel on each side and is owned by the requester's connection and VPC.
+// The SystemIds are stored as Spider SystemIds, ex) "spUUID:vgw-0bc7123b7e5cbf79d"
+type MultiCloudVPNIIDInfo struct {
+	ConnectionName      string `gorm:"primaryKey"` // ex) "aws-seoul-config"
+	NameId              string `gorm:"primaryKey"` // ex) "aws-azure-vpn"
+	OwnerVPCName        string // ex) "aws-vpc"
+	GatewaySystemId     string
+	TunnelSystemId      string
+	PeerConnectionName  string // ex) "azure-koreacentral-config"
+	PeerVPCName         string // ex) "azure-vnet"
+	PeerGatewaySystemId string
+	PeerTunnelSystemId  string
+}
+
+func (MultiCloudVPNIIDInfo) TableName() string {
+	return "multi_cloud_vpn_iid_infos"
+}
+
+//====================================================================
+
+func init() {
+	db, err := infostore.Open()
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	db.AutoMigrate(&MultiCloudVPNIIDInfo{})
+	infostore.Close(db)
+}
+
+// MultiCloudVPNReqInfo represents the request to connect two Spider VPCs in different connections.
+type MultiCloudVPNReqInfo struct {
+	Name               string `json:"Name" validate:"required" example:"aws-azure-vpn"`
+	VPCName            string `json:"VPCName" validate:"required" example:"aws-vpc"`
+	PeerConnectionName string `json:"PeerConnectionName" validate:"required" example:"azure-koreacentral-config"`
+	PeerVPCName        string `json:"PeerVPCName" validate:"required" example:"azure-vnet"`
+	PreSharedKey       string `json:"PreSharedKey,omitempty" validate:"omitempty"` // generated by Spider if empty
+
+	TagList []cres.KeyValue `json:"TagList,omitempty" validate:"omitempty"`
+}
+
+// MultiCloudVPNSideInfo represents one half of a Multi-Cloud VPN.
+type MultiCloudVPNSideInfo struct {
+	ConnectionName string              `json:"ConnectionName" validate:"required" example:"aws-seoul-config"`
+	VpcIID         cres.IID            `json:"VpcIID" validate:"required"`
+	VPNGateway     cres.VPNGatewayInfo `json:"VPNGateway" validate:"required"`
+	VPNTunnel      cres.VPNTunnelInfo  `json:"VPNTunnel" validate:"required"`
+}
+
+// MultiCloudVPNInfo represents both halves of a Multi-Cloud VPN as one logical resource.
+// The Status is Up only when the Tunnels of both sides are Up.
+type MultiCloudVPNInfo struct {
+	Name         string                `json:"Name" validate:"required" example:"aws-azure-vpn"`
+	Status       cres.VPNTunnelStatus  `json:"Status" validate:"required" example:"Up"`
+	Side         MultiCloudVPNSideInfo `json:"Side" validate:"required"`
+	PeerSide     MultiCloudVPNSideInfo `json:"PeerSide" validate:"required"`
+	PreSharedKey string                `json:"PreSharedKey,omitempty" validate:"omitempty"` // returned only on creation
+}
+
+// suffix of the Tunnel's driver NameId, the Tunnel is named after its VPN Gateway.
+const MULTICLOUD_VPN_TUNNEL_SUFFIX = "-tunnel"
+
+// AWS rule, which is the strictest: 8~64 characters of [A-Za-z0-9._] not starting with '0'
+const (
+	PSK_MIN_LENGTH      = 8
+	PSK_MAX_LENGTH      = 64
+	PSK_GENERATE_LENGTH = 32
+)
+
+// vpnSide bundles what is needed to provision one half of a Multi-Cloud VPN.
+type vpnSide struct {
+	connectionName string
+	vpcIIDInfo     VPCIIDInfo
+	vpcCIDR        string
+	handler        cres.VPNGatewayHandler
+}
+
+//================ Multi-Cloud VPN Handler
+
+// (1) check both VPCs are registered in Spider
+// (2) check exist(NameID)
+// (3) create a VPN Gateway on both sides
+// (4) create a Tunnel on both sides with the peer's public IP, CIDR and the pre-shared key
+// (5) insert spiderIIDs
+func CreateMultiCloudVPN(connectionName string, reqInfo MultiCloudVPNReqInfo, IDTransformMode string) (*MultiCloudVPNInfo, error) {
+	cblog.Info("call CreateMultiCloudVPN()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	reqInfo.Name = strings.TrimSpace(reqInfo.Name)
+	reqInfo.VPCName = strings.TrimSpace(reqInfo.VPCName)
+	reqInfo.PeerConnectionName = strings.TrimSpace(reqInfo.PeerConnectionName)
+	reqInfo.PeerVPCName = strings.TrimSpace(reqInfo.PeerVPCName)
+
+	emptyPermissionList := []string{
+		"commonruntime.MultiCloudVPNReqInfo:PreSharedKey",
+	}
+
+	err = ValidateStruct(reqInfo, emptyPermissionList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if reqInfo.PeerConnectionName == connectionName {
+		err := fmt.Errorf("Multi-Cloud VPN requires two different connections, use VPC Peering in the same connection!")
+		cblog.Error(err)
+		return nil, err
+	}
+
+	preSharedKey := reqInfo.PreSharedKey
+	if preSharedKey == "" {
+		preSharedKey, err = generatePreSharedKey()
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	} else if err := validatePreSharedKey(preSharedKey); err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	vpcSPLock.RLock(connectionName, reqInfo.VPCName)
+	defer vpcSPLock.RUnlock(connectionName, reqInfo.VPCName)
+	vpcSPLock.RLock(reqInfo.PeerConnectionName, reqInfo.PeerVPCName)
+	defer vpcSPLock.RUnlock(reqInfo.PeerConnectionName, reqInfo.PeerVPCName)
+
+	// (1) check both VPCs are registered in Spider
+	side, err := getVPNSide(connectionName, reqInfo.VPCName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	peerSide, err := getVPNSide(reqInfo.PeerConnectionName, reqInfo.PeerVPCName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if isOverlapCIDR(side.vpcCIDR, peerSide.vpcCIDR) {
+		err := fmt.Errorf("The VPC CIDRs(%s, %s) overlap, traffic cannot be routed through the VPN!", side.vpcCIDR, peerSide.vpcCIDR)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	vpnSPLock.Lock(connectionName, reqInfo.Name)
+	defer vpnSPLock.Unlock(connectionName, reqInfo.Name)
+
+	// (2) check exist(NameID)
+	bool_ret, err := infostore.HasByConditions(&MultiCloudVPNIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, reqInfo.Name)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if bool_ret {
+		err := fmt.Errorf("Multi-Cloud VPN-" + reqInfo.Name + " already exists!")
+		cblog.Error(err)
+		return nil, err
+	}
+
+	spUUID, err := newVPNGatewaySpUUID(connectionName, reqInfo.Name, IDTransformMode)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	peerSpUUID, err := newVPNGatewaySpUUID(reqInfo.PeerConnectionName, reqInfo.Name, IDTransformMode)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// undo the created parts in reverse order if a later step fails
+	var rollbackList []func() error
+	rollback := func(err error) error {
+		for i := len(rollbackList) - 1; i >= 0; i-- {
+			if err2 := rollbackList[i](); err2 != nil {
+				cblog.Error(err2)
+				err = fmt.Errorf(err.Error() + ", " + err2.Error())
+			}
+		}
+		return err
+	}
+
+	// (3) create a VPN Gateway on both sides
+	gwInfo, err := side.createGateway(spUUID, reqInfo.TagList, &rollbackList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, rollback(err)
+	}
+	peerGWInfo, err := peerSide.createGateway(peerSpUUID, reqInfo.TagList, &rollbackList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, rollback(err)
+	}
+
+	// (4) create a Tunnel on both sides, each pointing to the other side
+	tunnelInfo, err := side.createTunnel(gwInfo, peerGWInfo.PublicIP, peerSide.vpcCIDR, preSharedKey, reqInfo.TagList, &rollbackList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, rollback(err)
+	}
+	peerTunnelInfo, err := peerSide.createTunnel(peerGWInfo, gwInfo.PublicIP, side.vpcCIDR, preSharedKey, reqInfo.TagList, &rollbackList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, rollback(err)
+	}
+
+	// refresh the first Tunnel's status, which was evaluated before its peer Tunnel existed
+	if refreshedInfo, err := side.handler.GetVPNTunnel(tunnelInfo.IId); err != nil {
+		cblog.Info(err)
+	} else {
+		tunnelInfo = refreshedInfo
+	}
+
+	// (5) insert spiderIIDs
+	iidInfo := MultiCloudVPNIIDInfo{
+		ConnectionName:      connectionName,
+		NameId:              reqInfo.Name,
+		OwnerVPCName:        side.vpcIIDInfo.NameId,
+		GatewaySystemId:     spUUID + ":" + gwInfo.IId.SystemId,
+		TunnelSystemId:      tunnelInfo.IId.NameId + ":" + tunnelInfo.IId.SystemId,
+		PeerConnectionName:  peerSide.connectionName,
+		PeerVPCName:         peerSide.vpcIIDInfo.NameId,
+		PeerGatewaySystemId: peerSpUUID + ":" + peerGWInfo.IId.SystemId,
+		PeerTunnelSystemId:  peerTunnelInfo.IId.NameId + ":" + peerTunnelInfo.IId.SystemId,
+	}
+	err = infostore.Insert(&iidInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, rollback(err)
+	}
+
+	info := MultiCloudVPNInfo{
+		Name:         reqInfo.Name,
+		Side:         MultiCloudVPNSideInfo{ConnectionName: side.connectionName, VPNGateway: gwInfo, VPNTunnel: tunnelInfo},
+		PeerSide:     MultiCloudVPNSideInfo{ConnectionName: peerSide.connectionName, VPNGateway: peerGWInfo, VPNTunnel: peerTunnelInfo},
+		PreSharedKey: preSharedKey,
+	}
+	setMultiCloudVPNUserIIDs(&iidInfo, side.vpcIIDInfo, peerSide.vpcIIDInfo, &info)
+
+	return &info, nil
+}
+
+// get the VPC IIDInfo, the VPC CIDR and the VPN Gateway handler of a side
+func getVPNSide(connectionName string, vpcName string) (*vpnSide, error) {
+	var vpcIIDInfo VPCIIDInfo
+	err := infostore.GetByConditions(&vpcIIDInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, vpcName)
+	if err != nil {
+		return nil, fmt.Errorf("The VPC '%s' is not registered in the connection '%s': %s", vpcName, connectionName, err.Error())
+	}
+
+	drv, err := ccm.GetCloudDriver(connectionName)
+	if err != nil {
+		return nil, err
+	}
+	if !drv.GetDriverCapability().VPNGatewayHandler {
+		return nil, fmt.Errorf("The connection %s does not support the VPN Gateway!", connectionName)
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	vpcHandler, err := cldConn.CreateVPCHandler()
+	if err != nil {
+		return nil, err
+	}
+	vpcInfo, err := vpcHandler.GetVPC(getDriverIID(cres.IID{NameId: vpcIIDInfo.NameId, SystemId: vpcIIDInfo.SystemId}))
+	if err != nil {
+		return nil, err
+	}
+	if vpcInfo.IPv4_CIDR == "" {
+		return nil, fmt.Errorf("The VPC '%s' in the connection '%s' has no IPv4 CIDR to route through the VPN!", vpcName, connectionName)
+	}
+
+	handler, err := cldConn.CreateVPNGatewayHandler()
+	if err != nil {
+		return nil, err
+	}
+
+	return &vpnSide{connectionName: connectionName, vpcIIDInfo: vpcIIDInfo, vpcCIDR: vpcInfo.IPv4_CIDR, handler: handler}, nil
+}
+
+func newVPNGatewaySpUUID(connectionName string, nameID string, IDTransformMode string) (string, error) {
+	if GetID_MGMT(IDTransformMode) == "ON" { // Use IID Management
+		return iidm.New(connectionName, VPNGATEWAY, nameID)
+	}
+	// No Use IID Management
+	return nameID, nil
+}
+
+func (side *vpnSide) createGateway(spUUID string, tagList []cres.KeyValue, rollbackList *[]func() error) (cres.VPNGatewayInfo, error) {
+	gwInfo, err := side.handler.CreateVPNGateway(cres.VPNGatewayReqInfo{
+		IId:     cres.IID{NameId: spUUID, SystemId: ""},
+		VpcIID:  getDriverIID(cres.IID{NameId: side.vpcIIDInfo.NameId, SystemId: side.vpcIIDInfo.SystemId}),
+		TagList: tagList,
+	})
+	if err != nil {
+		return cres.VPNGatewayInfo{}, fmt.Errorf("%s: %s", side.connectionName, err.Error())
+	}
+
+	*rollbackList = append(*rollbackList, func() error {
+		cblog.Info("<<ROLLBACK:TRY:VPNGATEWAY-CSP>> " + gwInfo.IId.SystemId)
+		_, err := side.handler.DeleteVPNGateway(gwInfo.IId)
+		return err
+	})
+	return gwInfo, nil
+}
+
+func (side *vpnSide) createTunnel(gwInfo cres.VPNGatewayInfo, peerPublicIP string, peerCIDR string, preSharedKey string,
+	tagList []cres.KeyValue, rollbackList *[]func() error) (cres.VPNTunnelInfo, error) {
+
+	tunnelInfo, err := side.handler.CreateVPNTunnel(cres.VPNTunnelReqInfo{
+		IId:           cres.IID{NameId: gwInfo.IId.NameId + MULTICLOUD_VPN_TUNNEL_SUFFIX, SystemId: ""},
+		VPNGatewayIID: gwInfo.IId,
+		PeerPublicIP:  peerPublicIP,
+		PeerCIDRList:  []string{peerCIDR},
+		PreSharedKey:  preSharedKey,
+		TagList:       tagList,
+	})
+	if err != nil {
+		return cres.VPNTunnelInfo{}, fmt.Errorf("%s: %s", side.connectionName, err.Error())
+	}
+
+	*rollbackList = append(*rollbackList, func() error {
+		cblog.Info("<<ROLLBACK:TRY:VPNTUNNEL-CSP>> " + tunnelInfo.IId.SystemId)
+		_, err := side.handler.DeleteVPNTunnel(tunnelInfo.IId)
+		return err
+	})
+	return tunnelInfo, nil
+}
+
+// generate a random pre-shared key which every CSP accepts
+func generatePreSharedKey() (string, error) {
+	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	const charset = letters + "0123456789"
+
+	key := make([]byte, PSK_GENERATE_LENGTH)
+	for i := range key {
+		chars := charset
+		if i == 0 {
+			chars = letters
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+		if err != nil {
+			return "", err
+		}
+		key[i] = chars[n.Int64()]
+	}
+	return string(key), nil
+}
+
+func validatePreSharedKey(key string) error {
+	if len(key) < PSK_MIN_LENGTH || len(key) > PSK_MAX_LENGTH {
+		return fmt.Errorf("The PreSharedKey must be %d~%d characters!", PSK_MIN_LENGTH, PSK_MAX_LENGTH)
+	}
+	if key[0] == '0' {
+		return fmt.Errorf("The PreSharedKey cannot start with '0'!")
+	}
+	for _, c := range key {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '.' || c == '_') {
+			return fmt.Errorf("The PreSharedKey can contain only alphanumeric characters, periods(.) and underscores(_)!")
+		}
+	}
+	return nil
+}
+
+// set UserIIDs of the VPCs, VPN Gateways and Tunnels of both sides
+func setMultiCloudVPNUserIIDs(iidInfo *MultiCloudVPNIIDInfo, vpcIIDInfo VPCIIDInfo, peerVPCIIDInfo VPCIIDInfo, info *MultiCloudVPNInfo) {
+	info.Side.VpcIID = getUserIID(cres.IID{NameId: vpcIIDInfo.NameId, SystemId: vpcIIDInfo.SystemId})
+	info.Side.VPNGateway.VpcIID = info.Side.VpcIID
+	info.Side.VPNGateway.IId = getUserIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.GatewaySystemId})
+	info.Side.VPNTunnel.VPNGatewayIID = info.Side.VPNGateway.IId
+	info.Side.VPNTunnel.IId = getUserIID(cres.IID{NameId: iidInfo.NameId + MULTICLOUD_VPN_TUNNEL_SUFFIX, SystemId: iidInfo.TunnelSystemId})
+
+	info.PeerSide.VpcIID = getUserIID(cres.IID{NameId: peerVPCIIDInfo.NameId, SystemId: peerVPCIIDInfo.SystemId})
+	info.PeerSide.VPNGateway.VpcIID = info.PeerSide.VpcIID
+	info.PeerSide.VPNGateway.IId = getUserIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.PeerGatewaySystemId})
+	info.PeerSide.VPNTunnel.VPNGatewayIID = info.PeerSide.VPNGateway.IId
+	info.PeerSide.VPNTunnel.IId = getUserIID(cres.IID{NameId: iidInfo.NameId + MULTICLOUD_VPN_TUNNEL_SUFFIX, SystemId: iidInfo.PeerTunnelSystemId})
+
+	info.Status = getMultiCloudVPNStatus(info.Side.VPNTunnel.Status, info.PeerSide.VPNTunnel.Status)
+}
+
+// Up when both Tunnels are Up, Down when either is Down, otherwise Pending
+func getMultiCloudVPNStatus(status cres.VPNTunnelStatus, peerStatus cres.VPNTunnelStatus) cres.VPNTunnelStatus {
+	if status == cres.VPNTunnelUp && peerStatus == cres.VPNTunnelUp {
+		return cres.VPNTunnelUp
+	}
+	if status == cres.VPNTunnelDown || peerStatus == cres.VPNTunnelDown {
+		return cres.VPNTunnelDown
+	}
+	return cres.VPNTunnelPending
+}
+
+// get both halves of a Multi-Cloud VPN from the CSPs
+func getMultiCloudVPNInfo(iidInfo *MultiCloudVPNIIDInfo) (*MultiCloudVPNInfo, error) {
+	info := MultiCloudVPNInfo{Name: iidInfo.NameId}
+
+	var vpcIIDInfoList [2]VPCIIDInfo
+	for i, s := range []struct {
+		connectionName  string
+		vpcName         string
+		gatewaySystemId string
+		tunnelSystemId  string
+		sideInfo        *MultiCloudVPNSideInfo
+	}{
+		{iidInfo.ConnectionName, iidInfo.OwnerVPCName, iidInfo.GatewaySystemId, iidInfo.TunnelSystemId, &info.Side},
+		{iidInfo.PeerConnectionName, iidInfo.PeerVPCName, iidInfo.PeerGatewaySystemId, iidInfo.PeerTunnelSystemId, &info.PeerSide},
+	} {
+		err := infostore.GetByConditions(&vpcIIDInfoList[i], CONNECTION_NAME_COLUMN, s.connectionName, NAME_ID_COLUMN, s.vpcName)
+		if err != nil {
+			return nil, err
+		}
+
+		cldConn, err := ccm.GetCloudConnection(s.connectionName)
+		if err != nil {
+			return nil, err
+		}
+		handler, err := cldConn.CreateVPNGatewayHandler()
+		if err != nil {
+			return nil, err
+		}
+
+		gwInfo, err := handler.GetVPNGateway(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: s.gatewaySystemId}))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", s.connectionName, err.Error())
+		}
+		tunnelInfo, err := handler.GetVPNTunnel(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: s.tunnelSystemId}))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", s.connectionName, err.Error())
+		}
+
+		*s.sideInfo = MultiCloudVPNSideInfo{ConnectionName: s.connectionName, VPNGateway: gwInfo, VPNTunnel: tunnelInfo}
+	}
+
+	setMultiCloudVPNUserIIDs(iidInfo, vpcIIDInfoList[0], vpcIIDInfoList[1], &info)
+	return &info, nil
+}
+
+func ListMultiCloudVPN(connectionName string) ([]*MultiCloudVPNInfo, error) {
+	cblog.Info("call ListMultiCloudVPN()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (1) get IID:list
+	var iidInfoList []*MultiCloudVPNIIDInfo
+	err = infostore.ListByCondition(&iidInfoList, CONNECTION_NAME_COLUMN, connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	infoList := []*MultiCloudVPNInfo{}
+
+	// (2) Get MultiCloudVPNInfo-list with IID-list
+	for _, iidInfo := range iidInfoList {
+		vpnSPLock.RLock(connectionName, iidInfo.NameId)
+		info, err := getMultiCloudVPNInfo(iidInfo)
+		vpnSPLock.RUnlock(connectionName, iidInfo.NameId)
+		if err != nil {
+			if checkNotFoundError(err) {
+				cblog.Info(err)
+				continue
+			}
+			cblog.Error(err)
+			return nil, err
+		}
+		infoList = append(infoList, info)
+	}
+
+	return infoList, nil
+}
+
+// (1) get IID(NameId)
+// (2) get both halves from the CSPs
+func GetMultiCloudVPN(connectionName string, nameID string) (*MultiCloudVPNInfo, error) {
+	cblog.Info("call GetMultiCloudVPN()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	vpnSPLock.RLock(connectionName, nameID)
+	defer vpnSPLock.RUnlock(connectionName, nameID)
+
+	// (1) get IID(NameId)
+	var iidInfo MultiCloudVPNIIDInfo
+	err = infostore.GetByConditions(&iidInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) get both halves from the CSPs
+	info, err := getMultiCloudVPNInfo(&iidInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// (1) get spiderIIDs for creating driverIIDs
+// (2) delete the Tunnels and then the VPN Gateways of both sides
+// (3) delete IID
+func DeleteMultiCloudVPN(connectionName string, nameID string, force string) (bool, error) {
+	cblog.Info("call DeleteMultiCloudVPN()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	vpnSPLock.Lock(connectionName, nameID)
+	defer vpnSPLock.Unlock(connectionName, nameID)
+
+	// (1) get spiderIIDs for creating driverIIDs
+	var iidInfo MultiCloudVPNIIDInfo
+	err = infostore.GetByConditions(&iidInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	handlerMap := map[string]cres.VPNGatewayHandler{}
+	for _, connName := range []string{iidInfo.ConnectionName, iidInfo.PeerConnectionName} {
+		cldConn, err := ccm.GetCloudConnection(connName)
+		if err != nil {
+			cblog.Error(err)
+			return false, err
+		}
+		handlerMap[connName], err = cldConn.CreateVPNGatewayHandler()
+		if err != nil {
+			cblog.Error(err)
+			return false, err
+		}
+	}
+
+	// (2) delete the Tunnels and then the VPN Gateways of both sides
+	type deleteStep struct {
+		connectionName string
+		systemId       string
+		isTunnel       bool
+	}
+	stepList := []deleteStep{
+		{iidInfo.ConnectionName, iidInfo.TunnelSystemId, true},
+		{iidInfo.PeerConnectionName, iidInfo.PeerTunnelSystemId, true},
+		{iidInfo.ConnectionName, iidInfo.GatewaySystemId, false},
+		{iidInfo.PeerConnectionName, iidInfo.PeerGatewaySystemId, false},
+	}
+	result := true
+	for _, step := range stepList {
+		handler := handlerMap[step.connectionName]
+		driverIId := getDriverIID(cres.IID{NameId: nameID, SystemId: step.systemId})
+
+		var ret bool
+		if step.isTunnel {
+			ret, err = handler.DeleteVPNTunnel(driverIId)
+		} else {
+			ret, err = handler.DeleteVPNGateway(driverIId)
+		}
+		if err != nil {
+			err = fmt.Errorf("%s: %s", step.connectionName, err.Error())
+			cblog.Error(err)
+			if force != "true" {
+				return false, err
+			}
+		}
+		if !ret {
+			result = false
+			if force != "true" {
+				return false, nil
+			}
+		}
+	}
+
+	// (3) delete IID
+	_, err = infostore.DeleteByConditions(&MultiCloudVPNIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+	if err != nil {
+		cblog.Error(err)
+		if force != "true" {
+			return false, err
+		}
+	}
+
+	return result, nil
+}
+
+// check whether a VPC is used by any Multi-Cloud VPN on either side
+func checkMultiCloudVPNUsingVPC(connectionName string, vpcName string) (bool, error) {
+	bool_ret, err := infostore.HasByConditions(&MultiCloudVPNIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, OWNER_VPC_NAME_COLUMN, vpcName)
+	if err != nil || bool_ret {
+		return bool_ret, err
+	}
+	return infostore.HasByConditions(&MultiCloudVPNIIDInfo{}, PEER_CONNECTION_NAME_COLUMN, connectionName, PEER_VPC_NAME_COLUMN, vpcName)
+}
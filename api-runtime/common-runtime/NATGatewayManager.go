@@ -0,0 +1,406 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package commonruntime
+
+import (
+	"fmt"
+
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	iidm "github.com/cloud-barista/cb-spider/cloud-control-manager/iid-manager"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+// ====================================================================
+// type for GORM
+
+type NATGatewayIIDInfo VPCDependentIIDInfo
+
+func (NATGatewayIIDInfo) TableName() string {
+	return "nat_gateway_iid_infos"
+}
+
+//====================================================================
+
+func init() {
+	db, err := infostore.Open()
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	db.AutoMigrate(&NATGatewayIIDInfo{})
+	infostore.Close(db)
+}
+
+//================ NAT Gateway Handler
+
+// (1) check the VPC and the Subnet are registered in Spider
+// (2) check exist(NameID)
+// (3) generate SP-XID and create reqIID, driverIID
+// (4) create Resource
+// (5) insert spiderIID
+// (6) create userIID
+func CreateNATGateway(connectionName string, rsType string, reqInfo cres.NATGatewayReqInfo, IDTransformMode string) (*cres.NATGatewayInfo, error) {
+	cblog.Info("call CreateNATGateway()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	emptyPermissionList := []string{
+		"resources.IID:SystemId",
+	}
+
+	err = ValidateStruct(reqInfo, emptyPermissionList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	vpcSPLock.RLock(connectionName, reqInfo.VpcIID.NameId)
+	defer vpcSPLock.RUnlock(connectionName, reqInfo.VpcIID.NameId)
+
+	// (1) check the VPC and the Subnet are registered in Spider
+	var vpcIIDInfo VPCIIDInfo
+	err = infostore.GetByConditions(&vpcIIDInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, reqInfo.VpcIID.NameId)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	var subnetIIDInfo SubnetIIDInfo
+	err = infostore.GetBy3Conditions(&subnetIIDInfo, CONNECTION_NAME_COLUMN, connectionName, OWNER_VPC_NAME_COLUMN, vpcIIDInfo.NameId,
+		NAME_ID_COLUMN, reqInfo.SubnetIID.NameId)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	reqInfo.VpcIID = getDriverIID(cres.IID{NameId: vpcIIDInfo.NameId, SystemId: vpcIIDInfo.SystemId})
+	reqInfo.SubnetIID = getDriverIID(cres.IID{NameId: subnetIIDInfo.NameId, SystemId: subnetIIDInfo.SystemId})
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateNATGatewayHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	natGatewaySPLock.Lock(connectionName, reqInfo.IId.NameId)
+	defer natGatewaySPLock.Unlock(connectionName, reqInfo.IId.NameId)
+
+	// (2) check exist(NameID)
+	bool_ret, err := infostore.HasByConditions(&NATGatewayIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, reqInfo.IId.NameId)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if bool_ret {
+		err := fmt.Errorf(rsType + "-" + reqInfo.IId.NameId + " already exists!")
+		cblog.Error(err)
+		return nil, err
+	}
+
+	spUUID := ""
+	if GetID_MGMT(IDTransformMode) == "ON" { // Use IID Management
+		// (3) generate SP-XID and create reqIID, driverIID
+		spUUID, err = iidm.New(connectionName, rsType, reqInfo.IId.NameId)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	} else { // No Use IID Management
+		spUUID = reqInfo.IId.NameId
+	}
+
+	// reqIID
+	reqIId := cres.IID{NameId: reqInfo.IId.NameId, SystemId: spUUID}
+	// driverIID
+	driverIId := cres.IID{NameId: spUUID, SystemId: ""}
+	reqInfo.IId = driverIId
+
+	// (4) create Resource
+	info, err := handler.CreateNATGateway(reqInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// create spiderIID: {reqNameID, "driverNameID:driverSystemID"}
+	spiderIId := cres.IID{NameId: reqIId.NameId, SystemId: spUUID + ":" + info.IId.SystemId}
+
+	// (5) insert spiderIID
+	iidInfo := NATGatewayIIDInfo{ConnectionName: connectionName, NameId: spiderIId.NameId, SystemId: spiderIId.SystemId,
+		OwnerVPCName: vpcIIDInfo.NameId}
+	err = infostore.Insert(&iidInfo)
+	if err != nil {
+		cblog.Error(err)
+		// rollback
+		cblog.Info("<<ROLLBACK:TRY:NATGATEWAY-CSP>> " + info.IId.SystemId)
+		_, err2 := handler.DeleteNATGateway(info.IId)
+		if err2 != nil {
+			cblog.Error(err2)
+			return nil, fmt.Errorf(err.Error() + ", " + err2.Error())
+		}
+		return nil, err
+	}
+
+	// (6) create userIID: {reqNameID, driverSystemID}
+	info.IId = getUserIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+	info.VpcIID = getUserIID(cres.IID{NameId: vpcIIDInfo.NameId, SystemId: vpcIIDInfo.SystemId})
+	info.SubnetIID = getUserIID(cres.IID{NameId: subnetIIDInfo.NameId, SystemId: subnetIIDInfo.SystemId})
+
+	return &info, nil
+}
+
+// set UserIIDs of NAT Gateway, VPC and Subnet with the info-store
+func setNATGatewayUserIIDs(iidInfo *NATGatewayIIDInfo, info *cres.NATGatewayInfo) error {
+	info.IId = getUserIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+
+	var vpcIIDInfo VPCIIDInfo
+	err := infostore.GetByConditions(&vpcIIDInfo, CONNECTION_NAME_COLUMN, iidInfo.ConnectionName, NAME_ID_COLUMN, iidInfo.OwnerVPCName)
+	if err != nil {
+		return err
+	}
+	info.VpcIID = getUserIID(cres.IID{NameId: vpcIIDInfo.NameId, SystemId: vpcIIDInfo.SystemId})
+
+	var subnetIIDInfo SubnetIIDInfo
+	err = infostore.GetByConditionsAndContain(&subnetIIDInfo, CONNECTION_NAME_COLUMN, iidInfo.ConnectionName,
+		OWNER_VPC_NAME_COLUMN, iidInfo.OwnerVPCName, SYSTEM_ID_COLUMN, info.SubnetIID.SystemId)
+	if err != nil {
+		// not registered in Spider
+		info.SubnetIID.NameId = ""
+		return nil
+	}
+	info.SubnetIID = getUserIID(cres.IID{NameId: subnetIIDInfo.NameId, SystemId: subnetIIDInfo.SystemId})
+	return nil
+}
+
+func ListNATGateway(connectionName string, rsType string) ([]*cres.NATGatewayInfo, error) {
+	cblog.Info("call ListNATGateway()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateNATGatewayHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (1) get IID:list
+	var iidInfoList []*NATGatewayIIDInfo
+	err = infostore.ListByCondition(&iidInfoList, CONNECTION_NAME_COLUMN, connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	infoList := []*cres.NATGatewayInfo{}
+	if len(iidInfoList) <= 0 {
+		return infoList, nil
+	}
+
+	// (2) Get NATGatewayInfo-list with IID-list
+	for _, iidInfo := range iidInfoList {
+
+		natGatewaySPLock.RLock(connectionName, iidInfo.NameId)
+
+		info, err := handler.GetNATGateway(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}))
+		if err != nil {
+			natGatewaySPLock.RUnlock(connectionName, iidInfo.NameId)
+			if checkNotFoundError(err) {
+				cblog.Info(err)
+				continue
+			}
+			cblog.Error(err)
+			return nil, err
+		}
+		natGatewaySPLock.RUnlock(connectionName, iidInfo.NameId)
+
+		// (3) set ResourceInfo(IID.NameId)
+		err = setNATGatewayUserIIDs(iidInfo, &info)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+
+		infoList = append(infoList, &info)
+	}
+
+	return infoList, nil
+}
+
+// (1) get IID(NameId)
+// (2) get resource(SystemId)
+// (3) set ResourceInfo(IID.NameId)
+func GetNATGateway(connectionName string, rsType string, nameID string) (*cres.NATGatewayInfo, error) {
+	cblog.Info("call GetNATGateway()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateNATGatewayHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	natGatewaySPLock.RLock(connectionName, nameID)
+	defer natGatewaySPLock.RUnlock(connectionName, nameID)
+
+	// (1) get IID(NameId)
+	var iidInfo NATGatewayIIDInfo
+	err = infostore.GetByConditions(&iidInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) get resource(SystemId)
+	info, err := handler.GetNATGateway(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}))
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (3) set ResourceInfo(IID.NameId)
+	err = setNATGatewayUserIIDs(&iidInfo, &info)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// (1) get spiderIID for creating driverIID
+// (2) delete Resource(SystemId)
+// (3) delete IID
+func DeleteNATGateway(connectionName string, rsType string, nameID string, force string) (bool, error) {
+	cblog.Info("call DeleteNATGateway()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	handler, err := cldConn.CreateNATGatewayHandler()
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	natGatewaySPLock.Lock(connectionName, nameID)
+	defer natGatewaySPLock.Unlock(connectionName, nameID)
+
+	// (1) get spiderIID for creating driverIID
+	var iidInfo NATGatewayIIDInfo
+	err = infostore.GetByConditions(&iidInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	// (2) delete Resource(SystemId)
+	driverIId := getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+	result, err := handler.DeleteNATGateway(driverIId)
+	if err != nil {
+		cblog.Error(err)
+		if force != "true" {
+			return false, err
+		}
+	}
+
+	if force != "true" {
+		if !result {
+			return result, nil
+		}
+	}
+
+	// (3) delete IID
+	_, err = infostore.DeleteByConditions(&NATGatewayIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+	if err != nil {
+		cblog.Error(err)
+		if force != "true" {
+			return false, err
+		}
+	}
+
+	return result, nil
+}
+
+func CountAllNATGateways() (int64, error) {
+	var info NATGatewayIIDInfo
+	count, err := infostore.CountAllNameIDs(&info)
+	if err != nil {
+		cblog.Error(err)
+		return count, err
+	}
+
+	return count, nil
+}
+
+func CountNATGatewaysByConnection(connectionName string) (int64, error) {
+	var info NATGatewayIIDInfo
+	count, err := infostore.CountNameIDsByConnection(&info, connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return count, err
+	}
+
+	return count, nil
+}
@@ -442,6 +442,13 @@ func CreateNLB(connectionName string, rsType string, reqInfo cres.NLBInfo, IDTra
 	//     ex) userIID {"seoul-service", "i-0bc7123b7e5cbf79d"}
 	info.IId = getUserIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
 
+	// register the Listener's DNSName(or IP) with the DNSName tag
+	listenerTarget := info.Listener.DNSName
+	if listenerTarget == "" {
+		listenerTarget = info.Listener.IP
+	}
+	autoUpsertDNSRecord(connectionName, reqInfo.TagList, listenerTarget)
+
 	return &info, nil
 }
 
@@ -766,6 +773,7 @@ func AddNLBVMs(connectionName string, nlbName string, vmNames []string) (*cres.N
 		cblog.Error(err)
 		return nil, err
 	}
+	invalidateResourceSnapshot(connectionName, NLB, nlbName)
 
 	// (3) Get NLBInfo
 	info, err := handler.GetNLB(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}))
@@ -878,6 +886,7 @@ func RemoveNLBVMs(connectionName string, nlbName string, vmNames []string) (bool
 		cblog.Error(err)
 		return false, err
 	}
+	invalidateResourceSnapshot(connectionName, NLB, nlbName)
 
 	return result, nil
 }
@@ -960,6 +969,7 @@ func ChangeListener(connectionName string, nlbName string, listener cres.Listene
 		cblog.Error(err)
 		return nil, err
 	}
+	invalidateResourceSnapshot(connectionName, NLB, nlbName)
 
 	// (3) Get NLBInfo
 	info, err := handler.GetNLB(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}))
@@ -1076,6 +1086,7 @@ func ChangeVMGroup(connectionName string, nlbName string, vmGroup cres.VMGroupIn
 		cblog.Error(err)
 		return nil, err
 	}
+	invalidateResourceSnapshot(connectionName, NLB, nlbName)
 
 	// (3) Get NLBInfo
 	info, err := handler.GetNLB(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}))
@@ -1189,6 +1200,7 @@ func ChangeHealthChecker(connectionName string, nlbName string, healthChecker cr
 		cblog.Error(err)
 		return nil, err
 	}
+	invalidateResourceSnapshot(connectionName, NLB, nlbName)
 
 	// (3) Get NLBInfo
 	info, err := handler.GetNLB(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}))
@@ -9,6 +9,10 @@
 package commonruntime
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
 	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
 	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
 )
@@ -104,6 +108,79 @@ func GetPriceInfo(connectionName string, productFamily string, regionName string
 	return priceInfo, nil
 }
 
+// keywords of the CSP's PricingPolicy names for each PurchaseOption
+// ex) AWS: OnDemand, Azure: Consumption/Spot, Tencent: POSTPAID_BY_HOUR/SPOTPAID, GCP: Preemptible
+// Spot prices are not Preemptible prices, so a CSP without Preemptible pricing has no match.
+var purchaseOptionPolicyKeywords = map[cres.PurchaseOptionType][]string{
+	cres.OnDemand:    {"ondemand", "on-demand", "consumption", "postpaid", "payasyougo"},
+	cres.Spot:        {"spot"},
+	cres.Preemptible: {"preemptible"},
+}
+
+// GetVMPrice returns the PricingPolicies of a VMSpec for a PurchaseOption(OnDemand, Spot, Preemptible).
+// The Spot and Preemptible prices are selected from the PricingPolicies of the ComputeInstance price info.
+func GetVMPrice(connectionName string, regionName string, vmSpecName string, purchaseOption string) ([]cres.PricingPolicies, error) {
+	cblog.Info("call GetVMPrice()")
+
+	vmSpecName, err := EmptyCheckAndTrim("vmSpecName", vmSpecName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	optionType, err := NormalizePurchaseOptionType(purchaseOption)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	filterList := []cres.KeyValue{{Key: "instanceType", Value: vmSpecName}}
+	priceInfo, err := GetPriceInfo(connectionName, "ComputeInstance", regionName, filterList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	var priceData cres.CloudPriceData
+	err = json.Unmarshal([]byte(priceInfo), &priceData)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	policyList := []cres.PricingPolicies{}
+	for _, cloudPrice := range priceData.CloudPriceList {
+		for _, price := range cloudPrice.PriceList {
+			if !strings.EqualFold(price.ProductInfo.InstanceType, vmSpecName) {
+				continue
+			}
+			for _, policy := range price.PriceInfo.PricingPolicies {
+				if matchPurchaseOption(policy.PricingPolicy, optionType) {
+					policyList = append(policyList, policy)
+				}
+			}
+		}
+	}
+
+	if len(policyList) == 0 {
+		err := fmt.Errorf("There is no %s price of the VMSpec %s in the region %s!", optionType, vmSpecName, regionName)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return policyList, nil
+}
+
+func matchPurchaseOption(pricingPolicy string, optionType cres.PurchaseOptionType) bool {
+	policyName := strings.ToLower(pricingPolicy)
+	for _, keyword := range purchaseOptionPolicyKeywords[optionType] {
+		if strings.Contains(policyName, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
 func getProviderSpecificPFName(providerName, pfName string) string {
 
 	if pfName != "ComputeInstance" {
@@ -0,0 +1,474 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package commonruntime
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+// Resource types to reconcile, in the order of dependency(VPC first)
+var RECONCILE_RESOURCE_TYPES = []string{VPC, SG, KEY, VM, DISK, MYIMAGE, NLB, CLUSTER}
+
+const MIN_RECONCILE_INTERVAL = 60 // secs
+const MAX_ADOPT_NAME_LENGTH = 60
+
+// Reconcile actions
+const (
+	RECONCILE_UNREGISTER = "unregister" // unregister stale Spider IIDs(OnlySpiderList)
+	RECONCILE_ADOPT      = "adopt"      // register CSP-only resources(OnlyCSPList) with generated names
+	RECONCILE_DELETE     = "delete"     // delete CSP-only resources(OnlyCSPList)
+)
+
+// Reconcile action result status
+const (
+	RECONCILE_SUCCEEDED = "Succeeded"
+	RECONCILE_FAILED    = "Failed"
+)
+
+// ReconcileResourceInfo represents the drift of a resource type between Spider and the CSP.
+type ReconcileResourceInfo struct {
+	ResourceType   string      `json:"ResourceType" validate:"required" example:"vm"`
+	MappedCount    int         `json:"MappedCount" validate:"required" example:"3"`
+	OnlySpiderList []*cres.IID `json:"OnlySpiderList" validate:"required"` // stale Spider IIDs, the CSP resource does not exist
+	OnlyCSPList    []*cres.IID `json:"OnlyCSPList" validate:"required"`    // orphan CSP resources, not managed by Spider
+	ErrorMSG       string      `json:"ErrorMSG,omitempty" validate:"omitempty"`
+}
+
+// ReconcileReportInfo represents the drift report of a connection.
+type ReconcileReportInfo struct {
+	ConnectionName string                   `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	CheckedTime    time.Time                `json:"CheckedTime" validate:"required" example:"2024-10-20T10:00:00Z"`
+	HasDrift       bool                     `json:"HasDrift" validate:"required" example:"true"`
+	ResourceList   []*ReconcileResourceInfo `json:"ResourceList" validate:"required"`
+}
+
+// ReconcileActionResultInfo represents the result of a reconcile action for a resource.
+type ReconcileActionResultInfo struct {
+	Id       string `json:"Id" validate:"required" example:"i-0bc7123b7e5cbf79d"`                   // NameId for unregister, SystemId for adopt and delete
+	Status   string `json:"Status" validate:"required" example:"Succeeded"`                         // Succeeded | Failed
+	NameId   string `json:"NameId,omitempty" validate:"omitempty" example:"vm-i-0bc7123b7e5cbf79d"` // generated NameId, for adopt
+	ErrorMSG string `json:"ErrorMSG,omitempty" validate:"omitempty"`
+}
+
+// ReconcileScheduleInfo represents a schedule to reconcile a connection periodically.
+type ReconcileScheduleInfo struct {
+	ConnectionName string    `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	Interval       int       `json:"Interval" validate:"required" example:"3600"` // secs
+	StartedTime    time.Time `json:"StartedTime" validate:"required" example:"2024-10-20T10:00:00Z"`
+
+	stop chan struct{}
+}
+
+// the last report and the schedule of each connection, not persisted
+var reconcileReportMap = map[string]*ReconcileReportInfo{}
+var reconcileScheduleMap = map[string]*ReconcileScheduleInfo{}
+var reconcileLock sync.Mutex
+
+// ReconcileResources reports the drift of all resource types(or rsTypeList) of a connection.
+// The failure of a resource type is reported in the ErrorMSG, not returned.
+func ReconcileResources(connectionName string, rsTypeList []string) (*ReconcileReportInfo, error) {
+	cblog.Info("call ReconcileResources()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if len(rsTypeList) == 0 {
+		rsTypeList = RECONCILE_RESOURCE_TYPES
+	}
+	for _, rsType := range rsTypeList {
+		if !isReconcileResourceType(rsType) {
+			err := fmt.Errorf("%s is not a supported Resource Type to reconcile, use one of %s!", rsType, strings.Join(RECONCILE_RESOURCE_TYPES, ", "))
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+
+	report := ReconcileReportInfo{ConnectionName: connectionName, CheckedTime: time.Now(), ResourceList: []*ReconcileResourceInfo{}}
+	for _, rsType := range rsTypeList {
+		rsInfo := ReconcileResourceInfo{ResourceType: rsType, OnlySpiderList: []*cres.IID{}, OnlyCSPList: []*cres.IID{}}
+		allResList, err := ListAllResource(connectionName, rsType)
+		if err != nil {
+			cblog.Error(err)
+			rsInfo.ErrorMSG = err.Error()
+		} else {
+			rsInfo.MappedCount = len(allResList.AllList.MappedList)
+			if allResList.AllList.OnlySpiderList != nil {
+				rsInfo.OnlySpiderList = allResList.AllList.OnlySpiderList
+			}
+			if allResList.AllList.OnlyCSPList != nil {
+				rsInfo.OnlyCSPList = allResList.AllList.OnlyCSPList
+			}
+		}
+		if len(rsInfo.OnlySpiderList) > 0 || len(rsInfo.OnlyCSPList) > 0 {
+			report.HasDrift = true
+		}
+		report.ResourceList = append(report.ResourceList, &rsInfo)
+	}
+
+	reconcileLock.Lock()
+	reconcileReportMap[connectionName] = &report
+	reconcileLock.Unlock()
+
+	return &report, nil
+}
+
+// GetLastReconcileReport returns the last report of a connection, by on-demand or scheduled run.
+func GetLastReconcileReport(connectionName string) (*ReconcileReportInfo, error) {
+	cblog.Info("call GetLastReconcileReport()")
+
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	reconcileLock.Lock()
+	defer reconcileLock.Unlock()
+	report, ok := reconcileReportMap[connectionName]
+	if !ok {
+		return nil, fmt.Errorf("%s: has no reconcile report!", connectionName)
+	}
+	return report, nil
+}
+
+// ApplyReconcileAction applies an action to the resources of the current drift.
+// (1) get the current drift of the resource type
+// (2) check all ids are in the drift, OnlySpiderList for unregister, OnlyCSPList for adopt and delete
+// (3) apply the action to each id
+func ApplyReconcileAction(connectionName string, rsType string, action string, idList []string) ([]*ReconcileActionResultInfo, error) {
+	cblog.Info("call ApplyReconcileAction()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if !isReconcileResourceType(rsType) {
+		err := fmt.Errorf("%s is not a supported Resource Type to reconcile, use one of %s!", rsType, strings.Join(RECONCILE_RESOURCE_TYPES, ", "))
+		cblog.Error(err)
+		return nil, err
+	}
+	action = strings.ToLower(strings.TrimSpace(action))
+	if action != RECONCILE_UNREGISTER && action != RECONCILE_ADOPT && action != RECONCILE_DELETE {
+		err := fmt.Errorf("%s is not a valid reconcile action, use unregister, adopt or delete!", action)
+		cblog.Error(err)
+		return nil, err
+	}
+	if len(idList) == 0 {
+		err := fmt.Errorf("The id list of the reconcile action is empty!")
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (1) get the current drift of the resource type
+	allResList, err := ListAllResource(connectionName, rsType)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) check all ids are in the drift
+	driftIdMap := map[string]bool{}
+	if action == RECONCILE_UNREGISTER {
+		for _, iid := range allResList.AllList.OnlySpiderList {
+			driftIdMap[iid.NameId] = true
+		}
+	} else {
+		for _, iid := range allResList.AllList.OnlyCSPList {
+			driftIdMap[iid.SystemId] = true
+		}
+	}
+	for _, id := range idList {
+		if !driftIdMap[id] {
+			err := fmt.Errorf("%s-%s is not in the drift of %s, can not %s it!", rsType, id, connectionName, action)
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+
+	// (3) apply the action to each id
+	resultList := []*ReconcileActionResultInfo{}
+	for _, id := range idList {
+		result := ReconcileActionResultInfo{Id: id, Status: RECONCILE_SUCCEEDED}
+		switch action {
+		case RECONCILE_UNREGISTER:
+			_, err = UnregisterResource(connectionName, rsType, id)
+		case RECONCILE_ADOPT:
+			result.NameId, err = adoptCSPResource(connectionName, rsType, id)
+		case RECONCILE_DELETE:
+			_, _, err = DeleteCSPResource(connectionName, rsType, id)
+		}
+		if err != nil {
+			cblog.Error(err)
+			result.Status = RECONCILE_FAILED
+			result.ErrorMSG = err.Error()
+		}
+		resultList = append(resultList, &result)
+	}
+
+	return resultList, nil
+}
+
+// register a CSP-only resource with a generated name by the Register* function of the resource type
+func adoptCSPResource(connectionName string, rsType string, systemId string) (string, error) {
+	nameId, err := getAdoptNameId(connectionName, rsType, systemId)
+	if err != nil {
+		return "", err
+	}
+	userIID := cres.IID{NameId: nameId, SystemId: systemId}
+
+	switch rsType {
+	case VPC:
+		_, err = RegisterVPC(connectionName, userIID)
+	case KEY:
+		_, err = RegisterKey(connectionName, userIID)
+	case VM:
+		_, err = RegisterVM(connectionName, userIID)
+	case MYIMAGE:
+		_, err = RegisterMyImage(connectionName, userIID)
+	case DISK:
+		zoneId, err2 := findDiskOwnerZoneId(connectionName, systemId)
+		if err2 != nil {
+			return "", err2
+		}
+		_, err = RegisterDisk(connectionName, zoneId, userIID)
+	case SG, NLB, CLUSTER:
+		vpcName, err2 := findOwnerVPCName(connectionName, rsType, systemId)
+		if err2 != nil {
+			return "", err2
+		}
+		switch rsType {
+		case SG:
+			_, err = RegisterSecurity(connectionName, vpcName, userIID)
+		case NLB:
+			_, err = RegisterNLB(connectionName, vpcName, userIID)
+		case CLUSTER:
+			_, err = RegisterCluster(connectionName, vpcName, userIID)
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	return nameId, nil
+}
+
+var adoptNameRegexp = regexp.MustCompile("[^a-z0-9-]+")
+
+// generate a NameId from the SystemId, ex) "i-0bc7123b7e5cbf79d" => "vm-i-0bc7123b7e5cbf79d"
+// Azure's SystemId is a path, so the last element of the path is used.
+func getAdoptNameId(connectionName string, rsType string, systemId string) (string, error) {
+	idList := strings.Split(strings.TrimRight(systemId, "/"), "/")
+	baseName := adoptNameRegexp.ReplaceAllString(strings.ToLower(rsType+"-"+idList[len(idList)-1]), "-")
+	if len(baseName) > MAX_ADOPT_NAME_LENGTH {
+		baseName = baseName[:MAX_ADOPT_NAME_LENGTH]
+	}
+
+	var info interface{}
+	switch rsType {
+	case VPC:
+		info = &VPCIIDInfo{}
+	case SG:
+		info = &SGIIDInfo{}
+	case KEY:
+		info = &KeyIIDInfo{}
+	case VM:
+		info = &VMIIDInfo{}
+	case DISK:
+		info = &DiskIIDInfo{}
+	case MYIMAGE:
+		info = &MyImageIIDInfo{}
+	case NLB:
+		info = &NLBIIDInfo{}
+	case CLUSTER:
+		info = &ClusterIIDInfo{}
+	}
+
+	// add a suffix number, if the name is already used
+	nameId := baseName
+	for i := 2; ; i++ {
+		bool_ret, err := infostore.HasByConditions(info, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameId)
+		if err != nil {
+			return "", err
+		}
+		if !bool_ret {
+			return nameId, nil
+		}
+		nameId = baseName + "-" + strconv.Itoa(i)
+	}
+}
+
+// find the Spider's VPC name of a CSP-only SecurityGroup, NLB or Cluster
+func findOwnerVPCName(connectionName string, rsType string, systemId string) (string, error) {
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		return "", err
+	}
+
+	driverIID := cres.IID{NameId: systemId, SystemId: systemId}
+	var vpcSystemId string
+	switch rsType {
+	case SG:
+		handler, err := cldConn.CreateSecurityHandler()
+		if err != nil {
+			return "", err
+		}
+		info, err := handler.GetSecurity(driverIID)
+		if err != nil {
+			return "", err
+		}
+		vpcSystemId = info.VpcIID.SystemId
+	case NLB:
+		handler, err := cldConn.CreateNLBHandler()
+		if err != nil {
+			return "", err
+		}
+		info, err := handler.GetNLB(driverIID)
+		if err != nil {
+			return "", err
+		}
+		vpcSystemId = info.VpcIID.SystemId
+	case CLUSTER:
+		handler, err := cldConn.CreateClusterHandler()
+		if err != nil {
+			return "", err
+		}
+		info, err := handler.GetCluster(driverIID)
+		if err != nil {
+			return "", err
+		}
+		vpcSystemId = info.Network.VpcIID.SystemId
+	}
+	if vpcSystemId == "" {
+		return "", fmt.Errorf("%s-%s has no owner VPC!", rsType, systemId)
+	}
+
+	var vpcIIDInfo VPCIIDInfo
+	err = infostore.GetByConditionAndContain(&vpcIIDInfo, CONNECTION_NAME_COLUMN, connectionName, SYSTEM_ID_COLUMN, vpcSystemId)
+	if err != nil {
+		return "", fmt.Errorf("The owner VPC(%s) of %s-%s is not managed by Spider, adopt the VPC first!", vpcSystemId, rsType, systemId)
+	}
+	return vpcIIDInfo.NameId, nil
+}
+
+func isReconcileResourceType(rsType string) bool {
+	for _, one := range RECONCILE_RESOURCE_TYPES {
+		if one == rsType {
+			return true
+		}
+	}
+	return false
+}
+
+// StartReconcileSchedule reconciles a connection periodically, only for the report without any action.
+// The schedules are kept in memory, so they are cleared when the server restarts.
+func StartReconcileSchedule(connectionName string, interval int) (*ReconcileScheduleInfo, error) {
+	cblog.Info("call StartReconcileSchedule()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if interval < MIN_RECONCILE_INTERVAL {
+		err := fmt.Errorf("The reconcile interval should be at least %d secs, but %d!", MIN_RECONCILE_INTERVAL, interval)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// check the connection
+	_, err = ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	reconcileLock.Lock()
+	defer reconcileLock.Unlock()
+	if _, ok := reconcileScheduleMap[connectionName]; ok {
+		err := fmt.Errorf("%s already has a reconcile schedule!", connectionName)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	schedule := ReconcileScheduleInfo{ConnectionName: connectionName, Interval: interval, StartedTime: time.Now(), stop: make(chan struct{})}
+	reconcileScheduleMap[connectionName] = &schedule
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(interval) * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				report, err := ReconcileResources(connectionName, nil)
+				if err != nil {
+					cblog.Error(err)
+				} else if report.HasDrift {
+					cblog.Infof("%s has the drift of resources, CheckedTime: %v", connectionName, report.CheckedTime)
+				}
+			case <-schedule.stop:
+				return
+			}
+		}
+	}()
+
+	return &schedule, nil
+}
+
+// StopReconcileSchedule stops the reconcile schedule of a connection.
+func StopReconcileSchedule(connectionName string) (bool, error) {
+	cblog.Info("call StopReconcileSchedule()")
+
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	reconcileLock.Lock()
+	defer reconcileLock.Unlock()
+	schedule, ok := reconcileScheduleMap[connectionName]
+	if !ok {
+		err := fmt.Errorf("%s: has no reconcile schedule!", connectionName)
+		cblog.Error(err)
+		return false, err
+	}
+	close(schedule.stop)
+	delete(reconcileScheduleMap, connectionName)
+	return true, nil
+}
+
+// ListReconcileSchedule returns all reconcile schedules.
+func ListReconcileSchedule() []*ReconcileScheduleInfo {
+	cblog.Info("call ListReconcileSchedule()")
+
+	reconcileLock.Lock()
+	defer reconcileLock.Unlock()
+	scheduleList := []*ReconcileScheduleInfo{}
+	for _, schedule := range reconcileScheduleMap {
+		scheduleList = append(scheduleList, schedule)
+	}
+	return scheduleList
+}
@@ -0,0 +1,414 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package commonruntime
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+// Route Tables are not registered in Spider.
+// They are handled as a part of the VPC, so the VPC SPLock is used.
+
+//================ Route Table Handler
+
+// get driver IIDs of the VPC and the Subnet, the empty subnetName means the main Route Table.
+func getRouteTableOwnerIIDs(connectionName string, vpcName string, subnetName string) (VPCIIDInfo, cres.IID, error) {
+	var vpcIIDInfo VPCIIDInfo
+	err := infostore.GetByConditions(&vpcIIDInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, vpcName)
+	if err != nil {
+		return VPCIIDInfo{}, cres.IID{}, err
+	}
+
+	if subnetName == "" {
+		return vpcIIDInfo, cres.IID{}, nil
+	}
+
+	var subnetIIDInfo SubnetIIDInfo
+	err = infostore.GetBy3Conditions(&subnetIIDInfo, CONNECTION_NAME_COLUMN, connectionName, OWNER_VPC_NAME_COLUMN, vpcName,
+		NAME_ID_COLUMN, subnetName)
+	if err != nil {
+		return VPCIIDInfo{}, cres.IID{}, err
+	}
+	return vpcIIDInfo, getDriverIID(cres.IID{NameId: subnetIIDInfo.NameId, SystemId: subnetIIDInfo.SystemId}), nil
+}
+
+// check the route and replace the target's user IID with the driver IID.
+// The target must be a resource registered in Spider.
+func resolveRouteTarget(connectionName string, vpcName string, route *cres.RouteInfo) error {
+	_, _, err := net.ParseCIDR(route.DestinationCIDR)
+	if err != nil {
+		return fmt.Errorf("Invalid DestinationCIDR '%s': %s", route.DestinationCIDR, err.Error())
+	}
+
+	targetName := strings.TrimSpace(route.TargetIID.NameId)
+
+	switch route.TargetType {
+	case cres.RouteTargetInternetGateway:
+		route.TargetIID = cres.IID{}
+		return nil
+	case cres.RouteTargetVM:
+		var vmIIDInfo VMIIDInfo
+		err := infostore.GetByConditions(&vmIIDInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, targetName)
+		if err != nil {
+			return fmt.Errorf("The target VM '%s' is not registered in Spider: %s", targetName, err.Error())
+		}
+		route.TargetIID = getDriverIID(cres.IID{NameId: vmIIDInfo.NameId, SystemId: vmIIDInfo.SystemId})
+		return nil
+	case cres.RouteTargetVPCPeering:
+		iidInfo, err := getVPCPeeringOfVPC(connectionName, vpcName, targetName)
+		if err != nil {
+			return err
+		}
+		route.TargetIID = getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+		return nil
+	case cres.RouteTargetNATGateway:
+		var natIIDInfo NATGatewayIIDInfo
+		err := infostore.GetBy3Conditions(&natIIDInfo, CONNECTION_NAME_COLUMN, connectionName, OWNER_VPC_NAME_COLUMN, vpcName,
+			NAME_ID_COLUMN, targetName)
+		if err != nil {
+			return fmt.Errorf("The target NAT Gateway '%s' is not registered in the VPC '%s': %s", targetName, vpcName, err.Error())
+		}
+		route.TargetIID = getDriverIID(cres.IID{NameId: natIIDInfo.NameId, SystemId: natIIDInfo.SystemId})
+		return nil
+	case cres.RouteTargetLocal:
+		return fmt.Errorf("The %s route is managed by the CSP and can not be changed!", route.TargetType)
+	default:
+		return fmt.Errorf("'%s' is not a valid TargetType! (InternetGateway | NATGateway | VPCPeering | VM)", route.TargetType)
+	}
+}
+
+// get the driver IID of a route target not registered in Spider with the SystemId of the route
+func getUnregisteredRouteTarget(route cres.RouteInfo) (cres.IID, bool) {
+	if _, _, err := net.ParseCIDR(route.DestinationCIDR); err != nil {
+		return cres.IID{}, false
+	}
+	switch route.TargetType {
+	case cres.RouteTargetVM, cres.RouteTargetNATGateway, cres.RouteTargetVPCPeering:
+	default:
+		return cres.IID{}, false
+	}
+
+	systemId := strings.TrimSpace(route.TargetIID.SystemId)
+	if systemId == "" {
+		return cres.IID{}, false
+	}
+	return cres.IID{NameId: systemId, SystemId: systemId}, true
+}
+
+// get a VPC Peering connected to the VPC as a Requester or an Accepter
+func getVPCPeeringOfVPC(connectionName string, vpcName string, peeringName string) (*VPCPeeringIIDInfo, error) {
+	var iidInfoList []*VPCPeeringIIDInfo
+	err := infostore.ListByConditions(&iidInfoList, CONNECTION_NAME_COLUMN, connectionName, OWNER_VPC_NAME_COLUMN, vpcName)
+	if err != nil {
+		return nil, err
+	}
+	var peerIIDInfoList []*VPCPeeringIIDInfo
+	err = infostore.ListByConditions(&peerIIDInfoList, PEER_CONNECTION_NAME_COLUMN, connectionName, PEER_VPC_NAME_COLUMN, vpcName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, iidInfo := range append(iidInfoList, peerIIDInfoList...) {
+		if iidInfo.NameId == peeringName {
+			return iidInfo, nil
+		}
+	}
+	return nil, fmt.Errorf("The target VPC Peering '%s' is not connected to the VPC '%s'!", peeringName, vpcName)
+}
+
+// set user IIDs of the VPC, Subnets and route targets with the info-store
+func setRouteTableUserIIDs(connectionName string, vpcIIDInfo VPCIIDInfo, info *cres.RouteTableInfo) {
+	info.VpcIID = getUserIID(cres.IID{NameId: vpcIIDInfo.NameId, SystemId: vpcIIDInfo.SystemId})
+
+	for idx, subnetIID := range info.SubnetIIDs {
+		var subnetIIDInfo SubnetIIDInfo
+		err := infostore.GetByConditionsAndContain(&subnetIIDInfo, CONNECTION_NAME_COLUMN, connectionName,
+			OWNER_VPC_NAME_COLUMN, vpcIIDInfo.NameId, SYSTEM_ID_COLUMN, subnetIID.SystemId)
+		if err != nil {
+			// not registered in Spider
+			info.SubnetIIDs[idx].NameId = ""
+			continue
+		}
+		info.SubnetIIDs[idx] = getUserIID(cres.IID{NameId: subnetIIDInfo.NameId, SystemId: subnetIIDInfo.SystemId})
+	}
+
+	for idx, route := range info.Routes {
+		if route.TargetIID.SystemId == "" {
+			continue
+		}
+		userIID, err := getRouteTargetUserIID(connectionName, vpcIIDInfo.NameId, route)
+		if err != nil {
+			// not registered in Spider
+			info.Routes[idx].TargetIID.NameId = ""
+			continue
+		}
+		info.Routes[idx].TargetIID = userIID
+	}
+}
+
+func getRouteTargetUserIID(connectionName string, vpcName string, route cres.RouteInfo) (cres.IID, error) {
+	switch route.TargetType {
+	case cres.RouteTargetVM:
+		var vmIIDInfo VMIIDInfo
+		err := infostore.GetByContain(&vmIIDInfo, CONNECTION_NAME_COLUMN, connectionName, SYSTEM_ID_COLUMN, route.TargetIID.SystemId)
+		if err != nil {
+			return cres.IID{}, err
+		}
+		return getUserIID(cres.IID{NameId: vmIIDInfo.NameId, SystemId: vmIIDInfo.SystemId}), nil
+	case cres.RouteTargetNATGateway:
+		var natIIDInfo NATGatewayIIDInfo
+		err := infostore.GetByConditionsAndContain(&natIIDInfo, CONNECTION_NAME_COLUMN, connectionName, OWNER_VPC_NAME_COLUMN, vpcName,
+			SYSTEM_ID_COLUMN, route.TargetIID.SystemId)
+		if err != nil {
+			return cres.IID{}, err
+		}
+		return getUserIID(cres.IID{NameId: natIIDInfo.NameId, SystemId: natIIDInfo.SystemId}), nil
+	case cres.RouteTargetVPCPeering:
+		var iidInfo VPCPeeringIIDInfo
+		err := infostore.GetByConditionsAndContain(&iidInfo, CONNECTION_NAME_COLUMN, connectionName, OWNER_VPC_NAME_COLUMN, vpcName,
+			SYSTEM_ID_COLUMN, route.TargetIID.SystemId)
+		if err != nil {
+			err = infostore.GetByConditionsAndContain(&iidInfo, PEER_CONNECTION_NAME_COLUMN, connectionName, PEER_VPC_NAME_COLUMN, vpcName,
+				SYSTEM_ID_COLUMN, route.TargetIID.SystemId)
+			if err != nil {
+				return cres.IID{}, err
+			}
+		}
+		return getUserIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}), nil
+	default:
+		return cres.IID{}, fmt.Errorf("%s target does not exist!", route.TargetType)
+	}
+}
+
+func ListRouteTable(connectionName string, vpcName string) ([]*cres.RouteTableInfo, error) {
+	cblog.Info("call ListRouteTable()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	vpcName, err = EmptyCheckAndTrim("vpcName", vpcName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateRouteTableHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	vpcSPLock.RLock(connectionName, vpcName)
+	defer vpcSPLock.RUnlock(connectionName, vpcName)
+
+	vpcIIDInfo, _, err := getRouteTableOwnerIIDs(connectionName, vpcName, "")
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	infoList, err := handler.ListRouteTable(getDriverIID(cres.IID{NameId: vpcIIDInfo.NameId, SystemId: vpcIIDInfo.SystemId}))
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	for _, info := range infoList {
+		setRouteTableUserIIDs(connectionName, vpcIIDInfo, info)
+	}
+
+	return infoList, nil
+}
+
+// The empty subnetName means the main Route Table of the VPC.
+func GetRouteTable(connectionName string, vpcName string, subnetName string) (*cres.RouteTableInfo, error) {
+	cblog.Info("call GetRouteTable()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	vpcName, err = EmptyCheckAndTrim("vpcName", vpcName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	subnetName = strings.TrimSpace(subnetName)
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateRouteTableHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	vpcSPLock.RLock(connectionName, vpcName)
+	defer vpcSPLock.RUnlock(connectionName, vpcName)
+
+	vpcIIDInfo, subnetDriverIID, err := getRouteTableOwnerIIDs(connectionName, vpcName, subnetName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	info, err := handler.GetRouteTable(getDriverIID(cres.IID{NameId: vpcIIDInfo.NameId, SystemId: vpcIIDInfo.SystemId}), subnetDriverIID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	setRouteTableUserIIDs(connectionName, vpcIIDInfo, &info)
+
+	return &info, nil
+}
+
+// (1) check the VPC, Subnet and the route target are registered in Spider
+// (2) add a route
+// (3) set ResourceInfo(IID.NameId)
+func AddRoute(connectionName string, vpcName string, subnetName string, route cres.RouteInfo) (*cres.RouteTableInfo, error) {
+	cblog.Info("call AddRoute()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	vpcName, err = EmptyCheckAndTrim("vpcName", vpcName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	subnetName = strings.TrimSpace(subnetName)
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateRouteTableHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	vpcSPLock.Lock(connectionName, vpcName)
+	defer vpcSPLock.Unlock(connectionName, vpcName)
+
+	// (1) check the VPC, Subnet and the route target are registered in Spider
+	vpcIIDInfo, subnetDriverIID, err := getRouteTableOwnerIIDs(connectionName, vpcName, subnetName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	err = resolveRouteTarget(connectionName, vpcName, &route)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) add a route
+	info, err := handler.AddRoute(getDriverIID(cres.IID{NameId: vpcIIDInfo.NameId, SystemId: vpcIIDInfo.SystemId}), subnetDriverIID, route)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (3) set ResourceInfo(IID.NameId)
+	setRouteTableUserIIDs(connectionName, vpcIIDInfo, &info)
+
+	return &info, nil
+}
+
+func RemoveRoute(connectionName string, vpcName string, subnetName string, route cres.RouteInfo) (bool, error) {
+	cblog.Info("call RemoveRoute()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	vpcName, err = EmptyCheckAndTrim("vpcName", vpcName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+	subnetName = strings.TrimSpace(subnetName)
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	handler, err := cldConn.CreateRouteTableHandler()
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	vpcSPLock.Lock(connectionName, vpcName)
+	defer vpcSPLock.Unlock(connectionName, vpcName)
+
+	vpcIIDInfo, subnetDriverIID, err := getRouteTableOwnerIIDs(connectionName, vpcName, subnetName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	err = resolveRouteTarget(connectionName, vpcName, &route)
+	if err != nil {
+		// the target can be deleted or unregistered after the route was added,
+		// then the route is removed with the target's SystemId shown in the Route Table
+		targetIID, ok := getUnregisteredRouteTarget(route)
+		if !ok {
+			cblog.Error(err)
+			return false, err
+		}
+		cblog.Info(err)
+		route.TargetIID = targetIID
+	}
+
+	result, err := handler.RemoveRoute(getDriverIID(cres.IID{NameId: vpcIIDInfo.NameId, SystemId: vpcIIDInfo.SystemId}), subnetDriverIID, route)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,514 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package commonruntime
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	splock "github.com/cloud-barista/cb-spider/api-runtime/common-runtime/sp-lock"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+// ====================================================================
+// type for GORM
+
+// SGTemplateVersionInfo keeps a version of a SecurityGroup rule template, older versions are kept.
+type SGTemplateVersionInfo struct {
+	Name          string `gorm:"primaryKey"` // ex) "web"
+	Version       int    `gorm:"primaryKey"` // 1, 2, ...
+	Description   string
+	ParameterList string // JSON of []SGTemplateParameterInfo
+	RuleList      string // JSON of []cres.SecurityRuleInfo
+	CreatedTime   time.Time
+}
+
+func (SGTemplateVersionInfo) TableName() string {
+	return "sg_template_version_infos"
+}
+
+//====================================================================
+
+func init() {
+	db, err := infostore.Open()
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	db.AutoMigrate(&SGTemplateVersionInfo{})
+	infostore.Close(db)
+}
+
+const SG_TEMPLATE_NAME_COLUMN = "name"
+const SG_TEMPLATE_VERSION_COLUMN = "version"
+
+// serialize the version numbering of each template, the templates are not owned by a connection.
+var sgTemplateSPLock = splock.New()
+
+// placeholder of a parameter in the rule fields, ex) "{{CIDR}}"
+var sgTemplateParamRegexp = regexp.MustCompile(`\{\{([A-Za-z_][A-Za-z0-9_]*)\}\}`)
+var sgTemplateParamNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SGTemplateParameterInfo represents a parameter of a rule template.
+type SGTemplateParameterInfo struct {
+	Name        string `json:"Name" validate:"required" example:"CIDR"`
+	Default     string `json:"Default,omitempty" validate:"omitempty" example:"0.0.0.0/0"` // if not specified, the parameter is required
+	Description string `json:"Description,omitempty" validate:"omitempty" example:"source CIDR of the clients"`
+}
+
+// SGTemplateReqInfo represents a request to create or update a rule template.
+// The string fields of the rules can refer to the parameters with "{{Name}}".
+type SGTemplateReqInfo struct {
+	Name          string                    `json:"Name" validate:"required" example:"web"`
+	Description   string                    `json:"Description,omitempty" validate:"omitempty" example:"HTTP and HTTPS from the clients"`
+	ParameterList []SGTemplateParameterInfo `json:"ParameterList,omitempty" validate:"omitempty"`
+	RuleList      []cres.SecurityRuleInfo   `json:"RuleList" validate:"required"`
+}
+
+// SGTemplateRefInfo references a rule template with its parameters, it is expanded into the rules by CB-Spider.
+type SGTemplateRefInfo struct {
+	Name          string          `json:"Name" validate:"required" example:"web"`
+	Version       string          `json:"Version,omitempty" validate:"omitempty" example:"2"` // if not specified, the latest version
+	ParameterList []cres.KeyValue `json:"ParameterList,omitempty" validate:"omitempty"`       // ex) [{"Key": "CIDR", "Value": "10.0.0.0/16"}]
+}
+
+// SGTemplateInfo represents a version of a rule template.
+type SGTemplateInfo struct {
+	Name          string                    `json:"Name" validate:"required" example:"web"`
+	Version       int                       `json:"Version" validate:"required" example:"1"`
+	Description   string                    `json:"Description,omitempty" validate:"omitempty" example:"HTTP and HTTPS from the clients"`
+	ParameterList []SGTemplateParameterInfo `json:"ParameterList" validate:"required"`
+	RuleList      []cres.SecurityRuleInfo   `json:"RuleList" validate:"required"`
+	CreatedTime   time.Time                 `json:"CreatedTime" validate:"required" example:"2024-10-01T12:00:00Z"`
+}
+
+//================ SecurityGroup Rule Template
+
+// (1) check the template
+// (2) check the template does not exist
+// (3) insert the version 1
+func CreateSGTemplate(reqInfo SGTemplateReqInfo) (*SGTemplateInfo, error) {
+	cblog.Info("call CreateSGTemplate()")
+
+	// (1) check the template
+	name, err := EmptyCheckAndTrim("Name", reqInfo.Name)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	reqInfo.Name = name
+
+	err = checkSGTemplate(reqInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	sgTemplateSPLock.Lock("", reqInfo.Name)
+	defer sgTemplateSPLock.Unlock("", reqInfo.Name)
+
+	// (2) check the template does not exist
+	bool_ret, err := infostore.Has(&SGTemplateVersionInfo{}, SG_TEMPLATE_NAME_COLUMN, reqInfo.Name)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if bool_ret {
+		err := fmt.Errorf("The SecurityGroup Template '%s' already exists!", reqInfo.Name)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (3) insert the version 1
+	info, err := insertSGTemplateVersion(reqInfo, 1)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return info, nil
+}
+
+// (1) check the template
+// (2) get the latest version
+// (3) insert the next version
+func UpdateSGTemplate(name string, reqInfo SGTemplateReqInfo) (*SGTemplateInfo, error) {
+	cblog.Info("call UpdateSGTemplate()")
+
+	// (1) check the template
+	name, err := EmptyCheckAndTrim("Name", name)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	reqInfo.Name = name
+
+	err = checkSGTemplate(reqInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	sgTemplateSPLock.Lock("", name)
+	defer sgTemplateSPLock.Unlock("", name)
+
+	// (2) get the latest version
+	versionList, err := listSGTemplateVersions(name)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (3) insert the next version
+	info, err := insertSGTemplateVersion(reqInfo, versionList[len(versionList)-1].Version+1)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return info, nil
+}
+
+// ListSGTemplate returns the latest version of each template.
+func ListSGTemplate() ([]*SGTemplateInfo, error) {
+	cblog.Info("call ListSGTemplate()")
+
+	var versionInfoList []*SGTemplateVersionInfo
+	err := infostore.List(&versionInfoList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	latestMap := map[string]*SGTemplateVersionInfo{}
+	for _, versionInfo := range versionInfoList {
+		if latest, ok := latestMap[versionInfo.Name]; !ok || latest.Version < versionInfo.Version {
+			latestMap[versionInfo.Name] = versionInfo
+		}
+	}
+
+	infoList := []*SGTemplateInfo{}
+	for _, versionInfo := range latestMap {
+		info, err := toSGTemplateInfo(versionInfo)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		infoList = append(infoList, info)
+	}
+	sort.Slice(infoList, func(i, j int) bool { return infoList[i].Name < infoList[j].Name })
+
+	return infoList, nil
+}
+
+// ListSGTemplateVersion returns all versions of a template in ascending order.
+func ListSGTemplateVersion(name string) ([]*SGTemplateInfo, error) {
+	cblog.Info("call ListSGTemplateVersion()")
+
+	name, err := EmptyCheckAndTrim("Name", name)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	versionList, err := listSGTemplateVersions(name)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	infoList := []*SGTemplateInfo{}
+	for _, versionInfo := range versionList {
+		info, err := toSGTemplateInfo(versionInfo)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		infoList = append(infoList, info)
+	}
+	return infoList, nil
+}
+
+// GetSGTemplate returns a version of a template, the latest version if the version is "".
+func GetSGTemplate(name string, version string) (*SGTemplateInfo, error) {
+	cblog.Info("call GetSGTemplate()")
+
+	name, err := EmptyCheckAndTrim("Name", name)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	versionInfo, err := getSGTemplateVersion(name, version)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	info, err := toSGTemplateInfo(versionInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	return info, nil
+}
+
+// DeleteSGTemplate deletes a version of a template, all versions if the version is "".
+// The SecurityGroups created with the template are not changed.
+func DeleteSGTemplate(name string, version string) (bool, error) {
+	cblog.Info("call DeleteSGTemplate()")
+
+	name, err := EmptyCheckAndTrim("Name", name)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	sgTemplateSPLock.Lock("", name)
+	defer sgTemplateSPLock.Unlock("", name)
+
+	if strings.TrimSpace(version) == "" {
+		_, err := listSGTemplateVersions(name)
+		if err != nil {
+			cblog.Error(err)
+			return false, err
+		}
+		_, err = infostore.Delete(&SGTemplateVersionInfo{}, SG_TEMPLATE_NAME_COLUMN, name)
+		if err != nil {
+			cblog.Error(err)
+			return false, err
+		}
+		return true, nil
+	}
+
+	versionInfo, err := getSGTemplateVersion(name, version)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+	_, err = infostore.DeleteByConditions(&SGTemplateVersionInfo{}, SG_TEMPLATE_NAME_COLUMN, name, SG_TEMPLATE_VERSION_COLUMN, strconv.Itoa(versionInfo.Version))
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+	return true, nil
+}
+
+// ExpandSGTemplates expands the template references into the concrete rules.
+// The parameters without a value are set with their defaults.
+func ExpandSGTemplates(refList []SGTemplateRefInfo) ([]cres.SecurityRuleInfo, error) {
+	ruleList := []cres.SecurityRuleInfo{}
+	for _, ref := range refList {
+		name, err := EmptyCheckAndTrim("RuleTemplate Name", ref.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		versionInfo, err := getSGTemplateVersion(name, ref.Version)
+		if err != nil {
+			return nil, err
+		}
+		info, err := toSGTemplateInfo(versionInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		expandedList, err := expandSGTemplate(info, ref.ParameterList)
+		if err != nil {
+			return nil, err
+		}
+		ruleList = append(ruleList, expandedList...)
+	}
+	return ruleList, nil
+}
+
+// appendSGTemplateRules expands the template references and appends the rules to the rule list.
+func appendSGTemplateRules(ruleList *[]cres.SecurityRuleInfo, refList []SGTemplateRefInfo) (*[]cres.SecurityRuleInfo, error) {
+	if len(refList) == 0 {
+		return ruleList, nil
+	}
+
+	expandedList, err := ExpandSGTemplates(refList)
+	if err != nil {
+		return ruleList, err
+	}
+
+	newRuleList := []cres.SecurityRuleInfo{}
+	if ruleList != nil {
+		newRuleList = append(newRuleList, *ruleList...)
+	}
+	newRuleList = append(newRuleList, expandedList...)
+	return &newRuleList, nil
+}
+
+func expandSGTemplate(info *SGTemplateInfo, paramList []cres.KeyValue) ([]cres.SecurityRuleInfo, error) {
+	valueMap := map[string]string{}
+	for _, param := range info.ParameterList {
+		if param.Default != "" {
+			valueMap[param.Name] = param.Default
+		}
+	}
+	for _, kv := range paramList {
+		if !hasSGTemplateParameter(info.ParameterList, kv.Key) {
+			return nil, fmt.Errorf("The SecurityGroup Template '%s' v%d has no parameter '%s'!", info.Name, info.Version, kv.Key)
+		}
+		valueMap[kv.Key] = strings.TrimSpace(kv.Value)
+	}
+	for _, param := range info.ParameterList {
+		if valueMap[param.Name] == "" {
+			return nil, fmt.Errorf("The parameter '%s' of the SecurityGroup Template '%s' v%d is required!", param.Name, info.Name, info.Version)
+		}
+	}
+
+	replace := func(field string) string {
+		return sgTemplateParamRegexp.ReplaceAllStringFunc(field, func(placeholder string) string {
+			return valueMap[sgTemplateParamRegexp.FindStringSubmatch(placeholder)[1]]
+		})
+	}
+
+	ruleList := []cres.SecurityRuleInfo{}
+	for _, rule := range info.RuleList {
+		for _, field := range sgTemplateRuleFields(&rule) {
+			*field = replace(*field)
+		}
+		ruleList = append(ruleList, rule)
+	}
+	return ruleList, nil
+}
+
+// check the parameters are valid and the rules refer to the declared parameters only
+func checkSGTemplate(reqInfo SGTemplateReqInfo) error {
+	if len(reqInfo.RuleList) == 0 {
+		return fmt.Errorf("The SecurityGroup Template '%s' has no rule!", reqInfo.Name)
+	}
+
+	for i, param := range reqInfo.ParameterList {
+		if !sgTemplateParamNameRegexp.MatchString(param.Name) {
+			return fmt.Errorf("The parameter name '%s' is invalid, it must be letters, digits and '_'!", param.Name)
+		}
+		if hasSGTemplateParameter(reqInfo.ParameterList[:i], param.Name) {
+			return fmt.Errorf("The parameter '%s' is duplicated!", param.Name)
+		}
+	}
+
+	for _, rule := range reqInfo.RuleList {
+		if rule.SecurityGroupIID != nil {
+			return fmt.Errorf("The SecurityGroup Template '%s' can not refer to a SecurityGroup!", reqInfo.Name)
+		}
+		for _, field := range sgTemplateRuleFields(&rule) {
+			for _, match := range sgTemplateParamRegexp.FindAllStringSubmatch(*field, -1) {
+				if !hasSGTemplateParameter(reqInfo.ParameterList, match[1]) {
+					return fmt.Errorf("The placeholder '%s' is not a parameter of the SecurityGroup Template '%s'!", match[0], reqInfo.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func sgTemplateRuleFields(rule *cres.SecurityRuleInfo) []*string {
+	return []*string{&rule.Direction, &rule.IPProtocol, &rule.FromPort, &rule.ToPort, &rule.CIDR,
+		&rule.Description, &rule.Priority, &rule.Action}
+}
+
+func hasSGTemplateParameter(paramList []SGTemplateParameterInfo, name string) bool {
+	for _, param := range paramList {
+		if param.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// the caller must hold sgTemplateSPLock of the template
+func insertSGTemplateVersion(reqInfo SGTemplateReqInfo, version int) (*SGTemplateInfo, error) {
+	if reqInfo.ParameterList == nil {
+		reqInfo.ParameterList = []SGTemplateParameterInfo{}
+	}
+	paramBytes, err := json.Marshal(reqInfo.ParameterList)
+	if err != nil {
+		return nil, err
+	}
+	ruleBytes, err := json.Marshal(reqInfo.RuleList)
+	if err != nil {
+		return nil, err
+	}
+
+	versionInfo := SGTemplateVersionInfo{
+		Name:          reqInfo.Name,
+		Version:       version,
+		Description:   reqInfo.Description,
+		ParameterList: string(paramBytes),
+		RuleList:      string(ruleBytes),
+		CreatedTime:   time.Now().UTC(),
+	}
+	err = infostore.Insert(&versionInfo)
+	if err != nil {
+		return nil, err
+	}
+	return toSGTemplateInfo(&versionInfo)
+}
+
+// returns the versions in ascending order, an error if the template does not exist
+func listSGTemplateVersions(name string) ([]*SGTemplateVersionInfo, error) {
+	var versionList []*SGTemplateVersionInfo
+	err := infostore.ListByCondition(&versionList, SG_TEMPLATE_NAME_COLUMN, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(versionList) == 0 {
+		return nil, fmt.Errorf("The SecurityGroup Template '%s' does not exist!", name)
+	}
+	sort.Slice(versionList, func(i, j int) bool { return versionList[i].Version < versionList[j].Version })
+	return versionList, nil
+}
+
+func getSGTemplateVersion(name string, version string) (*SGTemplateVersionInfo, error) {
+	version = strings.TrimSpace(version)
+	if version != "" {
+		num, err := strconv.Atoi(version)
+		if err != nil || num < 1 {
+			return nil, fmt.Errorf("The version '%s' of the SecurityGroup Template '%s' is invalid!", version, name)
+		}
+	}
+
+	versionList, err := listSGTemplateVersions(name)
+	if err != nil {
+		return nil, err
+	}
+	if version == "" {
+		return versionList[len(versionList)-1], nil
+	}
+	for _, versionInfo := range versionList {
+		if strconv.Itoa(versionInfo.Version) == version {
+			return versionInfo, nil
+		}
+	}
+	return nil, fmt.Errorf("The SecurityGroup Template '%s' v%s does not exist!", name, version)
+}
+
+func toSGTemplateInfo(versionInfo *SGTemplateVersionInfo) (*SGTemplateInfo, error) {
+	info := SGTemplateInfo{
+		Name:        versionInfo.Name,
+		Version:     versionInfo.Version,
+		Description: versionInfo.Description,
+		CreatedTime: versionInfo.CreatedTime,
+	}
+	if err := json.Unmarshal([]byte(versionInfo.ParameterList), &info.ParameterList); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(versionInfo.RuleList), &info.RuleList); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
@@ -0,0 +1,416 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package commonruntime
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+// Severity levels of the audit findings
+const (
+	AUDIT_HIGH   = "HIGH"
+	AUDIT_MEDIUM = "MEDIUM"
+	AUDIT_LOW    = "LOW"
+)
+
+// Names of the built-in audit checks
+const (
+	AUDIT_SENSITIVE_PORT_OPEN  = "sensitive-port-open"
+	AUDIT_ALL_PROTOCOL_INBOUND = "all-protocol-inbound"
+	AUDIT_BROAD_PORT_RANGE     = "broad-port-range"
+	AUDIT_UNUSED_SG            = "unused-sg"
+	AUDIT_DUPLICATE_RULE       = "duplicate-rule"
+)
+
+// ports that should not be open to the world
+var AUDIT_SENSITIVE_PORTS = map[int]string{
+	22:    "SSH",
+	3389:  "RDP",
+	3306:  "MySQL",
+	5432:  "PostgreSQL",
+	1433:  "MSSQL",
+	1521:  "Oracle",
+	27017: "MongoDB",
+	6379:  "Redis",
+}
+
+// max number of ports in a rule before it is flagged as a broad port range
+var AUDIT_MAX_PORT_RANGE = 1000
+
+// SGAuditFindingInfo represents a risky rule or state of a SecurityGroup.
+type SGAuditFindingInfo struct {
+	SecurityGroup cres.IID               `json:"SecurityGroup" validate:"required"`
+	Check         string                 `json:"Check" validate:"required" example:"sensitive-port-open"`
+	Severity      string                 `json:"Severity" validate:"required" example:"HIGH"` // HIGH | MEDIUM | LOW
+	Rule          *cres.SecurityRuleInfo `json:"Rule,omitempty" validate:"omitempty"`
+	Message       string                 `json:"Message" validate:"required" example:"SSH(22) is open to 0.0.0.0/0"`
+}
+
+// SGAuditReportInfo represents the audit report of the managed SecurityGroups of a connection.
+type SGAuditReportInfo struct {
+	ConnectionName string               `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	AuditTime      time.Time            `json:"AuditTime" validate:"required" example:"2024-10-20T10:00:00Z"`
+	CheckList      []string             `json:"CheckList" validate:"required"`
+	SGCount        int                  `json:"SGCount" validate:"required" example:"3"`
+	Summary        map[string]int       `json:"Summary" validate:"required"` // Severity => number of findings
+	FindingList    []SGAuditFindingInfo `json:"FindingList" validate:"required"`
+}
+
+// SGAuditContext is shared by all checks of an audit.
+type SGAuditContext struct {
+	ConnectionName string
+	UsedSGIDs      map[string]bool // short CSP IDs of SGs used by VMs
+}
+
+// SGAuditCheck is a pluggable audit check, Check returns the findings of a SecurityGroup.
+type SGAuditCheck struct {
+	Name     string
+	Severity string
+	Check    func(sgInfo *cres.SecurityInfo, auditCtx *SGAuditContext) []SGAuditFindingInfo
+}
+
+var sgAuditCheckLock sync.RWMutex
+var sgAuditCheckList = []SGAuditCheck{
+	{Name: AUDIT_SENSITIVE_PORT_OPEN, Severity: AUDIT_HIGH, Check: checkSensitivePortOpen},
+	{Name: AUDIT_ALL_PROTOCOL_INBOUND, Severity: AUDIT_HIGH, Check: checkAllProtocolInbound},
+	{Name: AUDIT_BROAD_PORT_RANGE, Severity: AUDIT_MEDIUM, Check: checkBroadPortRange},
+	{Name: AUDIT_UNUSED_SG, Severity: AUDIT_LOW, Check: checkUnusedSG},
+	{Name: AUDIT_DUPLICATE_RULE, Severity: AUDIT_LOW, Check: checkDuplicateRule},
+}
+
+// RegisterSGAuditCheck adds a check to the audit rule set.
+func RegisterSGAuditCheck(check SGAuditCheck) error {
+	check.Name = strings.TrimSpace(check.Name)
+	if check.Name == "" || check.Check == nil {
+		return fmt.Errorf("The audit check must have a Name and a Check function!")
+	}
+	switch check.Severity {
+	case AUDIT_HIGH, AUDIT_MEDIUM, AUDIT_LOW:
+	default:
+		return fmt.Errorf("%s is not a valid Severity, use %s, %s or %s!", check.Severity, AUDIT_HIGH, AUDIT_MEDIUM, AUDIT_LOW)
+	}
+
+	sgAuditCheckLock.Lock()
+	defer sgAuditCheckLock.Unlock()
+	for _, one := range sgAuditCheckList {
+		if one.Name == check.Name {
+			return fmt.Errorf("The audit check '%s' already exists!", check.Name)
+		}
+	}
+	sgAuditCheckList = append(sgAuditCheckList, check)
+	return nil
+}
+
+// UnregisterSGAuditCheck removes a check added by RegisterSGAuditCheck, the built-in checks can not be removed.
+func UnregisterSGAuditCheck(name string) error {
+	name = strings.TrimSpace(name)
+	if isBuiltInSGAuditCheck(name) {
+		return fmt.Errorf("The built-in audit check '%s' can not be removed!", name)
+	}
+
+	sgAuditCheckLock.Lock()
+	defer sgAuditCheckLock.Unlock()
+	for i, one := range sgAuditCheckList {
+		if one.Name == name {
+			sgAuditCheckList = append(sgAuditCheckList[:i], sgAuditCheckList[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("The audit check '%s' does not exist!", name)
+}
+
+// GetSGAuditCheck returns a registered audit check, ex) to run a built-in check in a custom check.
+func GetSGAuditCheck(name string) (SGAuditCheck, error) {
+	sgAuditCheckLock.RLock()
+	defer sgAuditCheckLock.RUnlock()
+	for _, one := range sgAuditCheckList {
+		if one.Name == strings.TrimSpace(name) {
+			return one, nil
+		}
+	}
+	return SGAuditCheck{}, fmt.Errorf("%s is not a valid audit check, use one of %v!", name, listSGAuditCheckNames())
+}
+
+func isBuiltInSGAuditCheck(name string) bool {
+	switch name {
+	case AUDIT_SENSITIVE_PORT_OPEN, AUDIT_ALL_PROTOCOL_INBOUND, AUDIT_BROAD_PORT_RANGE, AUDIT_UNUSED_SG, AUDIT_DUPLICATE_RULE:
+		return true
+	}
+	return false
+}
+
+// ListSGAuditCheckNames returns the names of all registered audit checks.
+func ListSGAuditCheckNames() []string {
+	sgAuditCheckLock.RLock()
+	defer sgAuditCheckLock.RUnlock()
+	return listSGAuditCheckNames()
+}
+
+// the caller must hold sgAuditCheckLock
+func listSGAuditCheckNames() []string {
+	nameList := []string{}
+	for _, one := range sgAuditCheckList {
+		nameList = append(nameList, one.Name)
+	}
+	return nameList
+}
+
+// (1) select the checks
+// (2) get the managed SGs and the SGs used by VMs
+// (3) run the checks on each SG
+func AuditSecurity(connectionName string, checkNameList []string) (*SGAuditReportInfo, error) {
+	cblog.Info("call AuditSecurity()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (1) select the checks
+	checkList, err := selectSGAuditChecks(checkNameList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) get the managed SGs and the SGs used by VMs
+	sgInfoList, err := ListSecurity(connectionName, SG)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	usedSGIDs, err := getUsedSGIDs(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	auditCtx := SGAuditContext{ConnectionName: connectionName, UsedSGIDs: usedSGIDs}
+
+	// (3) run the checks on each SG
+	report := SGAuditReportInfo{
+		ConnectionName: connectionName,
+		AuditTime:      time.Now(),
+		CheckList:      []string{},
+		SGCount:        len(sgInfoList),
+		Summary:        map[string]int{AUDIT_HIGH: 0, AUDIT_MEDIUM: 0, AUDIT_LOW: 0},
+		FindingList:    []SGAuditFindingInfo{},
+	}
+	for _, check := range checkList {
+		report.CheckList = append(report.CheckList, check.Name)
+	}
+	for _, sgInfo := range sgInfoList {
+		for _, check := range checkList {
+			for _, finding := range check.Check(sgInfo, &auditCtx) {
+				finding.SecurityGroup = sgInfo.IId
+				finding.Check = check.Name
+				if finding.Severity == "" {
+					finding.Severity = check.Severity
+				}
+				report.Summary[finding.Severity]++
+				report.FindingList = append(report.FindingList, finding)
+			}
+		}
+	}
+
+	return &report, nil
+}
+
+func selectSGAuditChecks(checkNameList []string) ([]SGAuditCheck, error) {
+	sgAuditCheckLock.RLock()
+	defer sgAuditCheckLock.RUnlock()
+
+	if len(checkNameList) == 0 {
+		return append([]SGAuditCheck{}, sgAuditCheckList...), nil
+	}
+
+	checkList := []SGAuditCheck{}
+	for _, name := range checkNameList {
+		found := false
+		for _, one := range sgAuditCheckList {
+			if one.Name == strings.TrimSpace(name) {
+				checkList = append(checkList, one)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%s is not a valid audit check, use one of %v!", name, listSGAuditCheckNames())
+		}
+	}
+	return checkList, nil
+}
+
+// get the short CSP IDs of the SGs used by all VMs in the CSP, same matching as GetVMUsingRS()
+func getUsedSGIDs(connectionName string) (map[string]bool, error) {
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateVMHandler()
+	if err != nil {
+		return nil, err
+	}
+
+	vmInfoList, err := handler.ListVM()
+	if err != nil {
+		return nil, err
+	}
+
+	usedSGIDs := map[string]bool{}
+	for _, vmInfo := range vmInfoList {
+		for _, sgIID := range vmInfo.SecurityGroupIIds {
+			// Do not use NameId, because Azure driver use it like SystemId
+			usedSGIDs[getMSShortID(sgIID.SystemId)] = true
+		}
+	}
+	return usedSGIDs, nil
+}
+
+//================ built-in audit checks
+
+func isOpenToWorld(rule cres.SecurityRuleInfo) bool {
+	return rule.SecurityGroupIID == nil && (rule.CIDR == "0.0.0.0/0" || rule.CIDR == "::/0")
+}
+
+func isAllowInbound(rule cres.SecurityRuleInfo) bool {
+	return rule.Direction == "inbound" && rule.Action != cres.RuleDeny
+}
+
+// get the port range of a rule, "-1" or "" means all ports
+func getRulePortRange(rule cres.SecurityRuleInfo) (int, int, error) {
+	if rule.IPProtocol == "ALL" {
+		return 0, 65535, nil
+	}
+	if rule.FromPort == "" || rule.FromPort == "-1" || rule.ToPort == "" || rule.ToPort == "-1" {
+		return 0, 65535, nil
+	}
+	from, err := strconv.Atoi(rule.FromPort)
+	if err != nil {
+		return 0, 0, err
+	}
+	to, err := strconv.Atoi(rule.ToPort)
+	if err != nil {
+		return 0, 0, err
+	}
+	return from, to, nil
+}
+
+func sortedSensitivePorts() []int {
+	portList := []int{}
+	for port := range AUDIT_SENSITIVE_PORTS {
+		portList = append(portList, port)
+	}
+	sort.Ints(portList)
+	return portList
+}
+
+func checkSensitivePortOpen(sgInfo *cres.SecurityInfo, auditCtx *SGAuditContext) []SGAuditFindingInfo {
+	findingList := []SGAuditFindingInfo{}
+	if sgInfo.SecurityRules == nil {
+		return findingList
+	}
+	for _, rule := range *sgInfo.SecurityRules {
+		if !isAllowInbound(rule) || !isOpenToWorld(rule) || rule.IPProtocol == "ICMP" {
+			continue
+		}
+		from, to, err := getRulePortRange(rule)
+		if err != nil {
+			continue
+		}
+		for _, port := range sortedSensitivePorts() {
+			name := AUDIT_SENSITIVE_PORTS[port]
+			if from <= port && port <= to {
+				oneRule := rule
+				findingList = append(findingList, SGAuditFindingInfo{Rule: &oneRule,
+					Message: fmt.Sprintf("%s(%d) is open to %s", name, port, rule.CIDR)})
+			}
+		}
+	}
+	return findingList
+}
+
+func checkAllProtocolInbound(sgInfo *cres.SecurityInfo, auditCtx *SGAuditContext) []SGAuditFindingInfo {
+	findingList := []SGAuditFindingInfo{}
+	if sgInfo.SecurityRules == nil {
+		return findingList
+	}
+	for _, rule := range *sgInfo.SecurityRules {
+		if !isAllowInbound(rule) || rule.IPProtocol != "ALL" {
+			continue
+		}
+		oneRule := rule
+		finding := SGAuditFindingInfo{Rule: &oneRule, Message: "ALL protocols are allowed inbound"}
+		if !isOpenToWorld(rule) {
+			// limited sources are less risky
+			finding.Severity = AUDIT_MEDIUM
+		}
+		findingList = append(findingList, finding)
+	}
+	return findingList
+}
+
+func checkBroadPortRange(sgInfo *cres.SecurityInfo, auditCtx *SGAuditContext) []SGAuditFindingInfo {
+	findingList := []SGAuditFindingInfo{}
+	if sgInfo.SecurityRules == nil {
+		return findingList
+	}
+	for _, rule := range *sgInfo.SecurityRules {
+		// ALL protocol is reported by all-protocol-inbound
+		if !isAllowInbound(rule) || rule.IPProtocol == "ALL" || rule.IPProtocol == "ICMP" {
+			continue
+		}
+		from, to, err := getRulePortRange(rule)
+		if err != nil {
+			continue
+		}
+		if to-from+1 > AUDIT_MAX_PORT_RANGE {
+			oneRule := rule
+			findingList = append(findingList, SGAuditFindingInfo{Rule: &oneRule,
+				Message: fmt.Sprintf("%d ports(%d-%d) are open, more than %d", to-from+1, from, to, AUDIT_MAX_PORT_RANGE)})
+		}
+	}
+	return findingList
+}
+
+func checkUnusedSG(sgInfo *cres.SecurityInfo, auditCtx *SGAuditContext) []SGAuditFindingInfo {
+	if auditCtx.UsedSGIDs[getMSShortID(sgInfo.IId.SystemId)] {
+		return []SGAuditFindingInfo{}
+	}
+	return []SGAuditFindingInfo{{Message: "The SecurityGroup is not used by any VM"}}
+}
+
+func checkDuplicateRule(sgInfo *cres.SecurityInfo, auditCtx *SGAuditContext) []SGAuditFindingInfo {
+	findingList := []SGAuditFindingInfo{}
+	if sgInfo.SecurityRules == nil {
+		return findingList
+	}
+	ruleList := *sgInfo.SecurityRules
+	for i := range ruleList {
+		for j := 0; j < i; j++ {
+			if isEqualSecurityRule(ruleList[i], ruleList[j]) {
+				oneRule := ruleList[i]
+				findingList = append(findingList, SGAuditFindingInfo{Rule: &oneRule, Message: "The rule is duplicated"})
+				break
+			}
+		}
+	}
+	return findingList
+}
@@ -10,6 +10,7 @@ package commonruntime
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
@@ -228,6 +229,7 @@ func RegisterSecurity(connectionName string, vpcUserID string, userIID cres.IID)
 	// IPProtocol: to upper
 	// no CIDR: "0.0.0.0/0"
 	transformArgs(getInfo.SecurityRules)
+	setRuleSGUserIIDs(connectionName, getInfo.SecurityRules)
 
 	// (3) create spiderIID: {UserID, SP-XID:CSP-ID}
 	//     ex) spiderIID {"vpc-01", "vpc-01-9m4e2mr0ui3e8a215n4g:i-0bc7123b7e5cbf79d"}
@@ -269,7 +271,7 @@ func RegisterSecurity(connectionName string, vpcUserID string, userIID cres.IID)
 // (4) create spiderIID: {reqNameID, "driverNameID:driverSystemID"}
 // (5) insert spiderIID
 // (6) create userIID
-func CreateSecurity(connectionName string, rsType string, reqInfo cres.SecurityReqInfo, IDTransformMode string) (*cres.SecurityInfo, error) {
+func CreateSecurity(connectionName string, rsType string, reqInfo cres.SecurityReqInfo, templateRefList []SGTemplateRefInfo, IDTransformMode string) (*cres.SecurityInfo, error) {
 	cblog.Info("call CreateSecurity()")
 
 	// check empty and trim user inputs
@@ -293,6 +295,13 @@ func CreateSecurity(connectionName string, rsType string, reqInfo cres.SecurityR
 	   }
 	*/
 
+	// expand the rule templates into the concrete rules
+	reqInfo.SecurityRules, err = appendSGTemplateRules(reqInfo.SecurityRules, templateRefList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
 	vpcSPLock.Lock(connectionName, reqInfo.VpcIID.NameId)
 	defer vpcSPLock.Unlock(connectionName, reqInfo.VpcIID.NameId)
 
@@ -324,6 +333,25 @@ func CreateSecurity(connectionName string, rsType string, reqInfo cres.SecurityR
 	// no CIDR: "0.0.0.0/0"
 	transformArgs(reqInfo.SecurityRules)
 
+	err = validateRuleIPv6CIDRs(connectionName, reqInfo.SecurityRules)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	err = validateRuleActionPriority(connectionName, reqInfo.SecurityRules)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// set the driver IIDs of the referenced SGs
+	err = setRuleSGDriverIIDs(connectionName, reqInfo.SecurityRules)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
 	sgSPLock.Lock(connectionName, reqInfo.IId.NameId)
 	defer sgSPLock.Unlock(connectionName, reqInfo.IId.NameId)
 	// (1) check exist(NameID)
@@ -381,6 +409,7 @@ func CreateSecurity(connectionName string, rsType string, reqInfo cres.SecurityR
 	// IPProtocol: to upper
 	// no CIDR: "0.0.0.0/0"
 	transformArgs(info.SecurityRules)
+	setRuleSGUserIIDs(connectionName, info.SecurityRules)
 
 	// set VPC NameId
 	info.VpcIID.NameId = reqInfo.VpcIID.NameId
@@ -421,10 +450,176 @@ func transformArgs(ruleList *[]cres.SecurityRuleInfo) {
 		(*ruleList)[n].Direction = strings.ToLower((*ruleList)[n].Direction)
 		// IPProtocol: to upper => ALL | TCP | UDP | ICMP
 		(*ruleList)[n].IPProtocol = strings.ToUpper((*ruleList)[n].IPProtocol)
-		// no CIDR, set default ("0.0.0.0/0")
-		if (*ruleList)[n].CIDR == "" {
+		// no CIDR and no SecurityGroupIID, set default ("0.0.0.0/0")
+		if (*ruleList)[n].CIDR == "" && (*ruleList)[n].SecurityGroupIID == nil {
 			(*ruleList)[n].CIDR = "0.0.0.0/0"
 		}
+		// Action: to lower, no Action, set default ("allow")
+		(*ruleList)[n].Action = strings.ToLower(strings.TrimSpace((*ruleList)[n].Action))
+		if (*ruleList)[n].Action == "" {
+			(*ruleList)[n].Action = cres.RuleAllow
+		}
+		(*ruleList)[n].Priority = strings.TrimSpace((*ruleList)[n].Priority)
+	}
+}
+
+// check the Action and Priority of rules with the driver capabilities(SG_DENY_RULE, SG_RULE_PRIORITY)
+func validateRuleActionPriority(connectionName string, ruleList *[]cres.SecurityRuleInfo) error {
+	if ruleList == nil {
+		return nil
+	}
+	hasDeny, hasPriority := false, false
+	for _, rule := range *ruleList {
+		switch rule.Action {
+		case "", cres.RuleAllow:
+		case cres.RuleDeny:
+			hasDeny = true
+		default:
+			return fmt.Errorf("%s is not a valid Action of a rule, use %s or %s!", rule.Action, cres.RuleAllow, cres.RuleDeny)
+		}
+		if rule.Priority != "" {
+			priority, err := strconv.Atoi(rule.Priority)
+			if err != nil || priority < 0 {
+				return fmt.Errorf("%s is not a valid Priority of a rule, it should be a non-negative number!", rule.Priority)
+			}
+			hasPriority = true
+		}
+	}
+	if !hasDeny && !hasPriority {
+		return nil
+	}
+
+	drv, err := ccm.GetCloudDriver(connectionName)
+	if err != nil {
+		return err
+	}
+	capability := drv.GetDriverCapability()
+	if hasDeny && !capability.SG_DENY_RULE {
+		return fmt.Errorf("The connection %s does not support the deny rules!", connectionName)
+	}
+	if hasPriority && !capability.SG_RULE_PRIORITY {
+		return fmt.Errorf("The connection %s does not support the Priority of rules!", connectionName)
+	}
+	return nil
+}
+
+// check the IPv6 CIDRs(ex: ::/0) of rules with the driver capability(IPV6)
+func validateRuleIPv6CIDRs(connectionName string, ruleList *[]cres.SecurityRuleInfo) error {
+	if ruleList == nil {
+		return nil
+	}
+	checked := false
+	for _, rule := range *ruleList {
+		if !strings.Contains(rule.CIDR, ":") {
+			continue
+		}
+		if _, err := parseIPv6CIDR(rule.CIDR); err != nil {
+			return err
+		}
+		if !checked {
+			if err := checkIPv6Capability(connectionName); err != nil {
+				return err
+			}
+			checked = true
+		}
+	}
+	return nil
+}
+
+// set the driver IIDs of the SGs referenced by rules
+// (1) check the driver capability(SG_REFERENCE_RULE)
+// (2) get the SG IIDInfo(NameId) and set the driver IID
+func setRuleSGDriverIIDs(connectionName string, ruleList *[]cres.SecurityRuleInfo) error {
+	if ruleList == nil || !hasRuleSGReference(*ruleList) {
+		return nil
+	}
+
+	// (1) check the driver capability(SG_REFERENCE_RULE)
+	err := checkSGReferenceCapability(connectionName)
+	if err != nil {
+		return err
+	}
+
+	// (2) get the SG IIDInfo(NameId) and set the driver IID
+	for n, rule := range *ruleList {
+		if rule.SecurityGroupIID == nil {
+			continue
+		}
+		driverIID, err := getRuleSGDriverIID(connectionName, rule)
+		if err != nil {
+			return err
+		}
+		(*ruleList)[n].SecurityGroupIID = &driverIID
+	}
+	return nil
+}
+
+func hasRuleSGReference(ruleList []cres.SecurityRuleInfo) bool {
+	for _, rule := range ruleList {
+		if rule.SecurityGroupIID != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func checkSGReferenceCapability(connectionName string) error {
+	drv, err := ccm.GetCloudDriver(connectionName)
+	if err != nil {
+		return err
+	}
+	if !drv.GetDriverCapability().SG_REFERENCE_RULE {
+		return fmt.Errorf("The connection %s does not support the rules referencing a %s!", connectionName, RSTypeString(SG))
+	}
+	return nil
+}
+
+// returns the driver IID of the SG referenced by a rule
+func getRuleSGDriverIID(connectionName string, rule cres.SecurityRuleInfo) (cres.IID, error) {
+	if rule.CIDR != "" {
+		return cres.IID{}, fmt.Errorf("A rule can not have both CIDR(%s) and SecurityGroupIID(%s)!", rule.CIDR, rule.SecurityGroupIID.NameId)
+	}
+
+	nameId, err := EmptyCheckAndTrim("SecurityGroupIID.NameId", rule.SecurityGroupIID.NameId)
+	if err != nil {
+		return cres.IID{}, err
+	}
+
+	bool_ret, err := infostore.HasByConditions(&SGIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameId)
+	if err != nil {
+		return cres.IID{}, err
+	}
+	if !bool_ret {
+		return cres.IID{}, fmt.Errorf("The %s '%s' referenced by a rule does not exist!", RSTypeString(SG), nameId)
+	}
+
+	var iidInfo SGIIDInfo
+	err = infostore.GetByConditions(&iidInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameId)
+	if err != nil {
+		return cres.IID{}, err
+	}
+	return getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}), nil
+}
+
+// set the user IIDs of the SGs referenced by rules,
+// the NameId of a SG not managed by Spider is empty.
+func setRuleSGUserIIDs(connectionName string, ruleList *[]cres.SecurityRuleInfo) {
+	if ruleList == nil {
+		return
+	}
+	for n, rule := range *ruleList {
+		if rule.SecurityGroupIID == nil || rule.SecurityGroupIID.SystemId == "" {
+			continue
+		}
+		userIID := cres.IID{NameId: "", SystemId: rule.SecurityGroupIID.SystemId}
+		var iidInfo SGIIDInfo
+		err := infostore.GetByContain(&iidInfo, CONNECTION_NAME_COLUMN, connectionName, SYSTEM_ID_COLUMN, rule.SecurityGroupIID.SystemId)
+		if err == nil {
+			userIID = getUserIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+		} else {
+			cblog.Info(err)
+		}
+		(*ruleList)[n].SecurityGroupIID = &userIID
 	}
 }
 
@@ -489,6 +684,7 @@ func ListSecurity(connectionName string, rsType string) ([]*cres.SecurityInfo, e
 		// IPProtocol: to upper
 		// no CIDR: "0.0.0.0/0"
 		transformArgs(info.SecurityRules)
+		setRuleSGUserIIDs(connectionName, info.SecurityRules)
 
 		// (3) set ResourceInfo(IID.NameId)
 		// set ResourceInfo
@@ -561,6 +757,7 @@ func GetSecurity(connectionName string, rsType string, nameID string) (*cres.Sec
 	// IPProtocol: to upper
 	// no CIDR: "0.0.0.0/0"
 	transformArgs(info.SecurityRules)
+	setRuleSGUserIIDs(connectionName, info.SecurityRules)
 
 	// (3) set ResourceInfo(IID.NameId)
 	// set ResourceInfo
@@ -579,8 +776,8 @@ func GetSecurity(connectionName string, rsType string, nameID string) (*cres.Sec
 }
 
 // (1) check exist(NameID)
-// (2) add Rules
-func AddRules(connectionName string, sgName string, reqInfoList []cres.SecurityRuleInfo) (*cres.SecurityInfo, error) {
+// (2) add Rules, the rule templates are expanded and added with the rules
+func AddRules(connectionName string, sgName string, reqInfoList []cres.SecurityRuleInfo, templateRefList []SGTemplateRefInfo) (*cres.SecurityInfo, error) {
 	cblog.Info("call AddRules()")
 
 	// check empty and trim user inputs
@@ -608,11 +805,38 @@ func AddRules(connectionName string, sgName string, reqInfoList []cres.SecurityR
 		return nil, err
 	}
 
+	// expand the rule templates into the concrete rules
+	ruleList, err := appendSGTemplateRules(&reqInfoList, templateRefList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	reqInfoList = *ruleList
+
 	// Direction: to lower
 	// IPProtocol: to upper
 	// no CIDR: "0.0.0.0/0"
 	transformArgs(&reqInfoList)
 
+	err = validateRuleIPv6CIDRs(connectionName, &reqInfoList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	err = validateRuleActionPriority(connectionName, &reqInfoList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// set the driver IIDs of the referenced SGs
+	err = setRuleSGDriverIIDs(connectionName, &reqInfoList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
 	sgSPLock.Lock(connectionName, sgName)
 	defer sgSPLock.Unlock(connectionName, sgName)
 
@@ -642,10 +866,12 @@ func AddRules(connectionName string, sgName string, reqInfoList []cres.SecurityR
 		cblog.Error(err)
 		return nil, err
 	}
+	invalidateResourceSnapshot(connectionName, SG, sgName)
 	// Direction: to lower
 	// IPProtocol: to upper
 	// no CIDR: "0.0.0.0/0"
 	transformArgs(info.SecurityRules)
+	setRuleSGUserIIDs(connectionName, info.SecurityRules)
 
 	// (3) set ResourceInfo(userIID)
 	info.IId = getUserIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
@@ -697,6 +923,13 @@ func RemoveRules(connectionName string, sgName string, reqRuleInfoList []cres.Se
 	// no CIDR: "0.0.0.0/0"
 	transformArgs(&reqRuleInfoList)
 
+	// set the driver IIDs of the referenced SGs
+	err = setRuleSGDriverIIDs(connectionName, &reqRuleInfoList)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
 	sgSPLock.Lock(connectionName, sgName)
 	defer sgSPLock.Unlock(connectionName, sgName)
 
@@ -726,10 +959,232 @@ func RemoveRules(connectionName string, sgName string, reqRuleInfoList []cres.Se
 		cblog.Error(err)
 		return false, err
 	}
+	invalidateResourceSnapshot(connectionName, SG, sgName)
 
 	return result, nil
 }
 
+// SecurityRuleSyncInfo represents the delta applied to sync the rules of a SecurityGroup to the desired rules.
+type SecurityRuleSyncInfo struct {
+	DryRun         bool                    `json:"DryRun"`
+	AddedRules     []cres.SecurityRuleInfo `json:"AddedRules"`
+	RemovedRules   []cres.SecurityRuleInfo `json:"RemovedRules"`
+	UnchangedRules []cres.SecurityRuleInfo `json:"UnchangedRules"`
+}
+
+// (1) check exist(NameID)
+// (2) get current Rules
+// (3) diff current Rules with desired Rules
+// (4) remove and add Rules
+func SyncRules(connectionName string, sgName string, reqRuleInfoList []cres.SecurityRuleInfo, dryRun bool) (*SecurityRuleSyncInfo, error) {
+	cblog.Info("call SyncRules()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	sgName, err = EmptyCheckAndTrim("sgName", sgName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateSecurityHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// Direction: to lower
+	// IPProtocol: to upper
+	// no CIDR: "0.0.0.0/0"
+	transformArgs(&reqRuleInfoList)
+
+	err = validateRuleIPv6CIDRs(connectionName, &reqRuleInfoList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	err = validateRuleActionPriority(connectionName, &reqRuleInfoList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// set the driver IIDs of the referenced SGs
+	err = setRuleSGDriverIIDs(connectionName, &reqRuleInfoList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	sgSPLock.Lock(connectionName, sgName)
+	defer sgSPLock.Unlock(connectionName, sgName)
+
+	// (1) check exist(sgName)
+	bool_ret, err := infostore.HasByConditions(&SGIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, sgName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if !bool_ret {
+		err := fmt.Errorf("The %s '%s' does not exist!", RSTypeString(SG), sgName)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	var iidInfo SGIIDInfo
+	err = infostore.GetByConditions(&iidInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, sgName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) get current Rules
+	// driverIID for driver
+	driverIId := getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+	info, err := handler.GetSecurity(driverIId)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	curRuleInfoList := []cres.SecurityRuleInfo{}
+	if info.SecurityRules != nil {
+		curRuleInfoList = *info.SecurityRules
+	}
+	transformArgs(&curRuleInfoList)
+
+	// (3) diff current Rules with desired Rules
+	syncInfo := diffSecurityRules(curRuleInfoList, reqRuleInfoList)
+	syncInfo.DryRun = dryRun
+
+	// (4) remove and add Rules
+	// remove first, some CSPs reject a rule that differs from an existing one only in the Description
+	if !dryRun {
+		if len(syncInfo.RemovedRules) > 0 {
+			_, err = handler.RemoveRules(driverIId, &syncInfo.RemovedRules)
+			if err != nil {
+				cblog.Error(err)
+				return nil, err
+			}
+			invalidateResourceSnapshot(connectionName, SG, sgName)
+		}
+		if len(syncInfo.AddedRules) > 0 {
+			_, err = handler.AddRules(driverIId, &syncInfo.AddedRules)
+			if err != nil {
+				cblog.Error(err)
+				if len(syncInfo.RemovedRules) == 0 {
+					return nil, err
+				}
+				// rollback: restore the removed Rules
+				_, err2 := handler.AddRules(driverIId, &syncInfo.RemovedRules)
+				if err2 != nil {
+					cblog.Error(err2)
+					err = fmt.Errorf("%v, and %d removed rules of the %s '%s' could not be restored: %v",
+						err, len(syncInfo.RemovedRules), RSTypeString(SG), sgName, err2)
+				} else {
+					err = fmt.Errorf("%v, and %d removed rules of the %s '%s' were restored",
+						err, len(syncInfo.RemovedRules), RSTypeString(SG), sgName)
+				}
+				cblog.Error(err)
+				return nil, err
+			}
+			invalidateResourceSnapshot(connectionName, SG, sgName)
+		}
+	}
+
+	setRuleSGUserIIDs(connectionName, &syncInfo.AddedRules)
+	setRuleSGUserIIDs(connectionName, &syncInfo.RemovedRules)
+	setRuleSGUserIIDs(connectionName, &syncInfo.UnchangedRules)
+
+	return &syncInfo, nil
+}
+
+// diff the current rules with the desired rules, the duplicated desired rules are ignored.
+func diffSecurityRules(curRuleInfoList []cres.SecurityRuleInfo, reqRuleInfoList []cres.SecurityRuleInfo) SecurityRuleSyncInfo {
+	syncInfo := SecurityRuleSyncInfo{
+		AddedRules:     []cres.SecurityRuleInfo{},
+		RemovedRules:   []cres.SecurityRuleInfo{},
+		UnchangedRules: []cres.SecurityRuleInfo{},
+	}
+
+	desiredRuleInfoList := []cres.SecurityRuleInfo{}
+	for _, reqRule := range reqRuleInfoList {
+		if !containsSecurityRule(desiredRuleInfoList, reqRule) {
+			desiredRuleInfoList = append(desiredRuleInfoList, reqRule)
+		}
+	}
+
+	for _, curRule := range curRuleInfoList {
+		if containsSecurityRule(desiredRuleInfoList, curRule) {
+			syncInfo.UnchangedRules = append(syncInfo.UnchangedRules, curRule)
+		} else {
+			syncInfo.RemovedRules = append(syncInfo.RemovedRules, curRule)
+		}
+	}
+	for _, desiredRule := range desiredRuleInfoList {
+		if !containsSecurityRule(curRuleInfoList, desiredRule) {
+			syncInfo.AddedRules = append(syncInfo.AddedRules, desiredRule)
+		}
+	}
+
+	return syncInfo
+}
+
+func containsSecurityRule(ruleList []cres.SecurityRuleInfo, rule cres.SecurityRuleInfo) bool {
+	for _, r := range ruleList {
+		if isEqualSecurityRule(r, rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// rule identity: Direction, IPProtocol, Ports, CIDR, Description, Priority, Action and the referenced SG
+// The Description and Priority are compared only if both rules have them, because most drivers do not return them.
+// An empty Action is the allow.
+func isEqualSecurityRule(a cres.SecurityRuleInfo, b cres.SecurityRuleInfo) bool {
+	if a.Direction != b.Direction || a.IPProtocol != b.IPProtocol {
+		return false
+	}
+	if a.FromPort != b.FromPort || a.ToPort != b.ToPort || a.CIDR != b.CIDR {
+		return false
+	}
+	if a.Description != "" && b.Description != "" && a.Description != b.Description {
+		return false
+	}
+	if a.Priority != "" && b.Priority != "" && a.Priority != b.Priority {
+		return false
+	}
+	if getRuleAction(a) != getRuleAction(b) {
+		return false
+	}
+	if (a.SecurityGroupIID == nil) != (b.SecurityGroupIID == nil) {
+		return false
+	}
+	if a.SecurityGroupIID != nil && a.SecurityGroupIID.SystemId != b.SecurityGroupIID.SystemId {
+		return false
+	}
+	return true
+}
+
+func getRuleAction(rule cres.SecurityRuleInfo) string {
+	if rule.Action == "" {
+		return cres.RuleAllow
+	}
+	return rule.Action
+}
+
 // (1) get spiderIID
 // (2) delete Resource(SystemId)
 // (3) delete IID
@@ -65,7 +65,14 @@ func AddTag(connectionName string, resType cres.RSType, resName string, tag cres
 		return cres.KeyValue{}, err
 	}
 
-	return handler.AddTag(resType, getDriverIID(cres.IID{NameId: nameId, SystemId: systemId}), tag)
+	tagInfo, err := handler.AddTag(resType, getDriverIID(cres.IID{NameId: nameId, SystemId: systemId}), tag)
+	if err != nil {
+		cblog.Error(err)
+		return cres.KeyValue{}, err
+	}
+	invalidateResourceSnapshot(connectionName, string(resType), resName)
+
+	return tagInfo, nil
 }
 
 // ListTag lists all tags of a resource.
@@ -212,7 +219,14 @@ func RemoveTag(connectionName string, resType cres.RSType, resName string, key s
 		return false, err
 	}
 
-	return handler.RemoveTag(resType, getDriverIID(cres.IID{NameId: nameId, SystemId: systemId}), key)
+	result, err := handler.RemoveTag(resType, getDriverIID(cres.IID{NameId: nameId, SystemId: systemId}), key)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+	invalidateResourceSnapshot(connectionName, string(resType), resName)
+
+	return result, nil
 }
 
 // FindTag finds tags by key or value.
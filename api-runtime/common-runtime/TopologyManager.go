@@ -0,0 +1,323 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package commonruntime
+
+import (
+	"fmt"
+	"strings"
+
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+// Relations of the topology edges
+const (
+	TOPOLOGY_CONTAINS = "contains" // VPC => Subnet
+	TOPOLOGY_OWNS     = "owns"     // VPC => SG, NLB, Cluster
+	TOPOLOGY_ATTACHED = "attached" // VM => Subnet, Disk
+	TOPOLOGY_SECURED  = "secured"  // VM, Cluster => SG
+	TOPOLOGY_TARGETS  = "targets"  // NLB => VM
+	TOPOLOGY_USES     = "uses"     // Cluster => Subnet
+)
+
+// TopologyNodeInfo represents a resource in the topology graph.
+type TopologyNodeInfo struct {
+	Id           string `json:"Id" validate:"required" example:"subnet:vpc-01/subnet-01"`
+	ResourceType string `json:"ResourceType" validate:"required" example:"subnet"`
+	NameId       string `json:"NameId,omitempty" validate:"omitempty" example:"subnet-01"` // "" if not managed by Spider
+	SystemId     string `json:"SystemId,omitempty" validate:"omitempty" example:"subnet-0a1b2c3d"`
+	Managed      bool   `json:"Managed" validate:"required" example:"true"`
+}
+
+// TopologyEdgeInfo represents a relation between two resources.
+type TopologyEdgeInfo struct {
+	From     string `json:"From" validate:"required" example:"vm:vm-01"`
+	To       string `json:"To" validate:"required" example:"subnet:vpc-01/subnet-01"`
+	Relation string `json:"Relation" validate:"required" example:"attached"`
+}
+
+// TopologyInfo represents the deployed architecture of a connection.
+type TopologyInfo struct {
+	ConnectionName string             `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	NodeList       []TopologyNodeInfo `json:"NodeList" validate:"required"`
+	EdgeList       []TopologyEdgeInfo `json:"EdgeList" validate:"required"`
+	ErrorList      []string           `json:"ErrorList,omitempty" validate:"omitempty"` // resource types which could not be listed
+}
+
+type topologyBuilder struct {
+	topology TopologyInfo
+	nodeMap  map[string]bool
+	edgeMap  map[string]bool
+}
+
+// (1) add the nodes of the IID tables
+// (2) add the edges of the VPC dependent IID tables
+// (3) add the edges of VMs, NLBs and Clusters from the CSP infos
+func GetTopology(connectionName string) (*TopologyInfo, error) {
+	cblog.Info("call GetTopology()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	builder := topologyBuilder{
+		topology: TopologyInfo{ConnectionName: connectionName, NodeList: []TopologyNodeInfo{}, EdgeList: []TopologyEdgeInfo{}},
+		nodeMap:  map[string]bool{},
+		edgeMap:  map[string]bool{},
+	}
+
+	// (1) add the nodes of the IID tables
+	// (2) add the edges of the VPC dependent IID tables
+	var vpcIIDInfoList []*VPCIIDInfo
+	err = infostore.ListByCondition(&vpcIIDInfoList, CONNECTION_NAME_COLUMN, connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	for _, one := range vpcIIDInfoList {
+		builder.addNode(VPC, "", one.NameId, getDriverSystemId(cres.IID{NameId: one.NameId, SystemId: one.SystemId}))
+	}
+
+	var subnetIIDInfoList []*SubnetIIDInfo
+	err = infostore.ListByCondition(&subnetIIDInfoList, CONNECTION_NAME_COLUMN, connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	for _, one := range subnetIIDInfoList {
+		subnetId := builder.addNode(SUBNET, one.OwnerVPCName, one.NameId, getDriverSystemId(cres.IID{NameId: one.NameId, SystemId: one.SystemId}))
+		builder.addEdge(topologyNodeId(VPC, "", one.OwnerVPCName), subnetId, TOPOLOGY_CONTAINS)
+	}
+
+	vpcDependentList := []struct {
+		rsType   string
+		iidInfos interface{}
+	}{
+		{SG, &[]*SGIIDInfo{}},
+		{NLB, &[]*NLBIIDInfo{}},
+		{CLUSTER, &[]*ClusterIIDInfo{}},
+	}
+	for _, one := range vpcDependentList {
+		err = infostore.ListByCondition(one.iidInfos, CONNECTION_NAME_COLUMN, connectionName)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		for _, iidInfo := range toVPCDependentIIDInfoList(one.iidInfos) {
+			nodeId := builder.addNode(one.rsType, "", iidInfo.NameId, getDriverSystemId(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}))
+			builder.addEdge(topologyNodeId(VPC, "", iidInfo.OwnerVPCName), nodeId, TOPOLOGY_OWNS)
+		}
+	}
+
+	var vmIIDInfoList []*VMIIDInfo
+	err = infostore.ListByCondition(&vmIIDInfoList, CONNECTION_NAME_COLUMN, connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	for _, one := range vmIIDInfoList {
+		builder.addNode(VM, "", one.NameId, getDriverSystemId(cres.IID{NameId: one.NameId, SystemId: one.SystemId}))
+	}
+
+	var diskIIDInfoList []*DiskIIDInfo
+	err = infostore.ListByCondition(&diskIIDInfoList, CONNECTION_NAME_COLUMN, connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	for _, one := range diskIIDInfoList {
+		builder.addNode(DISK, "", one.NameId, getDriverSystemId(cres.IID{NameId: one.NameId, SystemId: one.SystemId}))
+	}
+
+	// (3) add the edges of VMs, NLBs and Clusters from the CSP infos
+	// the using resources of managed VMs are mapped to user IIDs by ListVM(), same as GetVMUsingRS() for unmanaged VMs
+	if len(vmIIDInfoList) > 0 {
+		vmInfoList, err := ListVM(connectionName, VM)
+		if err != nil {
+			builder.addError(VM, err)
+		}
+		for _, vmInfo := range vmInfoList {
+			vmId := topologyNodeId(VM, "", vmInfo.IId.NameId)
+			subnetId := builder.addRefNode(SUBNET, vmInfo.VpcIID.NameId, vmInfo.SubnetIID)
+			builder.addEdge(vmId, subnetId, TOPOLOGY_ATTACHED)
+			for _, sgIID := range vmInfo.SecurityGroupIIds {
+				builder.addEdge(vmId, builder.addRefNode(SG, "", sgIID), TOPOLOGY_SECURED)
+			}
+			for _, diskIID := range vmInfo.DataDiskIIDs {
+				builder.addEdge(vmId, builder.addRefNode(DISK, "", diskIID), TOPOLOGY_ATTACHED)
+			}
+		}
+	}
+
+	if builder.hasNodeType(NLB) {
+		nlbInfoList, err := ListNLB(connectionName, NLB)
+		if err != nil {
+			builder.addError(NLB, err)
+		}
+		for _, nlbInfo := range nlbInfoList {
+			if nlbInfo.VMGroup.VMs == nil {
+				continue
+			}
+			nlbId := topologyNodeId(NLB, "", nlbInfo.IId.NameId)
+			for _, vmIID := range *nlbInfo.VMGroup.VMs {
+				builder.addEdge(nlbId, builder.addRefNode(VM, "", vmIID), TOPOLOGY_TARGETS)
+			}
+		}
+	}
+
+	if builder.hasNodeType(CLUSTER) {
+		clusterInfoList, err := ListCluster(connectionName, CLUSTER)
+		if err != nil {
+			builder.addError(CLUSTER, err)
+		}
+		for _, clusterInfo := range clusterInfoList {
+			clusterId := topologyNodeId(CLUSTER, "", clusterInfo.IId.NameId)
+			for _, subnetIID := range clusterInfo.Network.SubnetIIDs {
+				builder.addEdge(clusterId, builder.addRefNode(SUBNET, clusterInfo.Network.VpcIID.NameId, subnetIID), TOPOLOGY_USES)
+			}
+			for _, sgIID := range clusterInfo.Network.SecurityGroupIIDs {
+				builder.addEdge(clusterId, builder.addRefNode(SG, "", sgIID), TOPOLOGY_SECURED)
+			}
+		}
+	}
+
+	return &builder.topology, nil
+}
+
+// ToDOT renders the topology in the Graphviz DOT language, VPCs are clusters of their Subnets.
+func (topology *TopologyInfo) ToDOT() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("digraph %q {\n", topology.ConnectionName))
+	sb.WriteString("  rankdir=LR;\n  node [shape=box];\n")
+
+	// group the Subnets by VPC
+	subnetMap := map[string][]TopologyNodeInfo{}
+	for _, node := range topology.NodeList {
+		if node.ResourceType == SUBNET {
+			vpcName := strings.SplitN(strings.TrimPrefix(node.Id, SUBNET+":"), "/", 2)[0]
+			vpcId := topologyNodeId(VPC, "", vpcName)
+			subnetMap[vpcId] = append(subnetMap[vpcId], node)
+		}
+	}
+
+	renderedMap := map[string]bool{}
+	for _, node := range topology.NodeList {
+		switch node.ResourceType {
+		case VPC:
+			sb.WriteString(fmt.Sprintf("  subgraph %q {\n", "cluster_"+node.Id))
+			sb.WriteString(fmt.Sprintf("    label=%q;\n", node.label()))
+			sb.WriteString(fmt.Sprintf("    %q [label=%q, shape=folder];\n", node.Id, node.label()))
+			for _, subnet := range subnetMap[node.Id] {
+				sb.WriteString(fmt.Sprintf("    %q [label=%q];\n", subnet.Id, subnet.label()))
+				renderedMap[subnet.Id] = true
+			}
+			sb.WriteString("  }\n")
+		case SUBNET:
+			// written in the VPC cluster
+		default:
+			sb.WriteString(fmt.Sprintf("  %q [label=%q];\n", node.Id, node.label()))
+		}
+	}
+	// the Subnets of the VPCs not managed by Spider
+	for _, node := range topology.NodeList {
+		if node.ResourceType == SUBNET && !renderedMap[node.Id] {
+			sb.WriteString(fmt.Sprintf("  %q [label=%q];\n", node.Id, node.label()))
+		}
+	}
+	for _, edge := range topology.EdgeList {
+		sb.WriteString(fmt.Sprintf("  %q -> %q [label=%q];\n", edge.From, edge.To, edge.Relation))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func (node TopologyNodeInfo) label() string {
+	name := node.NameId
+	if name == "" {
+		name = node.SystemId
+	}
+	return node.ResourceType + "\n" + name
+}
+
+// ex) "vpc:vpc-01", "subnet:vpc-01/subnet-01", "sg:sg-0a1b2c3d" for an unmanaged SG
+func topologyNodeId(rsType string, ownerVPCName string, name string) string {
+	if rsType == SUBNET {
+		return rsType + ":" + ownerVPCName + "/" + name
+	}
+	return rsType + ":" + name
+}
+
+func (builder *topologyBuilder) addNode(rsType string, ownerVPCName string, nameId string, systemId string) string {
+	nodeId := topologyNodeId(rsType, ownerVPCName, nameId)
+	if !builder.nodeMap[nodeId] {
+		builder.nodeMap[nodeId] = true
+		builder.topology.NodeList = append(builder.topology.NodeList, TopologyNodeInfo{Id: nodeId, ResourceType: rsType,
+			NameId: nameId, SystemId: systemId, Managed: true})
+	}
+	return nodeId
+}
+
+// add the node of a referenced resource, the resource not managed by Spider is identified by the CSP ID
+func (builder *topologyBuilder) addRefNode(rsType string, ownerVPCName string, iid cres.IID) string {
+	if iid.NameId != "" {
+		return builder.addNode(rsType, ownerVPCName, iid.NameId, iid.SystemId)
+	}
+	nodeId := topologyNodeId(rsType, ownerVPCName, iid.SystemId)
+	if !builder.nodeMap[nodeId] {
+		builder.nodeMap[nodeId] = true
+		builder.topology.NodeList = append(builder.topology.NodeList, TopologyNodeInfo{Id: nodeId, ResourceType: rsType,
+			SystemId: iid.SystemId, Managed: false})
+	}
+	return nodeId
+}
+
+func (builder *topologyBuilder) addEdge(from string, to string, relation string) {
+	key := from + "|" + to + "|" + relation
+	if builder.edgeMap[key] {
+		return
+	}
+	builder.edgeMap[key] = true
+	builder.topology.EdgeList = append(builder.topology.EdgeList, TopologyEdgeInfo{From: from, To: to, Relation: relation})
+}
+
+func (builder *topologyBuilder) hasNodeType(rsType string) bool {
+	for nodeId := range builder.nodeMap {
+		if strings.HasPrefix(nodeId, rsType+":") {
+			return true
+		}
+	}
+	return false
+}
+
+func (builder *topologyBuilder) addError(rsType string, err error) {
+	cblog.Error(err)
+	builder.topology.ErrorList = append(builder.topology.ErrorList, fmt.Sprintf("%s: %v", RSTypeString(rsType), err))
+}
+
+func toVPCDependentIIDInfoList(iidInfos interface{}) []VPCDependentIIDInfo {
+	iidInfoList := []VPCDependentIIDInfo{}
+	switch list := iidInfos.(type) {
+	case *[]*SGIIDInfo:
+		for _, one := range *list {
+			iidInfoList = append(iidInfoList, VPCDependentIIDInfo(*one))
+		}
+	case *[]*NLBIIDInfo:
+		for _, one := range *list {
+			iidInfoList = append(iidInfoList, VPCDependentIIDInfo(*one))
+		}
+	case *[]*ClusterIIDInfo:
+		for _, one := range *list {
+			iidInfoList = append(iidInfoList, VPCDependentIIDInfo(*one))
+		}
+	}
+	return iidInfoList
+}
@@ -0,0 +1,190 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package commonruntime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+const VMGROUP_INDEX_PLACEHOLDER = "{index}"
+const MAX_VMGROUP_COUNT = 100
+const DEFAULT_VMGROUP_CONCURRENCY = 10
+const MAX_VMGROUP_CONCURRENCY = 20
+
+// VMGroup result status of each VM
+const (
+	VMGROUP_SUCCEEDED       = "Succeeded"
+	VMGROUP_FAILED          = "Failed"
+	VMGROUP_ROLLED_BACK     = "RolledBack"
+	VMGROUP_ROLLBACK_FAILED = "RollbackFailed" // the VM could not be deleted in the rollback and still exists
+)
+
+// VMGroupResultInfo represents the creation result of a VM in a VM Group.
+type VMGroupResultInfo struct {
+	Name     string       `json:"Name" validate:"required" example:"worker-1"`
+	Status   string       `json:"Status" validate:"required" example:"Succeeded"` // Succeeded | Failed | RolledBack | RollbackFailed
+	ErrorMSG string       `json:"ErrorMSG,omitempty" validate:"omitempty"`
+	VMInfo   *cres.VMInfo `json:"VMInfo,omitempty" validate:"omitempty"`
+}
+
+// GetVMGroupNames returns the VM names of a name pattern, ex) "worker-{index}" => worker-1, worker-2, ...
+// If the pattern has no {index}, "-{index}" is appended to the pattern.
+func GetVMGroupNames(namePattern string, count int) ([]string, error) {
+	namePattern = strings.TrimSpace(namePattern)
+	if namePattern == "" {
+		return nil, fmt.Errorf("The name pattern of the VM Group is empty!")
+	}
+	if count < 1 || count > MAX_VMGROUP_COUNT {
+		return nil, fmt.Errorf("The count of the VM Group should be 1 ~ %d, but %d!", MAX_VMGROUP_COUNT, count)
+	}
+	if !strings.Contains(namePattern, VMGROUP_INDEX_PLACEHOLDER) {
+		namePattern += "-" + VMGROUP_INDEX_PLACEHOLDER
+	}
+
+	nameList := make([]string, count)
+	for i := 0; i < count; i++ {
+		nameList[i] = strings.ReplaceAll(namePattern, VMGROUP_INDEX_PLACEHOLDER, strconv.Itoa(i+1))
+	}
+	return nameList, nil
+}
+
+// (1) make the VM names from the name pattern and check exist(NameID)
+// (2) validate the VM template once before starting the VMs
+// (3) start VMs with bounded concurrency
+// (4) rollback the started VMs on a partial failure, if rollbackOnFailure
+func StartVMGroup(connectionName string, rsType string, templateInfo cres.VMReqInfo, namePattern string, count int,
+	maxConcurrency int, rollbackOnFailure bool, IDTransformMode string) ([]*VMGroupResultInfo, error) {
+	cblog.Info("call StartVMGroup()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if maxConcurrency == 0 {
+		maxConcurrency = DEFAULT_VMGROUP_CONCURRENCY
+	}
+	if maxConcurrency < 1 || maxConcurrency > MAX_VMGROUP_CONCURRENCY {
+		err := fmt.Errorf("The max concurrency of the VM Group should be 1 ~ %d, but %d!", MAX_VMGROUP_CONCURRENCY, maxConcurrency)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if len(templateInfo.DataDiskIIDs) > 0 {
+		err := fmt.Errorf("DataDisks can not be attached to all VMs of a VM Group!")
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (1) make the VM names from the name pattern and check exist(NameID)
+	nameList, err := GetVMGroupNames(namePattern, count)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	for _, name := range nameList {
+		bool_ret, err := infostore.HasByConditions(&VMIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, name)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		if bool_ret {
+			err := fmt.Errorf(rsType + "-" + name + " already exists!")
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+
+	// (2) validate the VM template once before starting the VMs
+	//     the last name is used for the ID length check, because it is the longest one.
+	templateReqInfo := cloneVMGroupReqInfo(templateInfo)
+	templateReqInfo.IId = cres.IID{NameId: nameList[len(nameList)-1]}
+	report, err := DryRunStartVM(connectionName, rsType, templateReqInfo, IDTransformMode)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if !report.Valid {
+		err := fmt.Errorf("The VM template of the VM Group is not valid: %s", report.failedChecks())
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (3) start VMs with bounded concurrency
+	resultList := make([]*VMGroupResultInfo, len(nameList))
+	semaphore := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	for i, name := range nameList {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(idx int, name string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			reqInfo := cloneVMGroupReqInfo(templateInfo)
+			reqInfo.IId = cres.IID{NameId: name}
+
+			result := VMGroupResultInfo{Name: name, Status: VMGROUP_SUCCEEDED}
+			vmInfo, err := StartVM(connectionName, rsType, reqInfo, IDTransformMode)
+			if err != nil {
+				result.Status = VMGROUP_FAILED
+				result.ErrorMSG = err.Error()
+			} else {
+				result.VMInfo = vmInfo
+			}
+			resultList[idx] = &result
+		}(i, name)
+	}
+	wg.Wait()
+
+	failed := false
+	for _, result := range resultList {
+		if result.Status == VMGROUP_FAILED {
+			failed = true
+			break
+		}
+	}
+	if !failed || !rollbackOnFailure {
+		return resultList, nil
+	}
+
+	// (4) rollback the started VMs on a partial failure
+	for _, result := range resultList {
+		if result.Status != VMGROUP_SUCCEEDED {
+			continue
+		}
+		_, _, err := DeleteVM(connectionName, rsType, result.Name, "false")
+		if err != nil {
+			cblog.Error(err)
+			result.Status = VMGROUP_ROLLBACK_FAILED
+			result.ErrorMSG = "failed to rollback: " + err.Error()
+			continue
+		}
+		result.Status = VMGROUP_ROLLED_BACK
+		result.VMInfo = nil
+	}
+
+	return resultList, nil
+}
+
+// each goroutine of StartVMGroup() has its own copy of the template slices
+func cloneVMGroupReqInfo(templateInfo cres.VMReqInfo) cres.VMReqInfo {
+	reqInfo := templateInfo
+	reqInfo.SecurityGroupIIDs = append([]cres.IID{}, templateInfo.SecurityGroupIIDs...)
+	reqInfo.TagList = append([]cres.KeyValue{}, templateInfo.TagList...)
+	return reqInfo
+}
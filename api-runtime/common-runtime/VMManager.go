@@ -9,6 +9,7 @@
 package commonruntime
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"os"
@@ -345,6 +346,24 @@ func RegisterVM(connectionName string, userIID cres.IID) (*cres.VMInfo, error) {
 	return &getInfo, nil
 }
 
+// fields of VMReqInfo which can be empty, used by StartVM() and DryRunStartVM()
+var vmReqEmptyPermissionList = []string{
+	"resources.IID:SystemId",
+	"resources.VMReqInfo:RootDiskType", // because can be set without disk type
+	"resources.VMReqInfo:RootDiskSize", // because can be set without disk size
+	// "resources.VMReqInfo:KeyPairName",  // because can be set without KeyPair for Windows
+	//	"resources.IID:NameId",
+	"resources.VMReqInfo:VMUserId",            // because can be set without VM User
+	"resources.VMReqInfo:VMUserPasswd",        // because can be set without VM PW
+	"resources.VMReqInfo:UserData",            // because can be set without UserData
+	"resources.PurchaseOptionInfo:MaxPrice",   // because can be set without MaxPrice
+	"resources.ReadinessProbeInfo:Port",       // because can be set without ReadinessProbe
+	"resources.ReadinessProbeInfo:Path",       // because can be set without ReadinessProbe
+	"resources.ReadinessProbeInfo:Command",    // because can be set without ReadinessProbe
+	"resources.ReadinessProbeInfo:PrivateKey", // because can be set without ReadinessProbe
+	"resources.ReadinessProbeInfo:Timeout",    // because can be set without ReadinessProbe
+}
+
 // (1) check exist(NameID)
 // (2) generate SP-XID and create reqIID, driverIID
 // (3) clone the reqInfo with DriverIID
@@ -362,17 +381,7 @@ func StartVM(connectionName string, rsType string, reqInfo cres.VMReqInfo, IDTra
 		return nil, err
 	}
 
-	emptyPermissionList := []string{
-		"resources.IID:SystemId",
-		"resources.VMReqInfo:RootDiskType", // because can be set without disk type
-		"resources.VMReqInfo:RootDiskSize", // because can be set without disk size
-		// "resources.VMReqInfo:KeyPairName",  // because can be set without KeyPair for Windows
-		//	"resources.IID:NameId",
-		"resources.VMReqInfo:VMUserId",     // because can be set without VM User
-		"resources.VMReqInfo:VMUserPasswd", // because can be set without VM PW
-	}
-
-	err = ValidateStruct(reqInfo, emptyPermissionList)
+	err = ValidateStruct(reqInfo, vmReqEmptyPermissionList)
 	if err != nil {
 		cblog.Error(err)
 		return nil, err
@@ -443,6 +452,24 @@ func StartVM(connectionName string, rsType string, reqInfo cres.VMReqInfo, IDTra
 		return nil, err
 	}
 
+	err = validateUserData(connectionName, providerName, reqInfo.UserData)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	err = validatePurchaseOption(connectionName, &reqInfo.PurchaseOption)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	err = validateReadinessProbe(&reqInfo.ReadinessProbe)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
 	// (2) clone and translate the reqInfo with DriverIID
 	var reqInfoForDriver cres.VMReqInfo
 	if dockerTest == "ON" {
@@ -530,64 +557,19 @@ func StartVM(connectionName string, rsType string, reqInfo cres.VMReqInfo, IDTra
 		return nil, err
 	}
 
-	// Check Sync Called and Make sure cb-user prepared -----------------
-	// --- <step-1> Get PublicIP of new VM
-	var checkError struct {
-		Flag bool
-		MSG  string
-	}
-
-	waiter := NewWaiter(5, 240) // (sleep, timeout)
-	var publicIP string
-	for {
-		vmInfo, err := handler.GetVM(info.IId)
-		if err != nil {
-			cblog.Error(err)
-			if checkNotFoundError(err) { // VM is not created yet.
-				continue
-			}
-			callInfo.ErrorMSG = err.Error()
-			callogger.Info(call.String(callInfo))
-
-			//handler.TerminateVM(info.IId)
-
-			return nil, err
-		}
-		if vmInfo.PublicIP != "" {
-			publicIP = vmInfo.PublicIP
-			break
-		}
-
-		if !waiter.Wait() {
-			//handler.TerminateVM(info.IId)
-			checkError.Flag = true
-			checkError.MSG = fmt.Sprintf("[%s] Failed to Start VM %s when getting PublicIP. (Timeout=%v)", connectionName, reqIId.NameId, waiter.Timeout)
-			break
-		}
-	}
-
-	if !checkError.Flag && !isWindowsOS && providerName != "MOCK" {
-		// --- <step-2> Check SSHD Daemon of new VM
-		waiter2 := NewWaiter(2, 120) // (sleep, timeout)
-
-		for {
-			if checkSSH(publicIP + ":22") {
-				break
-			}
-
-			if !waiter2.Wait() {
-				//handler.TerminateVM(info.IId)
-				checkError.Flag = true
-				checkError.MSG = fmt.Sprintf("[%s] Failed to Start VM %s when checking SSHD Daemon. (Timeout=%v)", connectionName, reqIId.NameId, waiter2.Timeout)
-				break
-			}
-		}
+	// Check Sync Called and Make sure the VM is ready with the ReadinessProbe -----------------
+	readinessStatus, err := waitVMReadiness(handler, info.IId, reqInfo.ReadinessProbe, isWindowsOS, providerName)
+	if err != nil {
+		cblog.Error(err)
+		callInfo.ErrorMSG = err.Error()
+		callogger.Info(call.String(callInfo))
+		return nil, err
 	}
 
 	callInfo.ElapsedTime = call.Elapsed(start)
 	callogger.Info(call.String(callInfo))
 
-	// End : Check Sync Called and Make sure cb-user prepared -----------------
+	// End : Check Sync Called and Make sure the VM is ready with the ReadinessProbe -----------------
 
 	// (5) create spiderIID: {reqNameID, "driverNameID:driverSystemID"}
 	//     ex) spiderIID {"seoul-service", "vm-01-9m4e2mr0ui3e8a215n4g:i-0bc7123b7e5cbf79d"}
@@ -625,6 +607,11 @@ func StartVM(connectionName string, rsType string, reqInfo cres.VMReqInfo, IDTra
 	/////////////////////////////////
 	setNameId(connectionName, &info, &reqInfo)
 
+	if info.PurchaseOption.Type == "" {
+		info.PurchaseOption = reqInfo.PurchaseOption
+	}
+	info.ReadinessStatus = readinessStatus
+
 	if isWindowsOS {
 		info.VMUserId = reqInfo.VMUserId
 		info.VMUserPasswd = reqInfo.VMUserPasswd
@@ -637,11 +624,10 @@ func StartVM(connectionName string, rsType string, reqInfo cres.VMReqInfo, IDTra
 		}
 	}
 
-	//if checkError.Flag {
-	//	return &info, fmt.Errorf(checkError.MSG)
-	//} else {
+	// register the PublicIP with the DNSName tag
+	autoUpsertDNSRecord(connectionName, reqInfo.TagList, info.PublicIP)
+
 	return &info, nil
-	//}
 }
 
 func checkImageType(reqInfo *cres.VMReqInfo) error {
@@ -706,6 +692,10 @@ func cloneReqInfoWithDriverIID(ConnectionName string, reqInfo cres.VMReqInfo) (c
 		VMUserId:     reqInfo.VMUserId,
 		VMUserPasswd: reqInfo.VMUserPasswd,
 
+		UserData: reqInfo.UserData,
+
+		PurchaseOption: reqInfo.PurchaseOption,
+
 		TagList: reqInfo.TagList,
 	}
 
@@ -889,6 +879,108 @@ func translateRootDiskSetupInfo(providerName string, reqInfo *cres.VMReqInfo) er
 	return nil
 }
 
+// DecodeUserData returns the plain UserData.
+// encoding: "" or "plain"(default) | "base64"
+func DecodeUserData(userData string, encoding string) (string, error) {
+	switch strings.ToLower(encoding) {
+	case "", "plain":
+		return userData, nil
+	case "base64":
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(userData))
+		if err != nil {
+			return "", fmt.Errorf("UserData is not a valid base64 string: %v", err)
+		}
+		return string(decoded), nil
+	default:
+		return "", fmt.Errorf("%s is not a supported UserData Encoding, use plain or base64!", encoding)
+	}
+}
+
+// check the driver capability(USER_DATA) and
+// validate the size of the plain UserData with the userdatamaxsize of cloudos_meta.yaml
+func validateUserData(connectionName string, providerName string, userData string) error {
+	if userData == "" {
+		return nil
+	}
+
+	drv, err := ccm.GetCloudDriver(connectionName)
+	if err != nil {
+		return err
+	}
+	if !drv.GetDriverCapability().USER_DATA {
+		return fmt.Errorf("UserData is not supported by the connection %s!", connectionName)
+	}
+
+	// get Provider's Meta Info
+	cloudOSMetaInfo, err := cim.GetCloudOSMetaInfo(providerName)
+	if err != nil {
+		cblog.Error(err)
+		return err
+	}
+
+	// not defined in cloudos_meta.yaml: bypass
+	if len(cloudOSMetaInfo.UserDataMaxSize) == 0 || cloudOSMetaInfo.UserDataMaxSize[0] == "" {
+		return nil
+	}
+	maxSize, err := strconv.Atoi(cloudOSMetaInfo.UserDataMaxSize[0])
+	if err != nil {
+		cblog.Error(err)
+		return err
+	}
+	if len(userData) > maxSize {
+		return fmt.Errorf("UserData size(%d bytes) exceeds the limit of %s(%d bytes)!", len(userData), providerName, maxSize)
+	}
+	return nil
+}
+
+// normalize the PurchaseOption and check the driver capability(SPOT_VM, PREEMPTIBLE_VM)
+func validatePurchaseOption(connectionName string, option *cres.PurchaseOptionInfo) error {
+	optionType, err := NormalizePurchaseOptionType(string(option.Type))
+	if err != nil {
+		return err
+	}
+	option.Type = optionType
+
+	if option.Type != cres.Spot && option.MaxPrice != "" {
+		return fmt.Errorf("MaxPrice can be set only for the Spot PurchaseOption!")
+	}
+	if option.Type == cres.OnDemand {
+		return nil
+	}
+
+	if option.MaxPrice != "" {
+		maxPrice, err := strconv.ParseFloat(option.MaxPrice, 64)
+		if err != nil || maxPrice <= 0 {
+			return fmt.Errorf("%s is not a valid MaxPrice, it should be a positive number(USD per hour)!", option.MaxPrice)
+		}
+	}
+
+	drv, err := ccm.GetCloudDriver(connectionName)
+	if err != nil {
+		return err
+	}
+	capability := drv.GetDriverCapability()
+	if (option.Type == cres.Spot && !capability.SPOT_VM) || (option.Type == cres.Preemptible && !capability.PREEMPTIBLE_VM) {
+		return fmt.Errorf("The %s PurchaseOption is not supported by the connection %s!", option.Type, connectionName)
+	}
+	return nil
+}
+
+// NormalizePurchaseOptionType normalizes a PurchaseOption case-insensitively.
+// "", "ondemand" => OnDemand, "spot" => Spot, "preemptible" => Preemptible
+func NormalizePurchaseOptionType(optionType string) (cres.PurchaseOptionType, error) {
+	switch strings.ToLower(optionType) {
+	case "", strings.ToLower(string(cres.OnDemand)):
+		return cres.OnDemand, nil
+	case strings.ToLower(string(cres.Spot)):
+		return cres.Spot, nil
+	case strings.ToLower(string(cres.Preemptible)):
+		return cres.Preemptible, nil
+	default:
+		return "", fmt.Errorf("%s is not a valid PurchaseOption, use OnDemand, Spot or Preemptible!", optionType)
+	}
+}
+
 func validateRootDiskType(diskType string, diskTypeList []string) bool {
 	for _, v := range diskTypeList {
 		if diskType == v {
@@ -1415,7 +1507,7 @@ func ListVMStatus(connectionName string, rsType string) ([]*cres.VMStatusInfo, e
 
 			if statusInfo == cres.Creating || statusInfo == cres.Running || statusInfo == cres.Suspending || statusInfo == cres.Suspended ||
 				statusInfo == cres.Resuming || statusInfo == cres.Rebooting || statusInfo == cres.Terminating || statusInfo == cres.Terminated ||
-				statusInfo == cres.NotExist || statusInfo == cres.Failed {
+				statusInfo == cres.NotExist || statusInfo == cres.Preempted || statusInfo == cres.Failed {
 				break
 			}
 
@@ -1493,7 +1585,7 @@ func GetVMStatus(connectionName string, rsType string, nameID string) (cres.VMSt
 
 		if info == cres.Creating || info == cres.Running || info == cres.Suspending || info == cres.Suspended ||
 			info == cres.Resuming || info == cres.Rebooting || info == cres.Terminating || info == cres.Terminated ||
-			info == cres.NotExist || info == cres.Failed {
+			info == cres.NotExist || info == cres.Preempted || info == cres.Failed {
 			return info, nil
 		}
 
@@ -1555,6 +1647,298 @@ func ControlVM(connectionName string, rsType string, nameID string, action strin
 	return info, nil
 }
 
+// (1) check the driver capability(VM_SPEC_CHANGE)
+// (2) get IID(NameId)
+// (3) validate the target VMSpec with the CSP's VMSpec list
+// (4) suspend CSP:VM(SystemId) if the CSP can not change the VMSpec online
+// (5) change CSP:VMSpec and resume CSP:VM
+// (6) set ResourceInfo(IID.NameId)
+func ChangeVMSpec(connectionName string, rsType string, nameID string, vmSpecName string) (*cres.VMInfo, error) {
+	cblog.Info("call ChangeVMSpec()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	vmSpecName, err = EmptyCheckAndTrim("vmSpecName", vmSpecName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (1) check the driver capability(VM_SPEC_CHANGE) before suspending the VM
+	drv, err := ccm.GetCloudDriver(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if !drv.GetDriverCapability().VM_SPEC_CHANGE {
+		err := fmt.Errorf("The connection %s does not support changing the VMSpec!", connectionName)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// hold the lock during suspend, change and resume
+	vmSPLock.Lock(connectionName, nameID)
+	defer vmSPLock.Unlock(connectionName, nameID)
+
+	// (2) get IID(NameId)
+	var iidInfo VMIIDInfo
+	err = infostore.GetByConditions(&iidInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	cldConn, err := ccm.GetZoneLevelCloudConnection(connectionName, iidInfo.ZoneId)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateVMHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (3) validate the target VMSpec with the CSP's VMSpec list
+	specHandler, err := cldConn.CreateVMSpecHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	specList, err := specHandler.ListVMSpec()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	specExist := false
+	for _, spec := range specList {
+		if spec.Name == vmSpecName {
+			specExist = true
+			break
+		}
+	}
+	if !specExist {
+		err := fmt.Errorf("%s VMSpec does not exist in the connection %s!", vmSpecName, connectionName)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	driverIID := getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+
+	curInfo, err := handler.GetVM(driverIID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if curInfo.VMSpecName == vmSpecName {
+		err := fmt.Errorf("The VM %s already has the VMSpec %s!", nameID, vmSpecName)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (4) suspend CSP:VM(SystemId) if the CSP can not change the VMSpec online
+	providerName, err := ccm.GetProviderNameByConnectionName(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	needStop, err := needStopToChangeVMSpec(providerName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	vmStatus, err := handler.GetVMStatus(driverIID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	suspended := false
+	switch vmStatus {
+	case cres.Running:
+		if needStop {
+			if _, err := handler.SuspendVM(driverIID); err != nil {
+				cblog.Error(err)
+				return nil, err
+			}
+			if err := waitVMStatus(handler, driverIID, cres.Suspended); err != nil {
+				cblog.Error(err)
+				return nil, err
+			}
+			suspended = true
+		}
+	case cres.Suspended:
+		// already stopped: keep the status after changing
+	default:
+		err := fmt.Errorf("The VM %s can not change the VMSpec in the %s status!", nameID, vmStatus)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (5) change CSP:VMSpec and resume CSP:VM
+	info, err := handler.ChangeVMSpec(driverIID, vmSpecName)
+	if err != nil {
+		cblog.Error(err)
+		if suspended {
+			// rollback: resume the VM with the original VMSpec
+			if _, err2 := handler.ResumeVM(driverIID); err2 != nil {
+				cblog.Error(err2)
+				return nil, fmt.Errorf("%s, and failed to resume the VM: %s", err.Error(), err2.Error())
+			}
+			if err2 := waitVMStatus(handler, driverIID, cres.Running); err2 != nil {
+				cblog.Error(err2)
+				return nil, fmt.Errorf("%s, and failed to resume the VM: %s", err.Error(), err2.Error())
+			}
+		}
+		return nil, err
+	}
+	invalidateResourceSnapshot(connectionName, VM, nameID)
+
+	if suspended {
+		if _, err := handler.ResumeVM(driverIID); err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		if err := waitVMStatus(handler, driverIID, cres.Running); err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		info, err = handler.GetVM(driverIID)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+
+	// (6) set ResourceInfo(IID.NameId)
+	info.IId = getUserIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+
+	err = getSetNameId(connectionName, &info)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// (1) check the driver capability(CONSOLE_OUTPUT)
+// (2) get IID(NameId)
+// (3) get CSP:ConsoleOutput(SystemId), the last tailLines lines if tailLines > 0
+func GetVMConsoleOutput(connectionName string, rsType string, nameID string, tailLines int) (string, error) {
+	cblog.Info("call GetVMConsoleOutput()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return "", err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return "", err
+	}
+
+	// (1) check the driver capability(CONSOLE_OUTPUT)
+	drv, err := ccm.GetCloudDriver(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return "", err
+	}
+	if !drv.GetDriverCapability().CONSOLE_OUTPUT {
+		err := fmt.Errorf("The connection %s does not support the VM console output!", connectionName)
+		cblog.Error(err)
+		return "", err
+	}
+
+	vmSPLock.RLock(connectionName, nameID)
+	defer vmSPLock.RUnlock(connectionName, nameID)
+
+	// (2) get IID(NameId)
+	var iidInfo VMIIDInfo
+	err = infostore.GetByConditions(&iidInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return "", err
+	}
+
+	cldConn, err := ccm.GetZoneLevelCloudConnection(connectionName, iidInfo.ZoneId)
+	if err != nil {
+		cblog.Error(err)
+		return "", err
+	}
+
+	handler, err := cldConn.CreateVMHandler()
+	if err != nil {
+		cblog.Error(err)
+		return "", err
+	}
+
+	// (3) get CSP:ConsoleOutput(SystemId)
+	output, err := handler.GetConsoleOutput(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}))
+	if err != nil {
+		cblog.Error(err)
+		return "", err
+	}
+
+	if tailLines > 0 {
+		lines := strings.Split(output, "\n")
+		if len(lines) > tailLines {
+			output = strings.Join(lines[len(lines)-tailLines:], "\n")
+		}
+	}
+	return output, nil
+}
+
+// vmspecchange in cloudos_meta.yaml: STOP(default) | ONLINE
+func needStopToChangeVMSpec(providerName string) (bool, error) {
+	cloudOSMetaInfo, err := cim.GetCloudOSMetaInfo(providerName)
+	if err != nil {
+		return false, err
+	}
+
+	if len(cloudOSMetaInfo.VMSpecChange) > 0 && strings.ToUpper(cloudOSMetaInfo.VMSpecChange[0]) == "ONLINE" {
+		return false, nil
+	}
+	return true, nil
+}
+
+func waitVMStatus(handler cres.VMHandler, vmIID cres.IID, targetStatus cres.VMStatus) error {
+	waiter := NewWaiter(5, 600) // (sleep, timeout)
+
+	for {
+		status, err := handler.GetVMStatus(vmIID)
+		if err != nil {
+			return err
+		}
+		if status == targetStatus {
+			return nil
+		}
+		if status == cres.Failed || status == cres.Preempted || status == cres.Terminating || status == cres.Terminated || status == cres.NotExist {
+			return fmt.Errorf("The VM %s is %s while waiting for %s!", vmIID.NameId, status, targetStatus)
+		}
+
+		if !waiter.Wait() {
+			return fmt.Errorf("Failed to wait for the VM %s to be %s. (Timeout=%v)", vmIID.NameId, targetStatus, waiter.Timeout)
+		}
+	}
+}
+
 func DeleteVM(connectionName string, rsType string, nameID string, force string) (bool, cres.VMStatus, error) {
 	cblog.Info("call DeleteVM()")
 
@@ -1598,6 +1982,20 @@ func DeleteVM(connectionName string, rsType string, nameID string, force string)
 	driverIId := getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
 	var vmStatus cres.VMStatus
 
+	// check ALBs using this VM as a target
+	if force != "true" {
+		albName, err := getALBUsingVM(connectionName, driverIId)
+		if err != nil {
+			cblog.Error(err)
+			return false, "", err
+		}
+		if albName != "" {
+			err := fmt.Errorf("The VM '%s' is a target of the ALB '%s', remove it from the Target Group first!", nameID, albName)
+			cblog.Error(err)
+			return false, "", err
+		}
+	}
+
 	providerName, err := ccm.GetProviderNameByConnectionName(connectionName)
 	if err != nil {
 		cblog.Error(err)
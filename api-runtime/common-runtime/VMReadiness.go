@@ -0,0 +1,178 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package commonruntime
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	sshrun "github.com/cloud-barista/cb-spider/cloud-control-manager/vm-ssh"
+)
+
+const DEFAULT_PUBLICIP_PROBE_TIMEOUT = 240 // secs
+const DEFAULT_PORT_PROBE_TIMEOUT = 120     // secs, for TCP, SSH, HTTP
+const MIN_READINESS_PROBE_TIMEOUT = 10     // secs, longer than the sleep of the waiters
+const MAX_READINESS_PROBE_TIMEOUT = 1800   // secs
+const READINESS_PROBE_CONN_TIMEOUT = 3     // secs, timeout of each connection
+
+// validateReadinessProbe normalizes the ReadinessProbe and sets the default Type, Port and Path.
+func validateReadinessProbe(probe *cres.ReadinessProbeInfo) error {
+	probe.Type = cres.ReadinessProbeType(strings.ToUpper(strings.TrimSpace(string(probe.Type))))
+
+	switch probe.Type {
+	case "":
+		probe.Type = cres.ReadinessDefault
+	case cres.ReadinessDefault, cres.ReadinessNone, cres.ReadinessPublicIP:
+	case cres.ReadinessTCP:
+		if probe.Port == "" {
+			return fmt.Errorf("The TCP ReadinessProbe requires a Port!")
+		}
+	case cres.ReadinessSSH:
+		if probe.Port == "" {
+			probe.Port = "22"
+		}
+		if probe.Command == "" || probe.PrivateKey == "" {
+			return fmt.Errorf("The SSH ReadinessProbe requires a Command and a PrivateKey!")
+		}
+	case cres.ReadinessHTTP:
+		if probe.Port == "" {
+			probe.Port = "80"
+		}
+		if probe.Path == "" {
+			probe.Path = "/"
+		}
+		if !strings.HasPrefix(probe.Path, "/") {
+			return fmt.Errorf("The Path of the HTTP ReadinessProbe should start with '/'!")
+		}
+	default:
+		return fmt.Errorf("%s is not a valid ReadinessProbe, use DEFAULT, NONE, PUBLIC_IP, TCP, SSH or HTTP!", probe.Type)
+	}
+
+	if probe.Port != "" {
+		port, err := strconv.Atoi(probe.Port)
+		if err != nil || port < 1 || port > 65535 {
+			return fmt.Errorf("%s is not a valid Port of the ReadinessProbe, it should be 1 ~ 65535!", probe.Port)
+		}
+	}
+	if probe.Timeout != "" {
+		timeout, err := strconv.Atoi(probe.Timeout)
+		if err != nil || timeout < MIN_READINESS_PROBE_TIMEOUT || timeout > MAX_READINESS_PROBE_TIMEOUT {
+			return fmt.Errorf("%s is not a valid Timeout of the ReadinessProbe, it should be %d ~ %d secs!", probe.Timeout, MIN_READINESS_PROBE_TIMEOUT, MAX_READINESS_PROBE_TIMEOUT)
+		}
+	}
+	return nil
+}
+
+// waitVMReadiness waits for the new VM to pass the ReadinessProbe validated by validateReadinessProbe().
+// The DEFAULT probe skips the SSHD check for Windows and the Mock driver.
+// Returns an error only when the VM can not be got from the CSP.
+func waitVMReadiness(handler cres.VMHandler, vmIID cres.IID, probe cres.ReadinessProbeInfo, isWindowsOS bool, providerName string) (cres.ReadinessStatus, error) {
+	if probe.Type == cres.ReadinessNone {
+		return cres.ReadinessSkipped, nil
+	}
+
+	probeTimeout := 0
+	if probe.Timeout != "" {
+		probeTimeout, _ = strconv.Atoi(probe.Timeout)
+	}
+
+	// --- <step-1> Get PublicIP of new VM
+	publicIPTimeout := DEFAULT_PUBLICIP_PROBE_TIMEOUT
+	if probe.Type == cres.ReadinessPublicIP && probeTimeout > 0 {
+		publicIPTimeout = probeTimeout
+	}
+	waiter := NewWaiter(5, publicIPTimeout) // (sleep, timeout)
+	var publicIP string
+	for {
+		vmInfo, err := handler.GetVM(vmIID)
+		if err != nil {
+			cblog.Error(err)
+			if !checkNotFoundError(err) { // VM is not created yet, if not found.
+				return "", err
+			}
+		} else if vmInfo.PublicIP != "" {
+			publicIP = vmInfo.PublicIP
+			break
+		}
+
+		if !waiter.Wait() {
+			cblog.Errorf("Failed to get the PublicIP of the VM %s. (Timeout=%v)", vmIID.NameId, waiter.Timeout)
+			return cres.ReadinessTimeout, nil
+		}
+	}
+
+	// --- <step-2> Check the probe with the PublicIP
+	var check func() bool
+	switch probe.Type {
+	case cres.ReadinessPublicIP:
+		return cres.Ready, nil
+	case cres.ReadinessDefault:
+		if isWindowsOS || providerName == "MOCK" {
+			return cres.Ready, nil
+		}
+		check = func() bool { return checkSSH(publicIP + ":22") }
+	case cres.ReadinessTCP:
+		check = func() bool { return checkTCPPort(publicIP + ":" + probe.Port) }
+	case cres.ReadinessSSH:
+		check = func() bool { return checkSSHCommand(publicIP+":"+probe.Port, probe.PrivateKey, probe.Command) }
+	case cres.ReadinessHTTP:
+		check = func() bool { return checkHTTPGet("http://" + publicIP + ":" + probe.Port + probe.Path) }
+	}
+
+	if probeTimeout == 0 {
+		probeTimeout = DEFAULT_PORT_PROBE_TIMEOUT
+	}
+	waiter2 := NewWaiter(2, probeTimeout) // (sleep, timeout)
+	for {
+		if check() {
+			return cres.Ready, nil
+		}
+
+		if !waiter2.Wait() {
+			cblog.Errorf("Failed to pass the %s ReadinessProbe of the VM %s. (Timeout=%v)", probe.Type, vmIID.NameId, waiter2.Timeout)
+			return cres.ReadinessTimeout, nil
+		}
+	}
+}
+
+func checkTCPPort(serverPort string) bool {
+	conn, err := net.DialTimeout("tcp", serverPort, READINESS_PROBE_CONN_TIMEOUT*time.Second)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+func checkSSHCommand(serverPort string, privateKey string, cmd string) bool {
+	sshInfo := sshrun.SSHInfo{
+		UserName:   "cb-user",
+		PrivateKey: []byte(privateKey),
+		ServerPort: serverPort,
+		Timeout:    READINESS_PROBE_CONN_TIMEOUT,
+	}
+
+	_, err := sshrun.SSHRun(sshInfo, cmd)
+	return err == nil
+}
+
+func checkHTTPGet(url string) bool {
+	client := http.Client{Timeout: READINESS_PROBE_CONN_TIMEOUT * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode >= 200 && resp.StatusCode < 400
+}
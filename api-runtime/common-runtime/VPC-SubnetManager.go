@@ -11,6 +11,7 @@ package commonruntime
 import (
 	"errors"
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 	"sync"
@@ -171,6 +172,25 @@ func RegisterVPC(connectionName string, userIID cres.IID) (*cres.VPCInfo, error)
 		getInfo.SubnetInfoList[count] = subnetInfo
 	} // end of for _, info
 
+	// record the CIDRs for IPAM
+	_, _, err = recordVPCCIDRs(connectionName, userIID.NameId, getInfo.IPv4_CIDR, getInfo.SubnetInfoList, "")
+	if err != nil {
+		cblog.Error(err)
+		// rollback the VPC and Subnet IIDs
+		cblog.Info("<<ROLLBACK:TRY:VPC-IID>> " + userIID.NameId)
+		_, err2 := infostore.DeleteByConditions(&VPCIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, userIID.NameId)
+		if err2 != nil {
+			cblog.Error(err2)
+			return nil, fmt.Errorf(err.Error() + ", " + err2.Error())
+		}
+		_, err3 := infostore.DeleteByConditions(&SubnetIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, OWNER_VPC_NAME_COLUMN, userIID.NameId)
+		if err3 != nil {
+			cblog.Error(err3)
+			return nil, fmt.Errorf(err.Error() + ", " + err3.Error())
+		}
+		return nil, err
+	}
+
 	// set up VPC User IID for return info
 	getInfo.IId = userIID
 
@@ -335,6 +355,7 @@ func UnregisterSubnet(connectionName string, vpcName string, nameId string) (boo
 		cblog.Error(err)
 		return false, err
 	}
+	deleteSubnetCIDR(connectionName, vpcName, nameId)
 	return true, nil
 }
 
@@ -350,6 +371,18 @@ type SubnetReqZoneInfo struct {
 	Zone string
 }
 
+// fields of VPCReqInfo which can be empty, used by CreateVPC() and DryRunCreateVPC()
+var vpcReqEmptyPermissionList = []string{
+	"resources.IID:SystemId",
+	"resources.VPCReqInfo:IPv4_CIDR", // because can be unused in some VPC
+	"resources.VPCReqInfo:IPv6_CIDR", // because IPv4 only VPC
+	"resources.SubnetInfo:IPv4_CIDR", // because allocated by IPAM
+	"resources.SubnetInfo:IPv6_CIDR", // because IPv4 only Subnet
+	"resources.SubnetInfo:Zone",      // because can be unused in some Zone
+	"resources.KeyValue:Key",         // because unusing key-value list
+	"resources.KeyValue:Value",       // because unusing key-value list
+}
+
 func CreateVPC(connectionName string, rsType string, reqInfo cres.VPCReqInfo, IDTransformMode string) (*cres.VPCInfo, error) {
 	cblog.Info("call CreateVPC()")
 
@@ -360,15 +393,13 @@ func CreateVPC(connectionName string, rsType string, reqInfo cres.VPCReqInfo, ID
 		return nil, err
 	}
 
-	emptyPermissionList := []string{
-		"resources.IID:SystemId",
-		"resources.VPCReqInfo:IPv4_CIDR", // because can be unused in some VPC
-		"resources.SubnetInfo:Zone",      // because can be unused in some Zone
-		"resources.KeyValue:Key",         // because unusing key-value list
-		"resources.KeyValue:Value",       // because unusing key-value list
+	err = ValidateStruct(reqInfo, vpcReqEmptyPermissionList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
 	}
 
-	err = ValidateStruct(reqInfo, emptyPermissionList)
+	err = validateIPv6CIDRs(connectionName, reqInfo.IPv6_CIDR, reqInfo.SubnetInfoList)
 	if err != nil {
 		cblog.Error(err)
 		return nil, err
@@ -421,6 +452,21 @@ func CreateVPC(connectionName string, rsType string, reqInfo cres.VPCReqInfo, ID
 		}
 	}
 
+	// allocate the omitted CIDRs, check the overlap with the other VPCs and reserve the CIDRs
+	err = reserveVPCCIDRs(connectionName, &reqInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	// rollback the reserved CIDRs, if the VPC is not created
+	vpcName := reqInfo.IId.NameId
+	vpcCreated := false
+	defer func() {
+		if !vpcCreated {
+			deleteVPCCIDRs(connectionName, vpcName)
+		}
+	}()
+
 	spUUID := ""
 	if GetID_MGMT(IDTransformMode) == "ON" { // Use IID Management
 		// (2) generate SP-XID and create reqIID, driverIID
@@ -576,9 +622,85 @@ func CreateVPC(connectionName string, rsType string, reqInfo cres.VPCReqInfo, ID
 	}
 	info.SubnetInfoList = subnetUserInfoList
 
+	vpcCreated = true
 	return &info, nil
 }
 
+// check the IPv6 CIDRs of a dual-stack VPC and its subnets
+// (1) check the driver capability(IPV6)
+// (2) check the VPC IPv6 CIDR
+// (3) check the subnet IPv6 CIDRs are in the VPC IPv6 CIDR and do not overlap each other
+func validateIPv6CIDRs(connectionName string, vpcIPv6CIDR string, subnetInfoList []cres.SubnetInfo) error {
+	hasIPv6 := vpcIPv6CIDR != ""
+	for _, subnetInfo := range subnetInfoList {
+		if subnetInfo.IPv6_CIDR != "" {
+			hasIPv6 = true
+		}
+	}
+	if !hasIPv6 {
+		return nil
+	}
+
+	// (1) check the driver capability(IPV6)
+	err := checkIPv6Capability(connectionName)
+	if err != nil {
+		return err
+	}
+
+	// (2) check the VPC IPv6 CIDR
+	if vpcIPv6CIDR == "" {
+		return fmt.Errorf("An IPv6 CIDR of a subnet needs the IPv6 CIDR of the VPC!")
+	}
+	vpcNet, err := parseIPv6CIDR(vpcIPv6CIDR)
+	if err != nil {
+		return err
+	}
+	vpcPrefix, _ := vpcNet.Mask.Size()
+
+	// (3) check the subnet IPv6 CIDRs are in the VPC IPv6 CIDR and do not overlap each other
+	subnetNetList := []*net.IPNet{}
+	for _, subnetInfo := range subnetInfoList {
+		if subnetInfo.IPv6_CIDR == "" {
+			continue
+		}
+		subnetNet, err := parseIPv6CIDR(subnetInfo.IPv6_CIDR)
+		if err != nil {
+			return err
+		}
+		subnetPrefix, _ := subnetNet.Mask.Size()
+		if subnetPrefix < vpcPrefix || !vpcNet.Contains(subnetNet.IP) {
+			return fmt.Errorf("The IPv6 CIDR %s of the subnet %s is not in the IPv6 CIDR %s of the VPC!", subnetInfo.IPv6_CIDR, subnetInfo.IId.NameId, vpcIPv6CIDR)
+		}
+		for _, otherNet := range subnetNetList {
+			if otherNet.Contains(subnetNet.IP) || subnetNet.Contains(otherNet.IP) {
+				return fmt.Errorf("The IPv6 CIDR %s of the subnet %s overlaps %s!", subnetInfo.IPv6_CIDR, subnetInfo.IId.NameId, otherNet.String())
+			}
+		}
+		subnetNetList = append(subnetNetList, subnetNet)
+	}
+	return nil
+}
+
+func checkIPv6Capability(connectionName string) error {
+	drv, err := ccm.GetCloudDriver(connectionName)
+	if err != nil {
+		return err
+	}
+	if !drv.GetDriverCapability().IPV6 {
+		return fmt.Errorf("The connection %s does not support IPv6(dual-stack)!", connectionName)
+	}
+	return nil
+}
+
+// returns the network of an IPv6 CIDR, an IPv4 CIDR is an error
+func parseIPv6CIDR(cidr string) (*net.IPNet, error) {
+	ip, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+	if err != nil || ip.To4() != nil {
+		return nil, fmt.Errorf("%s is not a valid IPv6 CIDR!", cidr)
+	}
+	return ipNet, nil
+}
+
 // Get reqNameId from reqIIdZoneList whith driver NameId
 func getSubnetReqNameId(reqIIdZoneList []SubnetReqZoneInfo, driverNameId string) string {
 	for _, reqInfo := range reqIIdZoneList {
@@ -867,6 +989,36 @@ func AddSubnet(connectionName string, rsType string, vpcName string, reqInfo cre
 		return nil, err
 	}
 
+	vpcInfo, err := handler.GetVPC(getDriverIID(cres.IID{NameId: iidVPCInfo.NameId, SystemId: iidVPCInfo.SystemId}))
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// check the subnet IPv6 CIDR with the VPC IPv6 CIDR and the other subnets
+	if reqInfo.IPv6_CIDR != "" {
+		err = validateIPv6CIDRs(connectionName, vpcInfo.IPv6_CIDR, append(vpcInfo.SubnetInfoList, reqInfo))
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	}
+
+	// allocate the omitted CIDR and reserve it, the subnets of a VPC without CIDR are checked with the other VPCs
+	err = reserveSubnetCIDR(connectionName, vpcName, vpcInfo, &reqInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	// rollback the reserved CIDR, if the Subnet is not added
+	subnetName := reqInfo.IId.NameId
+	subnetAdded := false
+	defer func() {
+		if !subnetAdded {
+			deleteSubnetCIDR(connectionName, vpcName, subnetName)
+		}
+	}()
+
 	subnetUUID := ""
 	if GetID_MGMT(IDTransformMode) == "ON" { // Use IID Management
 		subnetUUID, err = iidm.New(connectionName, rsType, reqInfo.IId.NameId)
@@ -900,6 +1052,7 @@ func AddSubnet(connectionName string, rsType string, vpcName string, reqInfo cre
 		cblog.Error(err)
 		return nil, err
 	}
+	invalidateResourceSnapshot(connectionName, VPC, vpcName)
 
 	// (3) insert IID
 	// for Subnet list
@@ -931,6 +1084,7 @@ func AddSubnet(connectionName string, rsType string, vpcName string, reqInfo cre
 			}
 		}
 	}
+	subnetAdded = true
 
 	// (3) set ResourceInfo(userIID)
 	info.IId = getUserIID(cres.IID{NameId: iidVPCInfo.NameId, SystemId: iidVPCInfo.SystemId})
@@ -1055,6 +1209,157 @@ func GetSubnet(connectionName string, vpcName string, nameID string) (*cres.Subn
 	return nil, fmt.Errorf("Subnet with nameID %s not found in VPC %s", nameID, vpcName)
 }
 
+// get the VPC and Subnet IIDInfo and the VPC Handler of the Subnet's zone
+func getSubnetOwnerInfos(connectionName string, vpcName string, nameID string) (VPCIIDInfo, SubnetIIDInfo, cres.VPCHandler, error) {
+	var vpcIIDInfo VPCIIDInfo
+	err := infostore.GetByConditions(&vpcIIDInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, vpcName)
+	if err != nil {
+		return VPCIIDInfo{}, SubnetIIDInfo{}, nil, err
+	}
+
+	var subnetIIDInfo SubnetIIDInfo
+	err = infostore.GetBy3Conditions(&subnetIIDInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID, OWNER_VPC_NAME_COLUMN, vpcName)
+	if err != nil {
+		return VPCIIDInfo{}, SubnetIIDInfo{}, nil, err
+	}
+
+	cldConn, err := ccm.GetZoneLevelCloudConnection(connectionName, subnetIIDInfo.ZoneId)
+	if err != nil {
+		return VPCIIDInfo{}, SubnetIIDInfo{}, nil, err
+	}
+
+	handler, err := cldConn.CreateVPCHandler()
+	if err != nil {
+		return VPCIIDInfo{}, SubnetIIDInfo{}, nil, err
+	}
+	return vpcIIDInfo, subnetIIDInfo, handler, nil
+}
+
+// (1) get spiderIIDs of the VPC and the Subnet
+// (2) get the IP usage of the Subnet(SystemId)
+// (3) set ResourceInfo(IID.NameId)
+func GetSubnetUsage(connectionName string, vpcName string, nameID string) (*cres.SubnetUsageInfo, error) {
+	cblog.Info("call GetSubnetUsage()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	vpcName, err = EmptyCheckAndTrim("vpcName", vpcName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	vpcSPLock.RLock(connectionName, vpcName)
+	defer vpcSPLock.RUnlock(connectionName, vpcName)
+
+	// (1) get spiderIIDs of the VPC and the Subnet
+	vpcIIDInfo, subnetIIDInfo, handler, err := getSubnetOwnerInfos(connectionName, vpcName, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) get the IP usage of the Subnet(SystemId)
+	info, err := handler.GetSubnetUsage(getDriverIID(cres.IID{NameId: vpcIIDInfo.NameId, SystemId: vpcIIDInfo.SystemId}),
+		getDriverIID(cres.IID{NameId: subnetIIDInfo.NameId, SystemId: subnetIIDInfo.SystemId}))
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (3) set ResourceInfo(IID.NameId)
+	info.SubnetIID = getUserIID(cres.IID{NameId: subnetIIDInfo.NameId, SystemId: subnetIIDInfo.SystemId})
+	if info.Zone == "" {
+		info.Zone = subnetIIDInfo.ZoneId
+	}
+
+	return &info, nil
+}
+
+// Route Tables are not registered in Spider, so the RouteTableIID is a CSP ID.
+// (1) get spiderIIDs of the VPC and the Subnet
+// (2) update the Subnet(SystemId)
+// (3) set ResourceInfo(IID.NameId)
+func UpdateSubnet(connectionName string, vpcName string, nameID string, updateInfo cres.SubnetUpdateInfo) (*cres.SubnetInfo, error) {
+	cblog.Info("call UpdateSubnet()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	vpcName, err = EmptyCheckAndTrim("vpcName", vpcName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if updateInfo.AutoAssignPublicIP == nil && updateInfo.RouteTableIID == nil {
+		err := fmt.Errorf("There is nothing to update in the Subnet '%s'!", nameID)
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if updateInfo.RouteTableIID != nil {
+		routeTableId := strings.TrimSpace(updateInfo.RouteTableIID.SystemId)
+		if routeTableId == "" {
+			routeTableId = strings.TrimSpace(updateInfo.RouteTableIID.NameId)
+		}
+		if routeTableId == "" {
+			err := fmt.Errorf("The RouteTableIID is empty!")
+			cblog.Error(err)
+			return nil, err
+		}
+		updateInfo.RouteTableIID = &cres.IID{NameId: routeTableId, SystemId: routeTableId}
+	}
+
+	vpcSPLock.Lock(connectionName, vpcName)
+	defer vpcSPLock.Unlock(connectionName, vpcName)
+
+	// (1) get spiderIIDs of the VPC and the Subnet
+	vpcIIDInfo, subnetIIDInfo, handler, err := getSubnetOwnerInfos(connectionName, vpcName, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) update the Subnet(SystemId)
+	info, err := handler.UpdateSubnet(getDriverIID(cres.IID{NameId: vpcIIDInfo.NameId, SystemId: vpcIIDInfo.SystemId}),
+		getDriverIID(cres.IID{NameId: subnetIIDInfo.NameId, SystemId: subnetIIDInfo.SystemId}), updateInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	invalidateResourceSnapshot(connectionName, VPC, vpcName)
+
+	// (3) set ResourceInfo(IID.NameId)
+	info.IId = getUserIID(cres.IID{NameId: subnetIIDInfo.NameId, SystemId: subnetIIDInfo.SystemId})
+	if info.Zone == "" {
+		info.Zone = subnetIIDInfo.ZoneId
+	}
+
+	return &info, nil
+}
+
 // (1) get spiderIID
 // (2) delete Resource(SystemId)
 // (3) delete IID
@@ -1120,6 +1425,7 @@ func RemoveSubnet(connectionName string, vpcName string, nameID string, force st
 			return false, err
 		}
 	}
+	invalidateResourceSnapshot(connectionName, VPC, vpcName)
 
 	if force != "true" {
 		if !result {
@@ -1136,6 +1442,7 @@ func RemoveSubnet(connectionName string, vpcName string, nameID string, force st
 			return false, err
 		}
 	}
+	deleteSubnetCIDR(connectionName, vpcName, nameID)
 
 	return result, nil
 }
@@ -1191,6 +1498,7 @@ func RemoveCSPSubnet(connectionName string, vpcName string, systemID string) (bo
 		cblog.Error(err)
 		return false, err
 	}
+	invalidateResourceSnapshot(connectionName, VPC, vpcName)
 
 	return result, nil
 }
@@ -1237,6 +1545,53 @@ func DeleteVPC(connectionName string, rsType string, nameID string, force string
 		return false, err
 	}
 
+	// check VPC Peerings, NAT Gateways, ALBs and Multi-Cloud VPNs using this VPC
+	if force != "true" {
+		inUse, err := checkVPCPeeringUsingVPC(connectionName, nameID)
+		if err != nil {
+			cblog.Error(err)
+			return false, err
+		}
+		if inUse {
+			err := fmt.Errorf("The VPC '%s' is used by VPC Peering, delete the Peering first!", nameID)
+			cblog.Error(err)
+			return false, err
+		}
+
+		inUse, err = infostore.HasByConditions(&NATGatewayIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, OWNER_VPC_NAME_COLUMN, nameID)
+		if err != nil {
+			cblog.Error(err)
+			return false, err
+		}
+		if inUse {
+			err := fmt.Errorf("The VPC '%s' is used by NAT Gateway, delete the NAT Gateway first!", nameID)
+			cblog.Error(err)
+			return false, err
+		}
+
+		inUse, err = checkALBUsingVPC(connectionName, nameID)
+		if err != nil {
+			cblog.Error(err)
+			return false, err
+		}
+		if inUse {
+			err := fmt.Errorf("The VPC '%s' is used by ALB, delete the ALB first!", nameID)
+			cblog.Error(err)
+			return false, err
+		}
+
+		inUse, err = checkMultiCloudVPNUsingVPC(connectionName, nameID)
+		if err != nil {
+			cblog.Error(err)
+			return false, err
+		}
+		if inUse {
+			err := fmt.Errorf("The VPC '%s' is used by Multi-Cloud VPN, delete the VPN first!", nameID)
+			cblog.Error(err)
+			return false, err
+		}
+	}
+
 	// (2) delete Resource(SystemId)
 	driverIId := getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
 	result := false
@@ -1270,6 +1625,7 @@ func DeleteVPC(connectionName string, rsType string, nameID string, force string
 			return false, err
 		}
 	}
+	deleteVPCCIDRs(connectionName, iidInfo.NameId)
 	return result, nil
 }
 
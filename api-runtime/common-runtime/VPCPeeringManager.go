@@ -0,0 +1,535 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package commonruntime
+
+import (
+	"fmt"
+	"strings"
+
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	iidm "github.com/cloud-barista/cb-spider/cloud-control-manager/iid-manager"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+)
+
+// ====================================================================
+// type for GORM
+
+// A VPC Peering is owned by the Requester's connection and VPC.
+// The Accepter can be in another connection of the same provider.
+type VPCPeeringIIDInfo struct {
+	ConnectionName     string `gorm:"primaryKey"` // ex) "aws-seoul-config", Requester's connection
+	NameId             string `gorm:"primaryKey"` // ex) "my_peering"
+	SystemId           string // ID in CSP, ex) "pcx-0bc7123b7e5cbf79d"
+	OwnerVPCName       string // ex) "app-vpc", Requester's VPC
+	PeerConnectionName string // ex) "aws-tokyo-config", Accepter's connection
+	PeerVPCName        string // ex) "shared-vpc", Accepter's VPC
+}
+
+const PEER_CONNECTION_NAME_COLUMN = "peer_connection_name"
+const PEER_VPC_NAME_COLUMN = "peer_vpc_name"
+
+func (VPCPeeringIIDInfo) TableName() string {
+	return "vpc_peering_iid_infos"
+}
+
+//====================================================================
+
+func init() {
+	db, err := infostore.Open()
+	if err != nil {
+		cblog.Error(err)
+		return
+	}
+	db.AutoMigrate(&VPCPeeringIIDInfo{})
+	infostore.Close(db)
+}
+
+//================ VPC Peering Handler
+
+// (1) check both VPCs are registered in Spider
+// (2) check exist(NameID)
+// (3) request Peering
+// (4) insert spiderIID
+func RequestVPCPeering(connectionName string, rsType string, reqInfo cres.VPCPeeringReqInfo, peerConnectionName string, IDTransformMode string) (*cres.VPCPeeringInfo, error) {
+	cblog.Info("call RequestVPCPeering()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// Accepter is in the same connection if not specified
+	peerConnectionName = strings.TrimSpace(peerConnectionName)
+	if peerConnectionName == "" {
+		peerConnectionName = connectionName
+	}
+
+	emptyPermissionList := []string{
+		"resources.IID:SystemId",
+		"resources.VPCPeeringReqInfo:AccepterRegion",
+	}
+
+	err = ValidateStruct(reqInfo, emptyPermissionList)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// Peering is supported only between the connections of the same provider
+	providerName, err := ccm.GetProviderNameByConnectionName(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	if peerConnectionName != connectionName {
+		peerProviderName, err := ccm.GetProviderNameByConnectionName(peerConnectionName)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		if providerName != peerProviderName {
+			err := fmt.Errorf("VPC Peering is not supported between different providers(%s, %s)!", providerName, peerProviderName)
+			cblog.Error(err)
+			return nil, err
+		}
+
+		regionName, _, err := ccm.GetRegionNameByConnectionName(connectionName)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		peerRegionName, _, err := ccm.GetRegionNameByConnectionName(peerConnectionName)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		if regionName != peerRegionName {
+			reqInfo.AccepterRegion = peerRegionName
+		}
+	}
+
+	vpcSPLock.RLock(connectionName, reqInfo.RequesterVPCIID.NameId)
+	defer vpcSPLock.RUnlock(connectionName, reqInfo.RequesterVPCIID.NameId)
+	if !(peerConnectionName == connectionName && reqInfo.AccepterVPCIID.NameId == reqInfo.RequesterVPCIID.NameId) {
+		vpcSPLock.RLock(peerConnectionName, reqInfo.AccepterVPCIID.NameId)
+		defer vpcSPLock.RUnlock(peerConnectionName, reqInfo.AccepterVPCIID.NameId)
+	}
+
+	// (1) check both VPCs are registered in Spider
+	var requesterVPCIIDInfo VPCIIDInfo
+	err = infostore.GetByConditions(&requesterVPCIIDInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, reqInfo.RequesterVPCIID.NameId)
+	if err != nil {
+		err = fmt.Errorf("The Requester VPC '%s' is not registered in the connection '%s': %s", reqInfo.RequesterVPCIID.NameId, connectionName, err.Error())
+		cblog.Error(err)
+		return nil, err
+	}
+	var accepterVPCIIDInfo VPCIIDInfo
+	err = infostore.GetByConditions(&accepterVPCIIDInfo, CONNECTION_NAME_COLUMN, peerConnectionName, NAME_ID_COLUMN, reqInfo.AccepterVPCIID.NameId)
+	if err != nil {
+		err = fmt.Errorf("The Accepter VPC '%s' is not registered in the connection '%s': %s", reqInfo.AccepterVPCIID.NameId, peerConnectionName, err.Error())
+		cblog.Error(err)
+		return nil, err
+	}
+	if requesterVPCIIDInfo.SystemId == accepterVPCIIDInfo.SystemId {
+		err := fmt.Errorf("The Requester VPC and the Accepter VPC must be different!")
+		cblog.Error(err)
+		return nil, err
+	}
+	reqInfo.RequesterVPCIID = getDriverIID(cres.IID{NameId: requesterVPCIIDInfo.NameId, SystemId: requesterVPCIIDInfo.SystemId})
+	reqInfo.AccepterVPCIID = getDriverIID(cres.IID{NameId: accepterVPCIIDInfo.NameId, SystemId: accepterVPCIIDInfo.SystemId})
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateVPCPeeringHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	vpcPeeringSPLock.Lock(connectionName, reqInfo.IId.NameId)
+	defer vpcPeeringSPLock.Unlock(connectionName, reqInfo.IId.NameId)
+
+	// (2) check exist(NameID)
+	bool_ret, err := infostore.HasByConditions(&VPCPeeringIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, reqInfo.IId.NameId)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	if bool_ret {
+		err := fmt.Errorf(rsType + "-" + reqInfo.IId.NameId + " already exists!")
+		cblog.Error(err)
+		return nil, err
+	}
+
+	spUUID := ""
+	if GetID_MGMT(IDTransformMode) == "ON" { // Use IID Management
+		spUUID, err = iidm.New(connectionName, rsType, reqInfo.IId.NameId)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+	} else { // No Use IID Management
+		spUUID = reqInfo.IId.NameId
+	}
+
+	// reqIID
+	reqIId := cres.IID{NameId: reqInfo.IId.NameId, SystemId: spUUID}
+	// driverIID
+	driverIId := cres.IID{NameId: spUUID, SystemId: ""}
+	reqInfo.IId = driverIId
+
+	// (3) request Peering
+	info, err := handler.RequestVPCPeering(reqInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// create spiderIID: {reqNameID, "driverNameID:driverSystemID"}
+	spiderIId := cres.IID{NameId: reqIId.NameId, SystemId: spUUID + ":" + info.IId.SystemId}
+
+	// (4) insert spiderIID
+	iidInfo := VPCPeeringIIDInfo{ConnectionName: connectionName, NameId: spiderIId.NameId, SystemId: spiderIId.SystemId,
+		OwnerVPCName: requesterVPCIIDInfo.NameId, PeerConnectionName: peerConnectionName, PeerVPCName: accepterVPCIIDInfo.NameId}
+	err = infostore.Insert(&iidInfo)
+	if err != nil {
+		cblog.Error(err)
+		// rollback
+		cblog.Info("<<ROLLBACK:TRY:VPCPEERING-CSP>> " + info.IId.SystemId)
+		_, err2 := handler.DeleteVPCPeering(info.IId)
+		if err2 != nil {
+			cblog.Error(err2)
+			return nil, fmt.Errorf(err.Error() + ", " + err2.Error())
+		}
+		return nil, err
+	}
+
+	// create userIID: {reqNameID, driverSystemID}
+	setVPCPeeringUserIIDs(&iidInfo, requesterVPCIIDInfo, accepterVPCIIDInfo, &info)
+
+	return &info, nil
+}
+
+// set UserIIDs of Peering, Requester VPC and Accepter VPC
+func setVPCPeeringUserIIDs(iidInfo *VPCPeeringIIDInfo, requesterVPCIIDInfo VPCIIDInfo, accepterVPCIIDInfo VPCIIDInfo, info *cres.VPCPeeringInfo) {
+	info.IId = getUserIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+	info.RequesterVPCIID = getUserIID(cres.IID{NameId: requesterVPCIIDInfo.NameId, SystemId: requesterVPCIIDInfo.SystemId})
+	info.AccepterVPCIID = getUserIID(cres.IID{NameId: accepterVPCIIDInfo.NameId, SystemId: accepterVPCIIDInfo.SystemId})
+}
+
+// get Requester and Accepter VPC IIDInfo of a Peering
+func getVPCPeeringVPCIIDInfos(iidInfo *VPCPeeringIIDInfo) (VPCIIDInfo, VPCIIDInfo, error) {
+	var requesterVPCIIDInfo VPCIIDInfo
+	err := infostore.GetByConditions(&requesterVPCIIDInfo, CONNECTION_NAME_COLUMN, iidInfo.ConnectionName, NAME_ID_COLUMN, iidInfo.OwnerVPCName)
+	if err != nil {
+		return VPCIIDInfo{}, VPCIIDInfo{}, err
+	}
+	var accepterVPCIIDInfo VPCIIDInfo
+	err = infostore.GetByConditions(&accepterVPCIIDInfo, CONNECTION_NAME_COLUMN, iidInfo.PeerConnectionName, NAME_ID_COLUMN, iidInfo.PeerVPCName)
+	if err != nil {
+		return VPCIIDInfo{}, VPCIIDInfo{}, err
+	}
+	return requesterVPCIIDInfo, accepterVPCIIDInfo, nil
+}
+
+// (1) get spiderIID
+// (2) accept Peering with the Accepter's connection
+// (3) set ResourceInfo(IID.NameId)
+func AcceptVPCPeering(connectionName string, rsType string, nameID string) (*cres.VPCPeeringInfo, error) {
+	cblog.Info("call AcceptVPCPeering()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	vpcPeeringSPLock.Lock(connectionName, nameID)
+	defer vpcPeeringSPLock.Unlock(connectionName, nameID)
+
+	// (1) get spiderIID
+	var iidInfo VPCPeeringIIDInfo
+	err = infostore.GetByConditions(&iidInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	requesterVPCIIDInfo, accepterVPCIIDInfo, err := getVPCPeeringVPCIIDInfos(&iidInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) accept Peering with the Accepter's connection
+	cldConn, err := ccm.GetCloudConnection(iidInfo.PeerConnectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateVPCPeeringHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	info, err := handler.AcceptVPCPeering(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}))
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (3) set ResourceInfo(IID.NameId)
+	setVPCPeeringUserIIDs(&iidInfo, requesterVPCIIDInfo, accepterVPCIIDInfo, &info)
+
+	return &info, nil
+}
+
+func ListVPCPeering(connectionName string, rsType string) ([]*cres.VPCPeeringInfo, error) {
+	cblog.Info("call ListVPCPeering()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateVPCPeeringHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (1) get IID:list
+	var iidInfoList []*VPCPeeringIIDInfo
+	err = infostore.ListByCondition(&iidInfoList, CONNECTION_NAME_COLUMN, connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	infoList := []*cres.VPCPeeringInfo{}
+	if len(iidInfoList) <= 0 {
+		return infoList, nil
+	}
+
+	// (2) Get VPCPeeringInfo-list with IID-list
+	for _, iidInfo := range iidInfoList {
+
+		vpcPeeringSPLock.RLock(connectionName, iidInfo.NameId)
+
+		info, err := handler.GetVPCPeering(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}))
+		if err != nil {
+			vpcPeeringSPLock.RUnlock(connectionName, iidInfo.NameId)
+			if checkNotFoundError(err) {
+				cblog.Info(err)
+				continue
+			}
+			cblog.Error(err)
+			return nil, err
+		}
+		vpcPeeringSPLock.RUnlock(connectionName, iidInfo.NameId)
+
+		// (3) set ResourceInfo(IID.NameId)
+		requesterVPCIIDInfo, accepterVPCIIDInfo, err := getVPCPeeringVPCIIDInfos(iidInfo)
+		if err != nil {
+			cblog.Error(err)
+			return nil, err
+		}
+		setVPCPeeringUserIIDs(iidInfo, requesterVPCIIDInfo, accepterVPCIIDInfo, &info)
+
+		infoList = append(infoList, &info)
+	}
+
+	return infoList, nil
+}
+
+// (1) get IID(NameId)
+// (2) get resource(SystemId)
+// (3) set ResourceInfo(IID.NameId)
+func GetVPCPeering(connectionName string, rsType string, nameID string) (*cres.VPCPeeringInfo, error) {
+	cblog.Info("call GetVPCPeering()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	handler, err := cldConn.CreateVPCPeeringHandler()
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	vpcPeeringSPLock.RLock(connectionName, nameID)
+	defer vpcPeeringSPLock.RUnlock(connectionName, nameID)
+
+	// (1) get IID(NameId)
+	var iidInfo VPCPeeringIIDInfo
+	err = infostore.GetByConditions(&iidInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (2) get resource(SystemId)
+	info, err := handler.GetVPCPeering(getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId}))
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+
+	// (3) set ResourceInfo(IID.NameId)
+	requesterVPCIIDInfo, accepterVPCIIDInfo, err := getVPCPeeringVPCIIDInfos(&iidInfo)
+	if err != nil {
+		cblog.Error(err)
+		return nil, err
+	}
+	setVPCPeeringUserIIDs(&iidInfo, requesterVPCIIDInfo, accepterVPCIIDInfo, &info)
+
+	return &info, nil
+}
+
+// (1) get spiderIID for creating driverIID
+// (2) delete Resource(SystemId)
+// (3) delete IID
+func DeleteVPCPeering(connectionName string, rsType string, nameID string, force string) (bool, error) {
+	cblog.Info("call DeleteVPCPeering()")
+
+	// check empty and trim user inputs
+	connectionName, err := EmptyCheckAndTrim("connectionName", connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	nameID, err = EmptyCheckAndTrim("nameID", nameID)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	handler, err := cldConn.CreateVPCPeeringHandler()
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	vpcPeeringSPLock.Lock(connectionName, nameID)
+	defer vpcPeeringSPLock.Unlock(connectionName, nameID)
+
+	// (1) get spiderIID for creating driverIID
+	var iidInfo VPCPeeringIIDInfo
+	err = infostore.GetByConditions(&iidInfo, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+	if err != nil {
+		cblog.Error(err)
+		return false, err
+	}
+
+	// (2) delete Resource(SystemId)
+	driverIId := getDriverIID(cres.IID{NameId: iidInfo.NameId, SystemId: iidInfo.SystemId})
+	result, err := handler.DeleteVPCPeering(driverIId)
+	if err != nil {
+		cblog.Error(err)
+		if force != "true" {
+			return false, err
+		}
+	}
+
+	if force != "true" {
+		if !result {
+			return result, nil
+		}
+	}
+
+	// (3) delete IID
+	_, err = infostore.DeleteByConditions(&VPCPeeringIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, NAME_ID_COLUMN, nameID)
+	if err != nil {
+		cblog.Error(err)
+		if force != "true" {
+			return false, err
+		}
+	}
+
+	return result, nil
+}
+
+// check whether a VPC is used by any VPC Peering as a Requester or an Accepter
+func checkVPCPeeringUsingVPC(connectionName string, vpcName string) (bool, error) {
+	bool_ret, err := infostore.HasByConditions(&VPCPeeringIIDInfo{}, CONNECTION_NAME_COLUMN, connectionName, OWNER_VPC_NAME_COLUMN, vpcName)
+	if err != nil || bool_ret {
+		return bool_ret, err
+	}
+	return infostore.HasByConditions(&VPCPeeringIIDInfo{}, PEER_CONNECTION_NAME_COLUMN, connectionName, PEER_VPC_NAME_COLUMN, vpcName)
+}
+
+func CountAllVPCPeerings() (int64, error) {
+	var info VPCPeeringIIDInfo
+	count, err := infostore.CountAllNameIDs(&info)
+	if err != nil {
+		cblog.Error(err)
+		return count, err
+	}
+
+	return count, nil
+}
+
+func CountVPCPeeringsByConnection(connectionName string) (int64, error) {
+	var info VPCPeeringIIDInfo
+	count, err := infostore.CountNameIDsByConnection(&info, connectionName)
+	if err != nil {
+		cblog.Error(err)
+		return count, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,66 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"strings"
+	"testing"
+)
+
+func TestDeleteResourcesUsedByALB(t *testing.T) {
+	connectionName := setUpMockConnection(t)
+	vmReqInfo := setUpMockVMNetwork(t, connectionName)
+
+	vmReqInfo.IId = cres.IID{NameId: "vm-01"}
+	if _, err := cmrt.StartVM(connectionName, cmrt.VM, vmReqInfo, "OFF"); err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() {
+		cmrt.DeleteVM(connectionName, cmrt.VM, "vm-01", "true")
+	})
+
+	_, err := cmrt.CreateALB(connectionName, cmrt.ALB, cres.ALBInfo{
+		IId:       cres.IID{NameId: "alb-01"},
+		VpcIID:    cres.IID{NameId: "vpc-01"},
+		Type:      "PUBLIC",
+		Listeners: []cres.ALBListenerInfo{{Protocol: "HTTP", Port: "80", DefaultTargetGroup: "web-tg"}},
+		TargetGroups: []cres.TargetGroupInfo{
+			{Name: "web-tg", Protocol: "HTTP", Port: "80", VMs: &[]cres.IID{{NameId: "vm-01"}},
+				HealthChecker: cres.HealthCheckerInfo{Protocol: "HTTP", Port: "80", Interval: 10, Timeout: 5, Threshold: 3}},
+		},
+	}, "OFF")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() {
+		cmrt.DeleteALB(connectionName, cmrt.ALB, "alb-01", "true")
+	})
+
+	_, err = cmrt.DeleteVPC(connectionName, cmrt.VPC, "vpc-01", "false")
+	if err == nil || !strings.Contains(err.Error(), "ALB") {
+		t.Errorf("Deleting the VPC of an ALB should be failed: %v", err)
+	}
+	_, _, err = cmrt.DeleteVM(connectionName, cmrt.VM, "vm-01", "false")
+	if err == nil || !strings.Contains(err.Error(), "alb-01") {
+		t.Errorf("Deleting a target VM of an ALB should be failed: %v", err)
+	}
+
+	if _, err := cmrt.RemoveALBTargetVMs(connectionName, "alb-01", "web-tg", []string{"vm-01"}); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, _, err := cmrt.DeleteVM(connectionName, cmrt.VM, "vm-01", "false"); err != nil {
+		t.Errorf("Deleting a VM removed from the Target Group should be succeeded: %v", err)
+	}
+	if _, err := cmrt.DeleteALB(connectionName, cmrt.ALB, "alb-01", "false"); err != nil {
+		t.Fatal(err.Error())
+	}
+}
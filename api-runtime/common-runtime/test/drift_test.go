@@ -0,0 +1,107 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"strings"
+	"testing"
+)
+
+func TestDriftInvalidInputs(t *testing.T) {
+	if _, err := cmrt.DetectDrift("", nil); err == nil {
+		t.Error("An empty connection name should be failed!")
+	}
+	if _, err := cmrt.DetectDrift("mock-connection", []string{"vpcpeering"}); err == nil {
+		t.Error("An unsupported Resource Type should be failed!")
+	}
+	if _, err := cmrt.UpdateResourceSnapshot("mock-connection", cmrt.VM, ""); err == nil {
+		t.Error("An empty resource name should be failed!")
+	}
+	if _, err := cmrt.UpdateResourceSnapshot("mock-connection", "nodegroup", "ng-01"); err == nil {
+		t.Error("An unsupported Resource Type should be failed!")
+	}
+}
+
+func TestDetectDriftSecurityGroup(t *testing.T) {
+	connectionName := setUpMockConnection(t)
+	setUpMockVMNetwork(t, connectionName)
+
+	detect := func() *cmrt.DriftResourceInfo {
+		t.Helper()
+		report, err := cmrt.DetectDrift(connectionName, []string{cmrt.SG})
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(report.ResourceList) != 1 {
+			t.Fatalf("The report should have only sg-01, but %d resources!", len(report.ResourceList))
+		}
+		return report.ResourceList[0]
+	}
+
+	// the first detection saves the snapshot
+	if rsInfo := detect(); rsInfo.Status != cmrt.DRIFT_BASELINED {
+		t.Errorf("The first detection should be baselined: %+v", rsInfo)
+	}
+	if rsInfo := detect(); rsInfo.Status != cmrt.DRIFT_NONE {
+		t.Errorf("No change should not be drifted: %+v", rsInfo)
+	}
+
+	// an out-of-band change by the CSP console
+	sgInfo, err := cmrt.GetSecurity(connectionName, cmrt.SG, "sg-01")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	handler, err := cldConn.CreateSecurityHandler()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	ruleList := []cres.SecurityRuleInfo{{Direction: "inbound", IPProtocol: "TCP", FromPort: "443", ToPort: "443", CIDR: "0.0.0.0/0"}}
+	driverIID := cres.IID{NameId: sgInfo.IId.SystemId, SystemId: sgInfo.IId.SystemId}
+	if _, err := handler.AddRules(driverIID, &ruleList); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	rsInfo := detect()
+	if rsInfo.Status != cmrt.DRIFT_DETECTED || len(rsInfo.FieldList) == 0 {
+		t.Fatalf("The out-of-band change should be drifted: %+v", rsInfo)
+	}
+	for _, field := range rsInfo.FieldList {
+		if !strings.HasPrefix(field.Field, "SecurityRules") {
+			t.Errorf("Only the SecurityRules should be drifted: %+v", field)
+		}
+	}
+	if !strings.Contains(rsInfo.FieldList[0].Current, "443") || strings.Contains(rsInfo.FieldList[0].Snapshot, "443") {
+		t.Errorf("The added rule should be in the current state only: %+v", rsInfo.FieldList[0])
+	}
+
+	// accept the change
+	if _, err := cmrt.UpdateResourceSnapshot(connectionName, cmrt.SG, "sg-01"); err != nil {
+		t.Fatal(err.Error())
+	}
+	if rsInfo := detect(); rsInfo.Status != cmrt.DRIFT_NONE {
+		t.Errorf("The accepted change should not be drifted: %+v", rsInfo)
+	}
+
+	// a change by Spider is re-baselined, not drifted
+	ruleList = []cres.SecurityRuleInfo{{Direction: "inbound", IPProtocol: "TCP", FromPort: "8080", ToPort: "8080", CIDR: "0.0.0.0/0"}}
+	if _, err := cmrt.AddRules(connectionName, "sg-01", ruleList, nil); err != nil {
+		t.Fatal(err.Error())
+	}
+	if rsInfo := detect(); rsInfo.Status != cmrt.DRIFT_BASELINED {
+		t.Errorf("The change by Spider should be baselined: %+v", rsInfo)
+	}
+}
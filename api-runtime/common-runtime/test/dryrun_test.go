@@ -0,0 +1,112 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"strings"
+	"testing"
+)
+
+func TestDryRunStartVM(t *testing.T) {
+	connectionName := setUpMockConnection(t)
+	vmReqInfo := setUpMockVMNetwork(t, connectionName)
+	vmReqInfo.IId = cres.IID{NameId: "vm-01"}
+
+	// a valid request
+	report, err := cmrt.DryRunStartVM(connectionName, cmrt.VM, vmReqInfo, "OFF")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !report.Valid || report.DriverNameId != "vm-01" {
+		t.Errorf("The valid request should be passed: %+v", report)
+	}
+	report, err = cmrt.DryRunStartVM(connectionName, cmrt.VM, vmReqInfo, "ON")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !report.Valid || !strings.HasPrefix(report.DriverNameId, "vm-01-") {
+		t.Errorf("The valid request should be passed with an example ID: %+v", report)
+	}
+
+	// each invalid request fails the check
+	testCases := []struct {
+		check  string
+		modify func(reqInfo *cres.VMReqInfo)
+	}{
+		{"VPC", func(reqInfo *cres.VMReqInfo) { reqInfo.VpcIID = cres.IID{NameId: "vpc-99"} }},
+		{"Subnet", func(reqInfo *cres.VMReqInfo) { reqInfo.SubnetIID = cres.IID{NameId: "subnet-99"} }},
+		{"SecurityGroup", func(reqInfo *cres.VMReqInfo) { reqInfo.SecurityGroupIIDs = []cres.IID{{NameId: "sg-99"}} }},
+		{"KeyPair", func(reqInfo *cres.VMReqInfo) { reqInfo.KeyPairIID = cres.IID{NameId: "keypair-99"} }},
+		{"RootDisk", func(reqInfo *cres.VMReqInfo) { reqInfo.RootDiskType = "NVME" }},
+		{"IDLength", func(reqInfo *cres.VMReqInfo) { reqInfo.IId = cres.IID{NameId: "vm-" + strings.Repeat("a", 253)} }},
+	}
+	for _, tc := range testCases {
+		reqInfo := vmReqInfo
+		tc.modify(&reqInfo)
+		report, err := cmrt.DryRunStartVM(connectionName, cmrt.VM, reqInfo, "OFF")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		checkDryRunFailed(t, report, tc.check)
+	}
+
+	// the name is already taken
+	if _, err := cmrt.StartVM(connectionName, cmrt.VM, vmReqInfo, "OFF"); err != nil {
+		t.Fatal(err.Error())
+	}
+	report, err = cmrt.DryRunStartVM(connectionName, cmrt.VM, vmReqInfo, "OFF")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	checkDryRunFailed(t, report, "NameUniqueness")
+	if _, _, err := cmrt.DeleteVM(connectionName, cmrt.VM, "vm-01", "true"); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// no VM is created in Spider and in the CSP by the dry-runs
+	vmList, err := cmrt.ListVM(connectionName, cmrt.VM)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(vmList) != 0 {
+		t.Errorf("ListVM: expected 0, got %d", len(vmList))
+	}
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	vmHandler, err := cldConn.CreateVMHandler()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	cspVMList, err := vmHandler.ListVM()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(cspVMList) != 0 {
+		t.Errorf("CSP ListVM: expected 0, got %d", len(cspVMList))
+	}
+}
+
+// checks the report is not valid by the failed check
+func checkDryRunFailed(t *testing.T, report *cmrt.DryRunReportInfo, checkName string) {
+	if report.Valid {
+		t.Errorf("%s: the report should not be valid!", checkName)
+	}
+	for _, check := range report.Checks {
+		if check.Name == checkName && !check.Passed {
+			return
+		}
+	}
+	t.Errorf("%s: the check should be failed: %+v", checkName, report.Checks)
+}
@@ -0,0 +1,227 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	infostore "github.com/cloud-barista/cb-spider/info-store"
+
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIPAMPoolInvalidInputs(t *testing.T) {
+	if _, err := cmrt.CreateIPAMPool(cmrt.IPAMPoolInfo{Name: "", CIDR: "10.0.0.0/8"}); err == nil {
+		t.Error("An empty Name should be failed!")
+	}
+	if _, err := cmrt.CreateIPAMPool(cmrt.IPAMPoolInfo{Name: "pool-01", CIDR: "10.0.0.0"}); err == nil {
+		t.Error("An invalid CIDR should be failed!")
+	}
+	if _, err := cmrt.CreateIPAMPool(cmrt.IPAMPoolInfo{Name: "pool-01", CIDR: "2001:db8::/32"}); err == nil {
+		t.Error("An IPv6 CIDR should be failed!")
+	}
+	if _, err := cmrt.CreateIPAMPool(cmrt.IPAMPoolInfo{Name: "pool-01", CIDR: "10.0.0.0/16", VPCPrefixLength: 8}); err == nil {
+		t.Error("A VPCPrefixLength shorter than the pool should be failed!")
+	}
+	if _, err := cmrt.CreateIPAMPool(cmrt.IPAMPoolInfo{Name: "pool-01", CIDR: "10.0.0.0/8", VPCPrefixLength: 16, SubnetPrefixLength: 12}); err == nil {
+		t.Error("A SubnetPrefixLength shorter than the VPCPrefixLength should be failed!")
+	}
+	if _, err := cmrt.DeleteIPAMPool(""); err == nil {
+		t.Error("An empty Name should be failed!")
+	}
+}
+
+func TestIPAMAllocation(t *testing.T) {
+	connectionName := setUpMockConnection(t)
+	t.Setenv("SPIDER_IPAM_OVERLAP_CHECK", "OFF")
+
+	poolName := "pool-" + connectionName
+	if _, err := cmrt.CreateIPAMPool(cmrt.IPAMPoolInfo{Name: poolName, CIDR: "172.30.0.0/22", VPCPrefixLength: 24, SubnetPrefixLength: 26}); err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() {
+		cmrt.DeleteIPAMPool(poolName)
+	})
+
+	createVPC := func(vpcName string, vpcCIDR string, subnetCIDRMap map[string]string) (*cres.VPCInfo, error) {
+		reqInfo := cres.VPCReqInfo{IId: cres.IID{NameId: vpcName}, IPv4_CIDR: vpcCIDR}
+		for _, subnetName := range []string{vpcName + "-subnet-1", vpcName + "-subnet-2"} {
+			if cidr, ok := subnetCIDRMap[subnetName]; ok {
+				reqInfo.SubnetInfoList = append(reqInfo.SubnetInfoList, cres.SubnetInfo{IId: cres.IID{NameId: subnetName}, IPv4_CIDR: cidr})
+			}
+		}
+		info, err := cmrt.CreateVPC(connectionName, cmrt.VPC, reqInfo, "OFF")
+		if err == nil {
+			t.Cleanup(func() {
+				cmrt.DeleteVPC(connectionName, cmrt.VPC, vpcName, "true")
+			})
+		}
+		return info, err
+	}
+	getPoolUsage := func() (cmrt.IPAMPoolUsageInfo, []cmrt.IPAMOverlapInfo) {
+		usageInfo, err := cmrt.GetIPAMUsage()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		overlapList := []cmrt.IPAMOverlapInfo{}
+		for _, overlap := range usageInfo.OverlapList {
+			if overlap.A.ConnectionName == connectionName && overlap.B.ConnectionName == connectionName {
+				overlapList = append(overlapList, overlap)
+			}
+		}
+		for _, poolUsage := range usageInfo.PoolUsageList {
+			if poolUsage.Pool.Name == poolName {
+				return poolUsage, overlapList
+			}
+		}
+		t.Fatalf("The IPAM Pool '%s' is not in the usage!", poolName)
+		return cmrt.IPAMPoolUsageInfo{}, nil
+	}
+
+	// the first free blocks of the pool and the VPC
+	vpcInfo, err := createVPC("vpc-a", "", map[string]string{"vpc-a-subnet-1": "", "vpc-a-subnet-2": ""})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	checkVPCCIDRs(t, vpcInfo, "172.30.0.0/24", []string{"172.30.0.0/26", "172.30.0.64/26"})
+
+	vpcInfo, err = createVPC("vpc-b", "", map[string]string{"vpc-b-subnet-1": "172.30.1.64/26", "vpc-b-subnet-2": ""})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	checkVPCCIDRs(t, vpcInfo, "172.30.1.0/24", []string{"172.30.1.64/26", "172.30.1.0/26"})
+
+	vpcInfo, err = cmrt.AddSubnet(connectionName, cmrt.SUBNET, "vpc-a", cres.SubnetInfo{IId: cres.IID{NameId: "vpc-a-subnet-3"}}, "OFF")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	checkVPCCIDRs(t, vpcInfo, "172.30.0.0/24", []string{"172.30.0.0/26", "172.30.0.64/26", "172.30.0.128/26"})
+
+	poolUsage, _ := getPoolUsage()
+	if poolUsage.TotalAddresses != 1024 || poolUsage.UsedAddresses != 512 || poolUsage.UtilizationPercent != 50 || len(poolUsage.VPCCIDRList) != 2 {
+		t.Errorf("unexpected pool usage: %+v", poolUsage)
+	}
+	if _, err := cmrt.DeleteIPAMPool(poolName); err == nil {
+		t.Error("Deleting a pool in use should be failed!")
+	}
+
+	// an overlap is rejected with SPIDER_IPAM_OVERLAP_CHECK=ON, and the CIDR is not recorded
+	t.Setenv("SPIDER_IPAM_OVERLAP_CHECK", "ON")
+	_, err = createVPC("vpc-c", "172.30.0.0/23", map[string]string{"vpc-c-subnet-1": "172.30.0.0/24"})
+	if err == nil || !strings.Contains(err.Error(), "overlaps") {
+		t.Errorf("The overlapped CIDR should be failed: %v", err)
+	}
+	if poolUsage, _ := getPoolUsage(); len(poolUsage.VPCCIDRList) != 2 {
+		t.Errorf("The rejected VPC should not be recorded: %+v", poolUsage.VPCCIDRList)
+	}
+
+	// an overlap is only reported with SPIDER_IPAM_OVERLAP_CHECK=OFF
+	t.Setenv("SPIDER_IPAM_OVERLAP_CHECK", "OFF")
+	if _, err = createVPC("vpc-c", "172.30.0.0/23", map[string]string{"vpc-c-subnet-1": "172.30.0.0/24"}); err != nil {
+		t.Fatal(err.Error())
+	}
+	poolUsage, overlapList := getPoolUsage()
+	if poolUsage.UsedAddresses != 512 || len(overlapList) != 2 {
+		t.Errorf("vpc-c should overlap vpc-a and vpc-b: %+v, %+v", poolUsage, overlapList)
+	}
+	for _, overlap := range overlapList {
+		if overlap.A.VPCName != "vpc-c" && overlap.B.VPCName != "vpc-c" {
+			t.Errorf("unexpected overlap: %+v", overlap)
+		}
+	}
+	if _, err := cmrt.DeleteVPC(connectionName, cmrt.VPC, "vpc-c", "true"); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, overlapList := getPoolUsage(); len(overlapList) != 0 {
+		t.Errorf("The deleted VPC should not be recorded: %+v", overlapList)
+	}
+
+	// no free block in the VPC, the Subnet prefix is not shorter than the VPC prefix
+	vpcInfo, err = createVPC("vpc-d", "192.168.250.0/27", map[string]string{"vpc-d-subnet-1": ""})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	checkVPCCIDRs(t, vpcInfo, "192.168.250.0/27", []string{"192.168.250.0/27"})
+	_, err = cmrt.AddSubnet(connectionName, cmrt.SUBNET, "vpc-d", cres.SubnetInfo{IId: cres.IID{NameId: "vpc-d-subnet-2"}}, "OFF")
+	if err == nil || !strings.Contains(err.Error(), "no free /27 block") {
+		t.Errorf("The allocation in a full VPC should be failed: %v", err)
+	}
+}
+
+func TestIPAMBackfill(t *testing.T) {
+	connectionName := setUpMockConnection(t)
+	t.Setenv("SPIDER_IPAM_OVERLAP_CHECK", "OFF")
+
+	poolName := "pool-" + connectionName
+	if _, err := cmrt.CreateIPAMPool(cmrt.IPAMPoolInfo{Name: poolName, CIDR: "172.31.0.0/22", VPCPrefixLength: 24, SubnetPrefixLength: 26}); err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() {
+		cmrt.DeleteIPAMPool(poolName)
+	})
+
+	// a VPC created before IPAM has no CIDR record
+	reqInfo := cres.VPCReqInfo{IId: cres.IID{NameId: "vpc-old"}, IPv4_CIDR: "172.31.0.0/24",
+		SubnetInfoList: []cres.SubnetInfo{{IId: cres.IID{NameId: "subnet-old"}, IPv4_CIDR: "172.31.0.0/26"}}}
+	if _, err := cmrt.CreateVPC(connectionName, cmrt.VPC, reqInfo, "OFF"); err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() {
+		cmrt.DeleteVPC(connectionName, cmrt.VPC, "vpc-old", "true")
+	})
+	if _, err := infostore.DeleteByConditions(&cmrt.IPAMCIDRInfo{}, cmrt.CONNECTION_NAME_COLUMN, connectionName, cmrt.NAME_ID_COLUMN, "vpc-old"); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := infostore.DeleteByConditions(&cmrt.IPAMCIDRInfo{}, cmrt.CONNECTION_NAME_COLUMN, connectionName, cmrt.OWNER_VPC_NAME_COLUMN, "vpc-old"); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	getPoolVPCCIDRList := func() []cmrt.VPCCIDRInfo {
+		usageInfo, err := cmrt.GetIPAMUsage()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		for _, poolUsage := range usageInfo.PoolUsageList {
+			if poolUsage.Pool.Name == poolName {
+				return poolUsage.VPCCIDRList
+			}
+		}
+		return nil
+	}
+	if vpcCIDRList := getPoolVPCCIDRList(); len(vpcCIDRList) != 0 {
+		t.Fatalf("The VPC without CIDR record should not be in the usage: %+v", vpcCIDRList)
+	}
+
+	// the backfill records the CIDRs from the CSP in the background
+	cmrt.StartIPAMBackfill()
+	for i := 0; i < 50; i++ {
+		vpcCIDRList := getPoolVPCCIDRList()
+		if len(vpcCIDRList) == 1 && vpcCIDRList[0].VPCName == "vpc-old" && vpcCIDRList[0].CIDR == "172.31.0.0/24" {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Error("The CIDR of the VPC created before IPAM should be recorded by the backfill!")
+}
+
+func checkVPCCIDRs(t *testing.T, vpcInfo *cres.VPCInfo, vpcCIDR string, subnetCIDRList []string) {
+	t.Helper()
+	if vpcInfo.IPv4_CIDR != vpcCIDR {
+		t.Errorf("The VPC CIDR should be %s, but %s", vpcCIDR, vpcInfo.IPv4_CIDR)
+	}
+	got := []string{}
+	for _, subnetInfo := range vpcInfo.SubnetInfoList {
+		got = append(got, subnetInfo.IPv4_CIDR)
+	}
+	if strings.Join(got, ",") != strings.Join(subnetCIDRList, ",") {
+		t.Errorf("The Subnet CIDRs should be %v, but %v", subnetCIDRList, got)
+	}
+}
@@ -0,0 +1,99 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+	ccim "github.com/cloud-barista/cb-spider/cloud-info-manager/connection-config-info-manager"
+	crim "github.com/cloud-barista/cb-spider/cloud-info-manager/credential-info-manager"
+	dim "github.com/cloud-barista/cb-spider/cloud-info-manager/driver-info-manager"
+	rim "github.com/cloud-barista/cb-spider/cloud-info-manager/region-info-manager"
+
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var mockConnectionSeq int64
+
+// setUpMockConnection registers a Mock connection with a new name for each call,
+// because the Mock Driver keeps the resources in memory but the info-store keeps the IIDs across the runs.
+func setUpMockConnection(t *testing.T) string {
+	t.Helper()
+
+	name := fmt.Sprintf("mock-test-%d-%d", time.Now().UnixNano(), atomic.AddInt64(&mockConnectionSeq, 1))
+
+	if _, err := dim.RegisterCloudDriver(name, "MOCK", "mock-driver-v1.0.so"); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := crim.RegisterCredential(name, "MOCK", []cres.KeyValue{{Key: "MockName", Value: name}}); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := rim.RegisterRegion(name, "MOCK", []cres.KeyValue{{Key: "Region", Value: "default"}}, nil); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := ccim.CreateConnectionConfig(name, "MOCK", name, name, name); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	t.Cleanup(func() {
+		ccim.DeleteConnectionConfig(name)
+		rim.UnRegisterRegion(name)
+		crim.UnRegisterCredential(name)
+		dim.UnRegisterCloudDriver(name)
+	})
+	return name
+}
+
+// setUpMockVMNetwork creates "vpc-01"(10.0.0.0/16) with "subnet-01"(10.0.1.0/24), "sg-01" and "keypair-01"
+// without the ID transformation, and returns a VM request with them.
+func setUpMockVMNetwork(t *testing.T, connectionName string) cres.VMReqInfo {
+	t.Helper()
+
+	_, err := cmrt.CreateVPC(connectionName, cmrt.VPC, cres.VPCReqInfo{
+		IId:            cres.IID{NameId: "vpc-01"},
+		IPv4_CIDR:      "10.0.0.0/16",
+		SubnetInfoList: []cres.SubnetInfo{{IId: cres.IID{NameId: "subnet-01"}, IPv4_CIDR: "10.0.1.0/24"}},
+	}, "OFF")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	ruleList := []cres.SecurityRuleInfo{{Direction: "inbound", IPProtocol: "TCP", FromPort: "22", ToPort: "22"}}
+	_, err = cmrt.CreateSecurity(connectionName, cmrt.SG, cres.SecurityReqInfo{
+		IId:           cres.IID{NameId: "sg-01"},
+		VpcIID:        cres.IID{NameId: "vpc-01"},
+		SecurityRules: &ruleList,
+	}, nil, "OFF")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	_, err = cmrt.CreateKey(connectionName, cmrt.KEY, cres.KeyPairReqInfo{IId: cres.IID{NameId: "keypair-01"}}, "OFF")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	t.Cleanup(func() {
+		cmrt.DeleteKey(connectionName, cmrt.KEY, "keypair-01", "true")
+		cmrt.DeleteSecurity(connectionName, cmrt.SG, "sg-01", "true")
+		cmrt.DeleteVPC(connectionName, cmrt.VPC, "vpc-01", "true")
+	})
+
+	return cres.VMReqInfo{
+		ImageIID:          cres.IID{NameId: "mock-vmimage-01"},
+		VpcIID:            cres.IID{NameId: "vpc-01"},
+		SubnetIID:         cres.IID{NameId: "subnet-01"},
+		SecurityGroupIIDs: []cres.IID{{NameId: "sg-01"}},
+		VMSpecName:        "mock-vmspec-01",
+		KeyPairIID:        cres.IID{NameId: "keypair-01"},
+	}
+}
@@ -0,0 +1,186 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"strings"
+	"testing"
+)
+
+func TestMultiCloudVPNInvalidInputs(t *testing.T) {
+	reqInfo := cmrt.MultiCloudVPNReqInfo{
+		Name:               "vpn-01",
+		VPCName:            "vpc-01",
+		PeerConnectionName: "mock-connection-02",
+		PeerVPCName:        "vpc-02",
+	}
+
+	if _, err := cmrt.CreateMultiCloudVPN("", reqInfo, "ON"); err == nil {
+		t.Error("An empty connection name should be failed!")
+	}
+
+	noName := reqInfo
+	noName.Name = ""
+	if _, err := cmrt.CreateMultiCloudVPN("mock-connection-01", noName, "ON"); err == nil {
+		t.Error("An empty VPN name should be failed!")
+	}
+
+	sameConn := reqInfo
+	sameConn.PeerConnectionName = "mock-connection-01"
+	_, err := cmrt.CreateMultiCloudVPN("mock-connection-01", sameConn, "ON")
+	if err == nil || !strings.Contains(err.Error(), "different connections") {
+		t.Errorf("The same connection on both sides should be failed: %v", err)
+	}
+
+	for _, key := range []string{"short", "0starts-with-zero", "has space in it"} {
+		badKey := reqInfo
+		badKey.PreSharedKey = key
+		_, err := cmrt.CreateMultiCloudVPN("mock-connection-01", badKey, "ON")
+		if err == nil || !strings.Contains(err.Error(), "PreSharedKey") {
+			t.Errorf("The PreSharedKey '%s' should be failed: %v", key, err)
+		}
+	}
+
+	if _, err := cmrt.GetMultiCloudVPN("mock-connection-01", ""); err == nil {
+		t.Error("An empty VPN name should be failed!")
+	}
+	if _, err := cmrt.DeleteMultiCloudVPN("", "vpn-01", "false"); err == nil {
+		t.Error("An empty connection name should be failed!")
+	}
+}
+
+// setUpMockVPNSide creates a VPC without the ID transformation and returns the VPN Gateway handler of the connection.
+func setUpMockVPNSide(t *testing.T, connectionName string, vpcName string, vpcCIDR string) cres.VPNGatewayHandler {
+	t.Helper()
+
+	_, err := cmrt.CreateVPC(connectionName, cmrt.VPC, cres.VPCReqInfo{
+		IId:            cres.IID{NameId: vpcName},
+		IPv4_CIDR:      vpcCIDR,
+		SubnetInfoList: []cres.SubnetInfo{{IId: cres.IID{NameId: vpcName + "-subnet"}, IPv4_CIDR: strings.Replace(vpcCIDR, ".0.0/16", ".1.0/24", 1)}},
+	}, "OFF")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() {
+		cmrt.DeleteVPC(connectionName, cmrt.VPC, vpcName, "true")
+	})
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	handler, err := cldConn.CreateVPNGatewayHandler()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return handler
+}
+
+func TestCreateMultiCloudVPN(t *testing.T) {
+	connectionName := setUpMockConnection(t)
+	peerConnectionName := setUpMockConnection(t)
+	handler := setUpMockVPNSide(t, connectionName, "vpc-01", "10.1.0.0/16")
+	peerHandler := setUpMockVPNSide(t, peerConnectionName, "vpc-02", "10.2.0.0/16")
+
+	reqInfo := cmrt.MultiCloudVPNReqInfo{
+		Name:               "vpn-01",
+		VPCName:            "vpc-01",
+		PeerConnectionName: peerConnectionName,
+		PeerVPCName:        "vpc-02",
+	}
+
+	// the peer VPC already has a VPN Gateway, so the first side should be rolled back
+	blockerIID := cres.IID{NameId: "blocker-gw", SystemId: "blocker-gw"}
+	_, err := peerHandler.CreateVPNGateway(cres.VPNGatewayReqInfo{IId: blockerIID, VpcIID: cres.IID{NameId: "vpc-02", SystemId: "vpc-02"}})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	_, err = cmrt.CreateMultiCloudVPN(connectionName, reqInfo, "OFF")
+	if err == nil || !strings.Contains(err.Error(), peerConnectionName) {
+		t.Fatalf("The VPN Gateway on the peer side should be failed: %v", err)
+	}
+	gwList, err := handler.ListVPNGateway()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(gwList) != 0 {
+		t.Errorf("The VPN Gateway of the first side should be rolled back: %d left", len(gwList))
+	}
+	if _, err := cmrt.GetMultiCloudVPN(connectionName, "vpn-01"); err == nil {
+		t.Error("The failed Multi-Cloud VPN should not be registered!")
+	}
+	if _, err := peerHandler.DeleteVPNGateway(blockerIID); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// both halves
+	info, err := cmrt.CreateMultiCloudVPN(connectionName, reqInfo, "OFF")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() {
+		cmrt.DeleteMultiCloudVPN(connectionName, "vpn-01", "true")
+	})
+
+	if info.PreSharedKey == "" {
+		t.Error("The generated PreSharedKey should be returned on creation!")
+	}
+	if info.Status != cres.VPNTunnelUp {
+		t.Errorf("The Status should be %s, got %s", cres.VPNTunnelUp, info.Status)
+	}
+	for _, tc := range []struct {
+		sideInfo       cmrt.MultiCloudVPNSideInfo
+		connectionName string
+		vpcName        string
+		peerSideInfo   cmrt.MultiCloudVPNSideInfo
+		peerCIDR       string
+	}{
+		{info.Side, connectionName, "vpc-01", info.PeerSide, "10.2.0.0/16"},
+		{info.PeerSide, peerConnectionName, "vpc-02", info.Side, "10.1.0.0/16"},
+	} {
+		if tc.sideInfo.ConnectionName != tc.connectionName || tc.sideInfo.VpcIID.NameId != tc.vpcName {
+			t.Errorf("expected %s/%s, got %s/%s", tc.connectionName, tc.vpcName, tc.sideInfo.ConnectionName, tc.sideInfo.VpcIID.NameId)
+		}
+		if tc.sideInfo.VPNGateway.IId.NameId != "vpn-01" || tc.sideInfo.VPNTunnel.IId.NameId != "vpn-01"+cmrt.MULTICLOUD_VPN_TUNNEL_SUFFIX {
+			t.Errorf("%s: unexpected IIDs: %+v, %+v", tc.connectionName, tc.sideInfo.VPNGateway.IId, tc.sideInfo.VPNTunnel.IId)
+		}
+		if tc.sideInfo.VPNTunnel.PeerPublicIP != tc.peerSideInfo.VPNGateway.PublicIP {
+			t.Errorf("%s: the Tunnel should point to the peer's public IP %s, got %s",
+				tc.connectionName, tc.peerSideInfo.VPNGateway.PublicIP, tc.sideInfo.VPNTunnel.PeerPublicIP)
+		}
+		if len(tc.sideInfo.VPNTunnel.PeerCIDRList) != 1 || tc.sideInfo.VPNTunnel.PeerCIDRList[0] != tc.peerCIDR {
+			t.Errorf("%s: the Tunnel should route to %s, got %v", tc.connectionName, tc.peerCIDR, tc.sideInfo.VPNTunnel.PeerCIDRList)
+		}
+	}
+
+	getInfo, err := cmrt.GetMultiCloudVPN(connectionName, "vpn-01")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if getInfo.PreSharedKey != "" {
+		t.Error("The PreSharedKey should be returned only on creation!")
+	}
+	if getInfo.Status != cres.VPNTunnelUp || getInfo.PeerSide.ConnectionName != peerConnectionName {
+		t.Errorf("unexpected Multi-Cloud VPN: %+v", getInfo)
+	}
+
+	result, err := cmrt.DeleteMultiCloudVPN(connectionName, "vpn-01", "false")
+	if err != nil || !result {
+		t.Fatalf("Delete should be succeeded: %v", err)
+	}
+	for _, h := range []cres.VPNGatewayHandler{handler, peerHandler} {
+		if gwList, err := h.ListVPNGateway(); err != nil || len(gwList) != 0 {
+			t.Errorf("Both VPN Gateways should be deleted: %d left, %v", len(gwList), err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"testing"
+)
+
+func TestStartVMReadinessProbe(t *testing.T) {
+	connectionName := setUpMockConnection(t)
+	vmReqInfo := setUpMockVMNetwork(t, connectionName)
+
+	// the Mock driver skips the SSHD check of the DEFAULT probe
+	testCases := []struct {
+		probe    cres.ReadinessProbeInfo
+		expected cres.ReadinessStatus
+	}{
+		{cres.ReadinessProbeInfo{}, cres.Ready},
+		{cres.ReadinessProbeInfo{Type: " default "}, cres.Ready},
+		{cres.ReadinessProbeInfo{Type: "none"}, cres.ReadinessSkipped},
+		{cres.ReadinessProbeInfo{Type: "public_ip", Timeout: "10"}, cres.Ready},
+	}
+
+	for _, tc := range testCases {
+		vmReqInfo.IId = cres.IID{NameId: "vm-01"}
+		vmReqInfo.ReadinessProbe = tc.probe
+		vmInfo, err := cmrt.StartVM(connectionName, cmrt.VM, vmReqInfo, "OFF")
+		if err != nil {
+			t.Errorf("%+v: %v", tc.probe, err)
+			continue
+		}
+		if vmInfo.ReadinessStatus != tc.expected {
+			t.Errorf("%+v: expected %s, got %s", tc.probe, tc.expected, vmInfo.ReadinessStatus)
+		}
+		if _, _, err := cmrt.DeleteVM(connectionName, cmrt.VM, "vm-01", "true"); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	// an invalid probe is rejected before the VM is created
+	invalidProbeList := []cres.ReadinessProbeInfo{
+		{Type: "ping"},
+		{Type: "tcp"},
+		{Type: "ssh", Command: "true"},
+		{Type: "ssh", PrivateKey: "key"},
+		{Type: "http", Path: "healthz"},
+		{Type: "tcp", Port: "0"},
+		{Type: "tcp", Port: "65536"},
+		{Type: "tcp", Port: "ssh"},
+		{Type: "public_ip", Timeout: "5"},
+		{Type: "public_ip", Timeout: "1801"},
+		{Type: "public_ip", Timeout: "1m"},
+	}
+	for _, probe := range invalidProbeList {
+		vmReqInfo.IId = cres.IID{NameId: "vm-01"}
+		vmReqInfo.ReadinessProbe = probe
+		if _, err := cmrt.StartVM(connectionName, cmrt.VM, vmReqInfo, "OFF"); err == nil {
+			t.Errorf("%+v should be failed!", probe)
+			cmrt.DeleteVM(connectionName, cmrt.VM, "vm-01", "true")
+		}
+	}
+	vmList, err := cmrt.ListVM(connectionName, cmrt.VM)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(vmList) != 0 {
+		t.Errorf("ListVM: expected 0, got %d", len(vmList))
+	}
+}
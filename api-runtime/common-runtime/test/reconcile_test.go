@@ -0,0 +1,131 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"strings"
+	"testing"
+)
+
+func TestReconcileInvalidInputs(t *testing.T) {
+	if _, err := cmrt.ReconcileResources("mock-connection", []string{"vpcpeering"}); err == nil {
+		t.Error("An unsupported Resource Type should be failed!")
+	}
+	if _, err := cmrt.ApplyReconcileAction("mock-connection", cmrt.VM, "import", []string{"vm-01"}); err == nil {
+		t.Error("An invalid action should be failed!")
+	}
+	if _, err := cmrt.ApplyReconcileAction("mock-connection", cmrt.VM, cmrt.RECONCILE_DELETE, nil); err == nil {
+		t.Error("An empty id list should be failed!")
+	}
+	if _, err := cmrt.StartReconcileSchedule("mock-connection", cmrt.MIN_RECONCILE_INTERVAL-1); err == nil {
+		t.Error("An interval under MIN_RECONCILE_INTERVAL should be failed!")
+	}
+	if _, err := cmrt.StopReconcileSchedule("no-schedule-connection"); err == nil {
+		t.Error("Stopping no schedule should be failed!")
+	}
+}
+
+func TestReconcileKeyPair(t *testing.T) {
+	connectionName := setUpMockConnection(t)
+
+	// "key-01": mapped, "key-02": only in Spider, "csp-key-01": only in the CSP
+	for _, name := range []string{"key-01", "key-02"} {
+		if _, err := cmrt.CreateKey(connectionName, cmrt.KEY, cres.KeyPairReqInfo{IId: cres.IID{NameId: name}}, "OFF"); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	t.Cleanup(func() {
+		cmrt.DeleteKey(connectionName, cmrt.KEY, "key-01", "true")
+		cmrt.DeleteKey(connectionName, cmrt.KEY, "key-02", "true")
+	})
+
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	handler, err := cldConn.CreateKeyPairHandler()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := handler.DeleteKey(cres.IID{NameId: "key-02", SystemId: "key-02"}); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := handler.CreateKey(cres.KeyPairReqInfo{IId: cres.IID{NameId: "csp-key-01"}}); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// report
+	report, err := cmrt.ReconcileResources(connectionName, []string{cmrt.KEY})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(report.ResourceList) != 1 {
+		t.Fatalf("The report should have only the KeyPair, but %d types!", len(report.ResourceList))
+	}
+	keyReport := report.ResourceList[0]
+	if !report.HasDrift || keyReport.ErrorMSG != "" || keyReport.MappedCount != 1 {
+		t.Errorf("unexpected report: %+v", keyReport)
+	}
+	if len(keyReport.OnlySpiderList) != 1 || keyReport.OnlySpiderList[0].NameId != "key-02" {
+		t.Errorf("The OnlySpiderList should have key-02: %v", keyReport.OnlySpiderList)
+	}
+	if len(keyReport.OnlyCSPList) != 1 || keyReport.OnlyCSPList[0].SystemId != "csp-key-01" {
+		t.Errorf("The OnlyCSPList should have csp-key-01: %v", keyReport.OnlyCSPList)
+	}
+	if lastReport, err := cmrt.GetLastReconcileReport(connectionName); err != nil || lastReport != report {
+		t.Errorf("The last report should be kept: %v", err)
+	}
+
+	// an id not in the drift
+	if _, err := cmrt.ApplyReconcileAction(connectionName, cmrt.KEY, cmrt.RECONCILE_ADOPT, []string{"key-01"}); err == nil {
+		t.Error("Adopting a mapped KeyPair should be failed!")
+	}
+
+	// adopt
+	resultList, err := cmrt.ApplyReconcileAction(connectionName, cmrt.KEY, cmrt.RECONCILE_ADOPT, []string{"csp-key-01"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(resultList) != 1 || resultList[0].Status != cmrt.RECONCILE_SUCCEEDED || resultList[0].NameId == "" {
+		t.Fatalf("unexpected adopt result: %+v", resultList[0])
+	}
+	adoptedName := resultList[0].NameId
+	t.Cleanup(func() {
+		cmrt.DeleteKey(connectionName, cmrt.KEY, adoptedName, "true")
+	})
+	if !strings.HasSuffix(adoptedName, "-csp-key-01") {
+		t.Errorf("The adopted NameId should be generated from the SystemId: %s", adoptedName)
+	}
+	if _, err := cmrt.GetKey(connectionName, cmrt.KEY, adoptedName); err != nil {
+		t.Errorf("The adopted KeyPair should be managed by Spider: %v", err)
+	}
+
+	// unregister
+	resultList, err = cmrt.ApplyReconcileAction(connectionName, cmrt.KEY, cmrt.RECONCILE_UNREGISTER, []string{"key-02"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(resultList) != 1 || resultList[0].Status != cmrt.RECONCILE_SUCCEEDED {
+		t.Fatalf("unexpected unregister result: %+v", resultList[0])
+	}
+
+	// no drift
+	report, err = cmrt.ReconcileResources(connectionName, []string{cmrt.KEY})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	keyReport = report.ResourceList[0]
+	if report.HasDrift || keyReport.MappedCount != 2 {
+		t.Errorf("The drift should be resolved: %+v", keyReport)
+	}
+}
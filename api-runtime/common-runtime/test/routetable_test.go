@@ -0,0 +1,59 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"testing"
+)
+
+func TestRemoveRouteOfDeletedTarget(t *testing.T) {
+	connectionName := setUpMockConnection(t)
+	setUpMockVMNetwork(t, connectionName)
+
+	natInfo, err := cmrt.CreateNATGateway(connectionName, cmrt.NATGATEWAY, cres.NATGatewayReqInfo{
+		IId:       cres.IID{NameId: "nat-01"},
+		VpcIID:    cres.IID{NameId: "vpc-01"},
+		SubnetIID: cres.IID{NameId: "subnet-01"},
+	}, "OFF")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	route := cres.RouteInfo{DestinationCIDR: "0.0.0.0/0", TargetType: cres.RouteTargetNATGateway, TargetIID: cres.IID{NameId: "nat-01"}}
+	if _, err := cmrt.AddRoute(connectionName, "vpc-01", "", route); err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := cmrt.DeleteNATGateway(connectionName, cmrt.NATGATEWAY, "nat-01", "false"); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// the target is not registered anymore
+	if _, err := cmrt.RemoveRoute(connectionName, "vpc-01", "", route); err == nil {
+		t.Error("A route to an unregistered target without the SystemId should be failed!")
+	}
+
+	route.TargetIID = cres.IID{SystemId: natInfo.IId.SystemId}
+	result, err := cmrt.RemoveRoute(connectionName, "vpc-01", "", route)
+	if err != nil || !result {
+		t.Fatalf("The route should be removed with the SystemId: %v", err)
+	}
+
+	rtInfo, err := cmrt.GetRouteTable(connectionName, "vpc-01", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for _, r := range rtInfo.Routes {
+		if r.TargetType == cres.RouteTargetNATGateway {
+			t.Errorf("The route should be removed: %+v", r)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"strings"
+	"testing"
+)
+
+func TestSGAuditChecks(t *testing.T) {
+	if _, err := cmrt.AuditSecurity("mock-connection", []string{"no-such-check"}); err == nil {
+		t.Error("An unknown audit check should be failed!")
+	}
+
+	noFinding := func(sgInfo *cres.SecurityInfo, auditCtx *cmrt.SGAuditContext) []cmrt.SGAuditFindingInfo {
+		return []cmrt.SGAuditFindingInfo{}
+	}
+	if err := cmrt.RegisterSGAuditCheck(cmrt.SGAuditCheck{Name: "test-check", Severity: "CRITICAL", Check: noFinding}); err == nil {
+		t.Error("An invalid Severity should be failed!")
+	}
+	if err := cmrt.RegisterSGAuditCheck(cmrt.SGAuditCheck{Name: cmrt.AUDIT_UNUSED_SG, Severity: cmrt.AUDIT_LOW, Check: noFinding}); err == nil {
+		t.Error("A duplicated check name should be failed!")
+	}
+	if err := cmrt.RegisterSGAuditCheck(cmrt.SGAuditCheck{Name: "test-check", Severity: cmrt.AUDIT_LOW, Check: noFinding}); err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() {
+		cmrt.UnregisterSGAuditCheck("test-check")
+	})
+
+	found := false
+	for _, name := range cmrt.ListSGAuditCheckNames() {
+		if name == "test-check" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("The registered check is not in the check list!")
+	}
+
+	if err := cmrt.UnregisterSGAuditCheck(cmrt.AUDIT_UNUSED_SG); err == nil {
+		t.Error("Removing a built-in check should be failed!")
+	}
+	if err := cmrt.UnregisterSGAuditCheck("no-such-check"); err == nil {
+		t.Error("Removing an unknown check should be failed!")
+	}
+}
+
+func TestSGAuditBuiltInChecks(t *testing.T) {
+	world := "0.0.0.0/0"
+	tcp := func(from, to, cidr string) cres.SecurityRuleInfo {
+		return cres.SecurityRuleInfo{Direction: "inbound", IPProtocol: "TCP", FromPort: from, ToPort: to, CIDR: cidr}
+	}
+	all := func(direction, cidr string) cres.SecurityRuleInfo {
+		return cres.SecurityRuleInfo{Direction: direction, IPProtocol: "ALL", FromPort: "-1", ToPort: "-1", CIDR: cidr}
+	}
+	withDesc := func(rule cres.SecurityRuleInfo, desc string) cres.SecurityRuleInfo {
+		rule.Description = desc
+		return rule
+	}
+	deny := tcp("22", "22", world)
+	deny.Action = cres.RuleDeny
+	outbound := tcp("22", "22", world)
+	outbound.Direction = "outbound"
+	sgRef := tcp("22", "22", "")
+	sgRef.SecurityGroupIID = &cres.IID{NameId: "sg-02", SystemId: "sg-02"}
+	icmp := cres.SecurityRuleInfo{Direction: "inbound", IPProtocol: "ICMP", FromPort: "-1", ToPort: "-1", CIDR: world}
+	udpAll := cres.SecurityRuleInfo{Direction: "inbound", IPProtocol: "UDP", FromPort: "-1", ToPort: "-1", CIDR: world}
+
+	testCases := []struct {
+		name      string
+		check     string
+		ruleList  []cres.SecurityRuleInfo
+		used      bool
+		expected  int    // number of findings
+		severity  string // overridden Severity of the first finding
+		messageOf string // a part of the message of the first finding
+	}{
+		{"ssh-world", cmrt.AUDIT_SENSITIVE_PORT_OPEN, []cres.SecurityRuleInfo{tcp("22", "22", world)}, false, 1, "", "SSH(22)"},
+		{"ssh-ipv6-world", cmrt.AUDIT_SENSITIVE_PORT_OPEN, []cres.SecurityRuleInfo{tcp("22", "22", "::/0")}, false, 1, "", "SSH(22)"},
+		{"ssh-private", cmrt.AUDIT_SENSITIVE_PORT_OPEN, []cres.SecurityRuleInfo{tcp("22", "22", "10.0.0.0/8")}, false, 0, "", ""},
+		{"ssh-deny", cmrt.AUDIT_SENSITIVE_PORT_OPEN, []cres.SecurityRuleInfo{deny}, false, 0, "", ""},
+		{"ssh-outbound", cmrt.AUDIT_SENSITIVE_PORT_OPEN, []cres.SecurityRuleInfo{outbound}, false, 0, "", ""},
+		{"ssh-sg-reference", cmrt.AUDIT_SENSITIVE_PORT_OPEN, []cres.SecurityRuleInfo{sgRef}, false, 0, "", ""},
+		{"range-with-ssh", cmrt.AUDIT_SENSITIVE_PORT_OPEN, []cres.SecurityRuleInfo{tcp("1", "1000", world)}, false, 1, "", "SSH(22)"},
+		{"range-with-db", cmrt.AUDIT_SENSITIVE_PORT_OPEN, []cres.SecurityRuleInfo{tcp("1400", "3400", world)}, false, 4, "", "MSSQL(1433)"},
+		{"all-ports", cmrt.AUDIT_SENSITIVE_PORT_OPEN, []cres.SecurityRuleInfo{all("inbound", world)}, false, 8, "", "SSH(22)"},
+		{"icmp", cmrt.AUDIT_SENSITIVE_PORT_OPEN, []cres.SecurityRuleInfo{icmp}, false, 0, "", ""},
+		{"https", cmrt.AUDIT_SENSITIVE_PORT_OPEN, []cres.SecurityRuleInfo{tcp("443", "443", world)}, false, 0, "", ""},
+
+		{"all-world", cmrt.AUDIT_ALL_PROTOCOL_INBOUND, []cres.SecurityRuleInfo{all("inbound", world)}, false, 1, "", "ALL protocols"},
+		{"all-private", cmrt.AUDIT_ALL_PROTOCOL_INBOUND, []cres.SecurityRuleInfo{all("inbound", "10.0.0.0/8")}, false, 1, cmrt.AUDIT_MEDIUM, "ALL protocols"},
+		{"all-outbound", cmrt.AUDIT_ALL_PROTOCOL_INBOUND, []cres.SecurityRuleInfo{all("outbound", world)}, false, 0, "", ""},
+		{"tcp-all-ports", cmrt.AUDIT_ALL_PROTOCOL_INBOUND, []cres.SecurityRuleInfo{tcp("1", "65535", world)}, false, 0, "", ""},
+
+		{"tcp-all-ports", cmrt.AUDIT_BROAD_PORT_RANGE, []cres.SecurityRuleInfo{tcp("1", "65535", world)}, false, 1, "", "65535 ports(1-65535)"},
+		{"max-range", cmrt.AUDIT_BROAD_PORT_RANGE, []cres.SecurityRuleInfo{tcp("1000", "1999", world)}, false, 0, "", ""},
+		{"over-max-range", cmrt.AUDIT_BROAD_PORT_RANGE, []cres.SecurityRuleInfo{tcp("1000", "2000", world)}, false, 1, "", "1001 ports"},
+		{"udp-all-ports", cmrt.AUDIT_BROAD_PORT_RANGE, []cres.SecurityRuleInfo{udpAll}, false, 1, "", "65536 ports"},
+		{"all-protocol", cmrt.AUDIT_BROAD_PORT_RANGE, []cres.SecurityRuleInfo{all("inbound", world)}, false, 0, "", ""},
+		{"icmp", cmrt.AUDIT_BROAD_PORT_RANGE, []cres.SecurityRuleInfo{icmp}, false, 0, "", ""},
+
+		{"unused", cmrt.AUDIT_UNUSED_SG, nil, false, 1, "", "not used"},
+		{"used", cmrt.AUDIT_UNUSED_SG, nil, true, 0, "", ""},
+
+		{"duplicate", cmrt.AUDIT_DUPLICATE_RULE, []cres.SecurityRuleInfo{tcp("22", "22", world), tcp("22", "22", world)}, false, 1, "", "duplicated"},
+		{"triplicate", cmrt.AUDIT_DUPLICATE_RULE, []cres.SecurityRuleInfo{tcp("22", "22", world), tcp("22", "22", world), tcp("22", "22", world)}, false, 2, "", "duplicated"},
+		{"different-cidr", cmrt.AUDIT_DUPLICATE_RULE, []cres.SecurityRuleInfo{tcp("22", "22", world), tcp("22", "22", "10.0.0.0/8")}, false, 0, "", ""},
+		{"different-desc", cmrt.AUDIT_DUPLICATE_RULE, []cres.SecurityRuleInfo{withDesc(tcp("22", "22", world), "a"), withDesc(tcp("22", "22", world), "b")}, false, 0, "", ""},
+		{"unreported-desc", cmrt.AUDIT_DUPLICATE_RULE, []cres.SecurityRuleInfo{withDesc(tcp("22", "22", world), "a"), tcp("22", "22", world)}, false, 1, "", "duplicated"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.check+"/"+tc.name, func(t *testing.T) {
+			check, err := cmrt.GetSGAuditCheck(tc.check)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+
+			sgInfo := cres.SecurityInfo{IId: cres.IID{NameId: "sg-01", SystemId: "sg-01"}}
+			if tc.ruleList != nil {
+				ruleList := tc.ruleList
+				sgInfo.SecurityRules = &ruleList
+			}
+			auditCtx := cmrt.SGAuditContext{ConnectionName: "mock-connection", UsedSGIDs: map[string]bool{}}
+			if tc.used {
+				auditCtx.UsedSGIDs["sg-01"] = true
+			}
+
+			findingList := check.Check(&sgInfo, &auditCtx)
+			if len(findingList) != tc.expected {
+				t.Fatalf("expected %d findings, got %d: %+v", tc.expected, len(findingList), findingList)
+			}
+			if tc.expected == 0 {
+				return
+			}
+			if findingList[0].Severity != tc.severity {
+				t.Errorf("expected the Severity '%s', got '%s'", tc.severity, findingList[0].Severity)
+			}
+			if !strings.Contains(findingList[0].Message, tc.messageOf) {
+				t.Errorf("The message should contain '%s': %s", tc.messageOf, findingList[0].Message)
+			}
+		})
+	}
+
+	if _, err := cmrt.GetSGAuditCheck("no-such-check"); err == nil {
+		t.Error("An unknown audit check should be failed!")
+	}
+}
@@ -0,0 +1,112 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestSyncRules(t *testing.T) {
+	connectionName := setUpMockConnection(t)
+	setUpMockVMNetwork(t, connectionName)
+
+	ssh := cres.SecurityRuleInfo{Direction: "inbound", IPProtocol: "TCP", FromPort: "22", ToPort: "22", CIDR: "0.0.0.0/0"}
+	https := cres.SecurityRuleInfo{Direction: "inbound", IPProtocol: "TCP", FromPort: "443", ToPort: "443", CIDR: "0.0.0.0/0"}
+	web := cres.SecurityRuleInfo{Direction: "inbound", IPProtocol: "TCP", FromPort: "8080", ToPort: "8080", CIDR: "0.0.0.0/0"}
+
+	// the Description and Action not returned by the driver do not make a diff
+	sshWithDesc := ssh
+	sshWithDesc.Description = "ssh from anywhere"
+	sshWithDesc.Action = cres.RuleAllow
+
+	// dry-run
+	syncInfo, err := cmrt.SyncRules(connectionName, "sg-01", []cres.SecurityRuleInfo{sshWithDesc, https, https}, true)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	checkSyncRulePorts(t, "dry-run", syncInfo, []string{"443"}, []string{}, []string{"22"})
+	checkSGRulePorts(t, connectionName, []string{"22"})
+
+	// sync
+	syncInfo, err = cmrt.SyncRules(connectionName, "sg-01", []cres.SecurityRuleInfo{sshWithDesc, https}, false)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	checkSyncRulePorts(t, "sync", syncInfo, []string{"443"}, []string{}, []string{"22"})
+	checkSGRulePorts(t, connectionName, []string{"22", "443"})
+
+	// the synced rules are settled
+	syncInfo, err = cmrt.SyncRules(connectionName, "sg-01", []cres.SecurityRuleInfo{sshWithDesc, https}, true)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	checkSyncRulePorts(t, "settled", syncInfo, []string{}, []string{}, []string{"22", "443"})
+
+	// replace
+	syncInfo, err = cmrt.SyncRules(connectionName, "sg-01", []cres.SecurityRuleInfo{https, web}, false)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	checkSyncRulePorts(t, "replace", syncInfo, []string{"8080"}, []string{"22"}, []string{"443"})
+	checkSGRulePorts(t, connectionName, []string{"443", "8080"})
+
+	// the removed rules are restored, if adding the rules is failed
+	badRange := cres.SecurityRuleInfo{Direction: "inbound", IPProtocol: "TCP", FromPort: "80", ToPort: "70", CIDR: "0.0.0.0/0"}
+	_, err = cmrt.SyncRules(connectionName, "sg-01", []cres.SecurityRuleInfo{https, badRange}, false)
+	if err == nil || !strings.Contains(err.Error(), "1 removed rules") || !strings.Contains(err.Error(), "restored") {
+		t.Errorf("The failure should report the restored rules: %v", err)
+	}
+	checkSGRulePorts(t, connectionName, []string{"443", "8080"})
+
+	if _, err := cmrt.SyncRules(connectionName, "no-sg", []cres.SecurityRuleInfo{https}, true); err == nil {
+		t.Error("Syncing a non-existent SecurityGroup should be failed!")
+	}
+}
+
+func checkSyncRulePorts(t *testing.T, name string, syncInfo *cmrt.SecurityRuleSyncInfo, added []string, removed []string, unchanged []string) {
+	t.Helper()
+	if got := getRuleFromPorts(syncInfo.AddedRules); !isEqualStringList(got, added) {
+		t.Errorf("%s: AddedRules should be %v, but %v", name, added, got)
+	}
+	if got := getRuleFromPorts(syncInfo.RemovedRules); !isEqualStringList(got, removed) {
+		t.Errorf("%s: RemovedRules should be %v, but %v", name, removed, got)
+	}
+	if got := getRuleFromPorts(syncInfo.UnchangedRules); !isEqualStringList(got, unchanged) {
+		t.Errorf("%s: UnchangedRules should be %v, but %v", name, unchanged, got)
+	}
+}
+
+func checkSGRulePorts(t *testing.T, connectionName string, expected []string) {
+	t.Helper()
+	sgInfo, err := cmrt.GetSecurity(connectionName, cmrt.SG, "sg-01")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if got := getRuleFromPorts(*sgInfo.SecurityRules); !isEqualStringList(got, expected) {
+		t.Errorf("The rules of sg-01 should be %v, but %v", expected, got)
+	}
+}
+
+func getRuleFromPorts(ruleList []cres.SecurityRuleInfo) []string {
+	portList := []string{}
+	for _, rule := range ruleList {
+		portList = append(portList, rule.FromPort)
+	}
+	sort.Strings(portList)
+	return portList
+}
+
+func isEqualStringList(a []string, b []string) bool {
+	return strings.Join(a, ",") == strings.Join(b, ",")
+}
@@ -0,0 +1,184 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSGTemplateInvalidInputs(t *testing.T) {
+	ruleList := []cres.SecurityRuleInfo{
+		{Direction: "inbound", IPProtocol: "TCP", FromPort: "443", ToPort: "443", CIDR: "{{CIDR}}"},
+	}
+	paramList := []cmrt.SGTemplateParameterInfo{{Name: "CIDR"}}
+
+	if _, err := cmrt.CreateSGTemplate(cmrt.SGTemplateReqInfo{Name: "", ParameterList: paramList, RuleList: ruleList}); err == nil {
+		t.Error("An empty Name should be failed!")
+	}
+	if _, err := cmrt.CreateSGTemplate(cmrt.SGTemplateReqInfo{Name: "web", ParameterList: paramList}); err == nil {
+		t.Error("A template without rules should be failed!")
+	}
+
+	_, err := cmrt.CreateSGTemplate(cmrt.SGTemplateReqInfo{Name: "web", RuleList: ruleList})
+	if err == nil || !strings.Contains(err.Error(), "{{CIDR}}") {
+		t.Errorf("An undeclared placeholder should be failed: %v", err)
+	}
+
+	badParams := [][]cmrt.SGTemplateParameterInfo{
+		{{Name: "CIDR"}, {Name: "CIDR"}},
+		{{Name: "CIDR"}, {Name: "bad-name"}},
+	}
+	for _, params := range badParams {
+		if _, err := cmrt.CreateSGTemplate(cmrt.SGTemplateReqInfo{Name: "web", ParameterList: params, RuleList: ruleList}); err == nil {
+			t.Errorf("The parameters %v should be failed!", params)
+		}
+	}
+
+	sgRuleList := []cres.SecurityRuleInfo{
+		{Direction: "inbound", IPProtocol: "TCP", FromPort: "5432", ToPort: "5432", SecurityGroupIID: &cres.IID{NameId: "sg-01"}},
+	}
+	if _, err := cmrt.CreateSGTemplate(cmrt.SGTemplateReqInfo{Name: "postgres", RuleList: sgRuleList}); err == nil {
+		t.Error("A template referring to a SecurityGroup should be failed!")
+	}
+
+	if _, err := cmrt.UpdateSGTemplate("", cmrt.SGTemplateReqInfo{ParameterList: paramList, RuleList: ruleList}); err == nil {
+		t.Error("An empty Name should be failed!")
+	}
+	if _, err := cmrt.GetSGTemplate("", ""); err == nil {
+		t.Error("An empty Name should be failed!")
+	}
+	for _, version := range []string{"latest", "0", "-1"} {
+		if _, err := cmrt.GetSGTemplate("web", version); err == nil {
+			t.Errorf("The version '%s' should be failed!", version)
+		}
+	}
+	if _, err := cmrt.DeleteSGTemplate("", ""); err == nil {
+		t.Error("An empty Name should be failed!")
+	}
+	if _, err := cmrt.ExpandSGTemplates([]cmrt.SGTemplateRefInfo{{Name: ""}}); err == nil {
+		t.Error("An empty template Name should be failed!")
+	}
+}
+
+func TestSGTemplateAddRules(t *testing.T) {
+	connectionName := setUpMockConnection(t)
+	setUpMockVMNetwork(t, connectionName)
+
+	// templates are shared by all connections, so the name should be new for each run
+	name := fmt.Sprintf("web-%d", time.Now().UnixNano())
+	_, err := cmrt.CreateSGTemplate(cmrt.SGTemplateReqInfo{
+		Name:          name,
+		ParameterList: []cmrt.SGTemplateParameterInfo{{Name: "CIDR"}},
+		RuleList:      []cres.SecurityRuleInfo{{Direction: "inbound", IPProtocol: "TCP", FromPort: "80", ToPort: "80", CIDR: "{{CIDR}}"}},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() {
+		cmrt.DeleteSGTemplate(name, "")
+	})
+
+	info, err := cmrt.UpdateSGTemplate(name, cmrt.SGTemplateReqInfo{
+		ParameterList: []cmrt.SGTemplateParameterInfo{{Name: "CIDR"}, {Name: "PORT", Default: "443"}},
+		RuleList: []cres.SecurityRuleInfo{
+			{Direction: "inbound", IPProtocol: "TCP", FromPort: "80", ToPort: "80", CIDR: "{{CIDR}}"},
+			{Direction: "inbound", IPProtocol: "TCP", FromPort: "{{PORT}}", ToPort: "{{PORT}}", CIDR: "{{CIDR}}"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if info.Version != 2 {
+		t.Errorf("The update should be the version 2, got %d", info.Version)
+	}
+
+	cidrParam := cres.KeyValue{Key: "CIDR", Value: "10.0.0.0/16"}
+	testCases := []struct {
+		ref      cmrt.SGTemplateRefInfo
+		expected []string // FromPorts, nil: should be failed
+	}{
+		{cmrt.SGTemplateRefInfo{Name: name, ParameterList: []cres.KeyValue{cidrParam}}, []string{"443", "80"}},
+		{cmrt.SGTemplateRefInfo{Name: name, Version: "1", ParameterList: []cres.KeyValue{cidrParam}}, []string{"80"}},
+		{cmrt.SGTemplateRefInfo{Name: name, ParameterList: []cres.KeyValue{cidrParam, {Key: "PORT", Value: "8443"}}}, []string{"80", "8443"}},
+		{cmrt.SGTemplateRefInfo{Name: name}, nil},
+		{cmrt.SGTemplateRefInfo{Name: name, ParameterList: []cres.KeyValue{cidrParam, {Key: "UNKNOWN", Value: "1"}}}, nil},
+		{cmrt.SGTemplateRefInfo{Name: name, Version: "3", ParameterList: []cres.KeyValue{cidrParam}}, nil},
+	}
+	for _, tc := range testCases {
+		ruleList, err := cmrt.ExpandSGTemplates([]cmrt.SGTemplateRefInfo{tc.ref})
+		if tc.expected == nil {
+			if err == nil {
+				t.Errorf("%+v should be failed!", tc.ref)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%+v: %v", tc.ref, err)
+			continue
+		}
+		if got := getRuleFromPorts(ruleList); !isEqualStringList(got, tc.expected) {
+			t.Errorf("%+v: expected %v, got %v", tc.ref, tc.expected, got)
+		}
+		for _, rule := range ruleList {
+			if rule.CIDR != cidrParam.Value {
+				t.Errorf("%+v: the CIDR should be %s, got %s", tc.ref, cidrParam.Value, rule.CIDR)
+			}
+		}
+	}
+
+	_, err = cmrt.AddRules(connectionName, "sg-01", nil, []cmrt.SGTemplateRefInfo{
+		{Name: name, ParameterList: []cres.KeyValue{cidrParam, {Key: "PORT", Value: "8443"}}},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	checkSGRulePorts(t, connectionName, []string{"22", "80", "8443"})
+}
+
+func TestUpdateSGTemplateConcurrently(t *testing.T) {
+	name := fmt.Sprintf("web-%d", time.Now().UnixNano())
+	reqInfo := cmrt.SGTemplateReqInfo{
+		Name:     name,
+		RuleList: []cres.SecurityRuleInfo{{Direction: "inbound", IPProtocol: "TCP", FromPort: "80", ToPort: "80"}},
+	}
+	if _, err := cmrt.CreateSGTemplate(reqInfo); err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() {
+		cmrt.DeleteSGTemplate(name, "")
+	})
+
+	// each update gets its own next version
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cmrt.UpdateSGTemplate(name, reqInfo); err != nil {
+				t.Error(err.Error())
+			}
+		}()
+	}
+	wg.Wait()
+
+	infoList, err := cmrt.ListSGTemplateVersion(name)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(infoList) != 6 {
+		t.Errorf("ListSGTemplateVersion: expected 6, got %d", len(infoList))
+	}
+}
@@ -0,0 +1,93 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"strings"
+	"testing"
+)
+
+func TestSubnetUsageUpdateInvalidInputs(t *testing.T) {
+	if _, err := cmrt.GetSubnetUsage("", "vpc-01", "subnet-01"); err == nil {
+		t.Error("An empty connection name should be failed!")
+	}
+	if _, err := cmrt.GetSubnetUsage("mock-connection", "vpc-01", ""); err == nil {
+		t.Error("An empty subnet name should be failed!")
+	}
+
+	autoAssign := true
+	if _, err := cmrt.UpdateSubnet("mock-connection", "", "subnet-01", cres.SubnetUpdateInfo{AutoAssignPublicIP: &autoAssign}); err == nil {
+		t.Error("An empty VPC name should be failed!")
+	}
+
+	_, err := cmrt.UpdateSubnet("mock-connection", "vpc-01", "subnet-01", cres.SubnetUpdateInfo{})
+	if err == nil || !strings.Contains(err.Error(), "nothing to update") {
+		t.Errorf("An empty update should be failed: %v", err)
+	}
+
+	_, err = cmrt.UpdateSubnet("mock-connection", "vpc-01", "subnet-01", cres.SubnetUpdateInfo{RouteTableIID: &cres.IID{}})
+	if err == nil || !strings.Contains(err.Error(), "RouteTableIID") {
+		t.Errorf("An empty RouteTableIID should be failed: %v", err)
+	}
+}
+
+func TestSubnetUsageUpdate(t *testing.T) {
+	connectionName := setUpMockConnection(t)
+	setUpMockVMNetwork(t, connectionName)
+
+	usageInfo, err := cmrt.GetSubnetUsage(connectionName, "vpc-01", "subnet-01")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if usageInfo.SubnetIID.NameId != "subnet-01" || usageInfo.IPv4_CIDR != "10.0.1.0/24" {
+		t.Errorf("unexpected Subnet: %+v", usageInfo)
+	}
+	if usageInfo.TotalIPCount != 256 || usageInfo.UsedIPCount != 0 ||
+		usageInfo.AvailableIPCount != usageInfo.TotalIPCount-usageInfo.ReservedIPCount {
+		t.Errorf("unexpected usage of an empty /24 Subnet: %+v", usageInfo)
+	}
+
+	autoAssign := true
+	subnetInfo, err := cmrt.UpdateSubnet(connectionName, "vpc-01", "subnet-01", cres.SubnetUpdateInfo{AutoAssignPublicIP: &autoAssign})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if subnetInfo.IId.NameId != "subnet-01" || getKeyValue(subnetInfo.KeyValueList, "AutoAssignPublicIP") != "true" {
+		t.Errorf("AutoAssignPublicIP should be updated: %+v", subnetInfo)
+	}
+
+	// the update should be kept in the VPC
+	vpcInfo, err := cmrt.GetVPC(connectionName, cmrt.VPC, "vpc-01")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(vpcInfo.SubnetInfoList) != 1 || getKeyValue(vpcInfo.SubnetInfoList[0].KeyValueList, "AutoAssignPublicIP") != "true" {
+		t.Errorf("AutoAssignPublicIP should be kept in the VPC: %+v", vpcInfo.SubnetInfoList)
+	}
+
+	_, err = cmrt.UpdateSubnet(connectionName, "vpc-01", "subnet-01", cres.SubnetUpdateInfo{RouteTableIID: &cres.IID{NameId: "rtb-none"}})
+	if err == nil || !strings.Contains(err.Error(), "rtb-none") {
+		t.Errorf("A Route Table not in the VPC should be failed: %v", err)
+	}
+	if _, err := cmrt.GetSubnetUsage(connectionName, "vpc-01", "subnet-99"); err == nil {
+		t.Error("A Subnet not registered should be failed!")
+	}
+}
+
+func getKeyValue(kvList []cres.KeyValue, key string) string {
+	for _, kv := range kvList {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return ""
+}
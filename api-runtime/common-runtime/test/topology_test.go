@@ -0,0 +1,134 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"strings"
+	"testing"
+)
+
+func TestGetTopology(t *testing.T) {
+	connectionName := setUpMockConnection(t)
+	vmReqInfo := setUpMockVMNetwork(t, connectionName)
+
+	// VM with a data disk, and a NLB targeting the VM
+	vmReqInfo.IId = cres.IID{NameId: "vm-01"}
+	if _, err := cmrt.StartVM(connectionName, cmrt.VM, vmReqInfo, "OFF"); err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() {
+		cmrt.DeleteVM(connectionName, cmrt.VM, "vm-01", "true")
+	})
+	if _, err := cmrt.CreateDisk(connectionName, cmrt.DISK, cres.DiskInfo{IId: cres.IID{NameId: "disk-01"}, DiskType: "default", DiskSize: "default"}, "OFF"); err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() {
+		cmrt.DeleteDisk(connectionName, cmrt.DISK, "disk-01", "true")
+	})
+	if _, err := cmrt.AttachDisk(connectionName, "disk-01", "vm-01"); err != nil {
+		t.Fatal(err.Error())
+	}
+	nlbReqInfo := cres.NLBInfo{
+		IId:           cres.IID{NameId: "nlb-01"},
+		VpcIID:        cres.IID{NameId: "vpc-01"},
+		Type:          "PUBLIC",
+		Scope:         "REGION",
+		Listener:      cres.ListenerInfo{Protocol: "TCP", Port: "80"},
+		VMGroup:       cres.VMGroupInfo{Protocol: "TCP", Port: "80", VMs: &[]cres.IID{{NameId: "vm-01"}}},
+		HealthChecker: cres.HealthCheckerInfo{Protocol: "TCP", Port: "80", Interval: 10, Timeout: 10, Threshold: 3},
+	}
+	if _, err := cmrt.CreateNLB(connectionName, cmrt.NLB, nlbReqInfo, "OFF"); err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() {
+		cmrt.DeleteNLB(connectionName, cmrt.NLB, "nlb-01", "true")
+	})
+
+	topology, err := cmrt.GetTopology(connectionName)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(topology.ErrorList) != 0 {
+		t.Errorf("unexpected errors: %v", topology.ErrorList)
+	}
+
+	vpcId, subnetId, sgId := cmrt.VPC+":vpc-01", cmrt.SUBNET+":vpc-01/subnet-01", cmrt.SG+":sg-01"
+	vmId, diskId, nlbId := cmrt.VM+":vm-01", cmrt.DISK+":disk-01", cmrt.NLB+":nlb-01"
+
+	nodeMap := map[string]cmrt.TopologyNodeInfo{}
+	for _, node := range topology.NodeList {
+		nodeMap[node.Id] = node
+	}
+	for _, nodeId := range []string{vpcId, subnetId, sgId, vmId, diskId, nlbId} {
+		node, ok := nodeMap[nodeId]
+		if !ok {
+			t.Errorf("The topology does not have the node %s: %+v", nodeId, topology.NodeList)
+			continue
+		}
+		if !node.Managed || node.NameId == "" {
+			t.Errorf("The node %s should be managed: %+v", nodeId, node)
+		}
+	}
+
+	edgeMap := map[cmrt.TopologyEdgeInfo]bool{}
+	for _, edge := range topology.EdgeList {
+		edgeMap[edge] = true
+	}
+	for _, edge := range []cmrt.TopologyEdgeInfo{
+		{From: vpcId, To: subnetId, Relation: cmrt.TOPOLOGY_CONTAINS},
+		{From: vpcId, To: sgId, Relation: cmrt.TOPOLOGY_OWNS},
+		{From: vpcId, To: nlbId, Relation: cmrt.TOPOLOGY_OWNS},
+		{From: vmId, To: subnetId, Relation: cmrt.TOPOLOGY_ATTACHED},
+		{From: vmId, To: sgId, Relation: cmrt.TOPOLOGY_SECURED},
+		{From: vmId, To: diskId, Relation: cmrt.TOPOLOGY_ATTACHED},
+		{From: nlbId, To: vmId, Relation: cmrt.TOPOLOGY_TARGETS},
+	} {
+		if !edgeMap[edge] {
+			t.Errorf("The topology does not have the edge %+v: %+v", edge, topology.EdgeList)
+		}
+	}
+	if len(topology.EdgeList) != 7 {
+		t.Errorf("EdgeList: expected 7, got %d: %+v", len(topology.EdgeList), topology.EdgeList)
+	}
+
+	// the DOT has the edges of the mock resources
+	dot := topology.ToDOT()
+	if !strings.Contains(dot, `"`+nlbId+`" -> "`+vmId+`" [label="targets"]`) {
+		t.Errorf("The DOT does not have the NLB edge: %s", dot)
+	}
+}
+
+func TestTopologyToDOT(t *testing.T) {
+	if _, err := cmrt.GetTopology(""); err == nil {
+		t.Error("An empty connection name should be failed!")
+	}
+
+	topology := cmrt.TopologyInfo{
+		ConnectionName: "mock-connection",
+		NodeList: []cmrt.TopologyNodeInfo{
+			{Id: "vpc:vpc-01", ResourceType: cmrt.VPC, NameId: "vpc-01", Managed: true},
+			{Id: "subnet:vpc-01/subnet-01", ResourceType: cmrt.SUBNET, NameId: "subnet-01", Managed: true},
+			{Id: "subnet:/subnet-0a1b", ResourceType: cmrt.SUBNET, SystemId: "subnet-0a1b", Managed: false},
+			{Id: "vm:vm-01", ResourceType: cmrt.VM, NameId: "vm-01", Managed: true},
+		},
+		EdgeList: []cmrt.TopologyEdgeInfo{
+			{From: "vpc:vpc-01", To: "subnet:vpc-01/subnet-01", Relation: cmrt.TOPOLOGY_CONTAINS},
+			{From: "vm:vm-01", To: "subnet:vpc-01/subnet-01", Relation: cmrt.TOPOLOGY_ATTACHED},
+		},
+	}
+	dot := topology.ToDOT()
+	for _, want := range []string{`subgraph "cluster_vpc:vpc-01"`, `"subnet:/subnet-0a1b"`, `"vm:vm-01" -> "subnet:vpc-01/subnet-01" [label="attached"]`} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("The DOT does not have %s: %s", want, dot)
+		}
+	}
+}
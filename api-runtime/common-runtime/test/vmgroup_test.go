@@ -0,0 +1,201 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	ccm "github.com/cloud-barista/cb-spider/cloud-control-manager"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"testing"
+)
+
+func TestGetVMGroupNames(t *testing.T) {
+	testCases := []struct {
+		namePattern string
+		count       int
+		expected    []string
+	}{
+		{"worker-{index}", 3, []string{"worker-1", "worker-2", "worker-3"}},
+		{"{index}-batch", 2, []string{"1-batch", "2-batch"}},
+		{"worker", 2, []string{"worker-1", "worker-2"}},
+	}
+
+	for _, tc := range testCases {
+		nameList, err := cmrt.GetVMGroupNames(tc.namePattern, tc.count)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(nameList) != len(tc.expected) {
+			t.Fatalf("%s: expected %v, got %v", tc.namePattern, tc.expected, nameList)
+		}
+		for i, name := range nameList {
+			if name != tc.expected[i] {
+				t.Errorf("%s: expected %v, got %v", tc.namePattern, tc.expected, nameList)
+				break
+			}
+		}
+	}
+
+	// invalid inputs
+	if _, err := cmrt.GetVMGroupNames("", 3); err == nil {
+		t.Error("An empty name pattern should be failed!")
+	}
+	if _, err := cmrt.GetVMGroupNames("worker-{index}", 0); err == nil {
+		t.Error("The count 0 should be failed!")
+	}
+	if _, err := cmrt.GetVMGroupNames("worker-{index}", cmrt.MAX_VMGROUP_COUNT+1); err == nil {
+		t.Error("The count over MAX_VMGROUP_COUNT should be failed!")
+	}
+}
+
+func TestStartVMGroup(t *testing.T) {
+	connectionName := setUpMockConnection(t)
+	templateInfo := setUpMockVMNetwork(t, connectionName)
+
+	for _, maxConcurrency := range []int{-1, cmrt.MAX_VMGROUP_CONCURRENCY + 1} {
+		if _, err := cmrt.StartVMGroup(connectionName, cmrt.VM, templateInfo, "web-{index}", 2, maxConcurrency, true, "OFF"); err == nil {
+			t.Errorf("The max concurrency %d should be failed!", maxConcurrency)
+		}
+	}
+
+	// one at a time
+	resultList, err := cmrt.StartVMGroup(connectionName, cmrt.VM, templateInfo, "web-{index}", 3, 1, true, "OFF")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for _, result := range resultList {
+		if result.Status != cmrt.VMGROUP_SUCCEEDED || result.VMInfo == nil {
+			t.Errorf("%s: expected %s, got %s(%s)", result.Name, cmrt.VMGROUP_SUCCEEDED, result.Status, result.ErrorMSG)
+		}
+	}
+
+	// the members already exist
+	if _, err := cmrt.StartVMGroup(connectionName, cmrt.VM, templateInfo, "web-{index}", 3, 1, true, "OFF"); err == nil {
+		t.Error("A VM Group with the existing VM names should be failed!")
+	}
+
+	for _, result := range resultList {
+		if _, _, err := cmrt.DeleteVM(connectionName, cmrt.VM, result.Name, "false"); err != nil {
+			t.Error(err.Error())
+		}
+	}
+}
+
+func TestStartVMGroupInvalidTemplate(t *testing.T) {
+	connectionName := setUpMockConnection(t)
+	templateInfo := setUpMockVMNetwork(t, connectionName)
+
+	badSGInfo := templateInfo
+	badSGInfo.SecurityGroupIIDs = []cres.IID{{NameId: "sg-99"}}
+	badSpecInfo := templateInfo
+	badSpecInfo.VMSpecName = "mock-vmspec-99"
+
+	for _, badInfo := range []cres.VMReqInfo{badSGInfo, badSpecInfo} {
+		if _, err := cmrt.StartVMGroup(connectionName, cmrt.VM, badInfo, "web-{index}", 3, 2, true, "OFF"); err == nil {
+			t.Error("A VM Group with an invalid template should be failed!")
+		}
+	}
+
+	// no VM is started in Spider and in the CSP
+	vmList, err := cmrt.ListVM(connectionName, cmrt.VM)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(vmList) != 0 {
+		t.Errorf("ListVM: expected 0, got %d", len(vmList))
+	}
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	vmHandler, err := cldConn.CreateVMHandler()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	cspVMList, err := vmHandler.ListVM()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(cspVMList) != 0 {
+		t.Errorf("CSP ListVM: expected 0, got %d", len(cspVMList))
+	}
+}
+
+func TestStartVMGroupRollback(t *testing.T) {
+	connectionName := setUpMockConnection(t)
+	templateInfo := setUpMockVMNetwork(t, connectionName)
+
+	// a VM named "worker-2" exists only in the CSP, so the second member fails
+	cldConn, err := ccm.GetCloudConnection(connectionName)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	vmHandler, err := cldConn.CreateVMHandler()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	cspReqInfo := templateInfo
+	cspReqInfo.IId = cres.IID{NameId: "worker-2"}
+	if _, err := vmHandler.StartVM(cspReqInfo); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	resultList, err := cmrt.StartVMGroup(connectionName, cmrt.VM, templateInfo, "worker-{index}", 3, 2, true, "OFF")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := map[string]string{
+		"worker-1": cmrt.VMGROUP_ROLLED_BACK,
+		"worker-2": cmrt.VMGROUP_FAILED,
+		"worker-3": cmrt.VMGROUP_ROLLED_BACK,
+	}
+	for _, result := range resultList {
+		if result.Status != expected[result.Name] {
+			t.Errorf("%s: expected %s, got %s(%s)", result.Name, expected[result.Name], result.Status, result.ErrorMSG)
+		}
+		if result.VMInfo != nil {
+			t.Errorf("%s: VMInfo should be nil after the rollback", result.Name)
+		}
+	}
+
+	// the rolled back VMs are deleted in Spider and in the CSP
+	vmList, err := cmrt.ListVM(connectionName, cmrt.VM)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(vmList) != 0 {
+		t.Errorf("ListVM: expected 0, got %d", len(vmList))
+	}
+	cspVMList, err := vmHandler.ListVM()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(cspVMList) != 1 || cspVMList[0].IId.NameId != "worker-2" {
+		t.Errorf("only the CSP VM worker-2 should remain, got %d VMs", len(cspVMList))
+	}
+
+	// without the rollback, the succeeded members are kept
+	resultList, err = cmrt.StartVMGroup(connectionName, cmrt.VM, templateInfo, "worker-{index}", 3, 2, false, "OFF")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for _, result := range resultList {
+		if result.Name == "worker-2" {
+			continue
+		}
+		if result.Status != cmrt.VMGROUP_SUCCEEDED {
+			t.Errorf("%s: expected %s, got %s(%s)", result.Name, cmrt.VMGROUP_SUCCEEDED, result.Status, result.ErrorMSG)
+			continue
+		}
+		if _, _, err := cmrt.DeleteVM(connectionName, cmrt.VM, result.Name, "false"); err != nil {
+			t.Error(err.Error())
+		}
+	}
+}
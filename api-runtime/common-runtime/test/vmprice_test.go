@@ -0,0 +1,48 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	"testing"
+)
+
+func TestGetVMPrice(t *testing.T) {
+	connectionName := setUpMockConnection(t)
+
+	testCases := []struct {
+		purchaseOption string
+		expected       string // PricingPolicy, "": should be failed
+	}{
+		{"", "OnDemand"},
+		{"ondemand", "OnDemand"},
+		{"SPOT", "Spot"},
+		// the Mock Driver has no Preemptible price, the Spot price should not be returned
+		{"Preemptible", ""},
+		{"Reserved", ""},
+	}
+
+	for _, tc := range testCases {
+		policyList, err := cmrt.GetVMPrice(connectionName, "default", "mock.enhnace1", tc.purchaseOption)
+		if tc.expected == "" {
+			if err == nil {
+				t.Errorf("The PurchaseOption '%s' should be failed: %+v", tc.purchaseOption, policyList)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tc.purchaseOption, err)
+			continue
+		}
+		if len(policyList) != 1 || policyList[0].PricingPolicy != tc.expected {
+			t.Errorf("%s: expected a %s price, got %+v", tc.purchaseOption, tc.expected, policyList)
+		}
+	}
+}
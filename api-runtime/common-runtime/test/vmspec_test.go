@@ -0,0 +1,58 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package validatetest
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"testing"
+)
+
+func TestChangeVMSpec(t *testing.T) {
+	connectionName := setUpMockConnection(t)
+	vmReqInfo := setUpMockVMNetwork(t, connectionName)
+
+	vmReqInfo.IId = cres.IID{NameId: "vm-01"}
+	if _, err := cmrt.StartVM(connectionName, cmrt.VM, vmReqInfo, "OFF"); err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() {
+		cmrt.DeleteVM(connectionName, cmrt.VM, "vm-01", "true")
+	})
+
+	// rejected before the VM is suspended
+	for _, specName := range []string{"mock-vmspec-unknown", vmReqInfo.VMSpecName} {
+		if _, err := cmrt.ChangeVMSpec(connectionName, cmrt.VM, "vm-01", specName); err == nil {
+			t.Errorf("Changing to the VMSpec %s should be failed!", specName)
+		}
+		checkVMStatus(t, connectionName, "vm-01", cres.Running)
+	}
+
+	// the Running VM is suspended, changed and resumed
+	info, err := cmrt.ChangeVMSpec(connectionName, cmrt.VM, "vm-01", "mock-vmspec-02")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if info.IId.NameId != "vm-01" || info.VMSpecName != "mock-vmspec-02" {
+		t.Errorf("The VMSpec should be changed: %s, %s", info.IId.NameId, info.VMSpecName)
+	}
+	checkVMStatus(t, connectionName, "vm-01", cres.Running)
+}
+
+func checkVMStatus(t *testing.T, connectionName string, vmName string, expected cres.VMStatus) {
+	t.Helper()
+	status, err := cmrt.GetVMStatus(connectionName, cmrt.VM, vmName)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if status != expected {
+		t.Errorf("The VM %s should be %s, got %s", vmName, expected, status)
+	}
+}
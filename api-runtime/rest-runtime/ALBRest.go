@@ -0,0 +1,481 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package restruntime
+
+import (
+	"fmt"
+	"strings"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"strconv"
+)
+
+//================ ALB Handler
+
+// ALBCreateRequest represents the request body for creating an ALB.
+type ALBCreateRequest struct {
+	ConnectionName  string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	IDTransformMode string `json:"IDTransformMode,omitempty" validate:"omitempty" example:"ON"` // ON: transform CSP ID, OFF: no-transform CSP ID
+	ReqInfo         struct {
+		Name         string                  `json:"Name" validate:"required" example:"alb-01"`
+		VPCName      string                  `json:"VPCName" validate:"required" example:"vpc-01"`
+		Type         string                  `json:"Type" validate:"required" example:"PUBLIC"` // PUBLIC(V) | INTERNAL
+		Listeners    []ALBListenerRequest    `json:"Listeners" validate:"required"`
+		TargetGroups []ALBTargetGroupRequest `json:"TargetGroups" validate:"required"`
+		TagList      []cres.KeyValue         `json:"TagList,omitempty" validate:"omitempty"`
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// ALBListenerRequest represents the request body for a listener configuration in an ALB.
+type ALBListenerRequest struct {
+	Protocol           string           `json:"Protocol" validate:"required" example:"HTTPS"`                                 // HTTP|HTTPS
+	Port               string           `json:"Port" validate:"required" example:"443"`                                       // 1-65535
+	CertificateRef     string           `json:"CertificateRef,omitempty" validate:"omitempty" example:"arn:aws:acm:...:cert"` // required for HTTPS
+	DefaultTargetGroup string           `json:"DefaultTargetGroup" validate:"required" example:"web-tg"`
+	Rules              []ALBRuleRequest `json:"Rules,omitempty" validate:"omitempty"`
+}
+
+// ALBRuleRequest represents the request body for a host/path based routing rule of a listener.
+type ALBRuleRequest struct {
+	Priority    string `json:"Priority" validate:"required" example:"10"` // 1-50000, lower is evaluated first
+	Host        string `json:"Host,omitempty" validate:"omitempty" example:"api.example.com"`
+	PathPattern string `json:"PathPattern,omitempty" validate:"omitempty" example:"/api/*"`
+	TargetGroup string `json:"TargetGroup" validate:"required" example:"api-tg"`
+}
+
+// ALBTargetGroupRequest represents the request body for a Target Group in an ALB.
+type ALBTargetGroupRequest struct {
+	Name          string                  `json:"Name" validate:"required" example:"web-tg"`
+	Protocol      string                  `json:"Protocol" validate:"required" example:"HTTP"` // HTTP|HTTPS
+	Port          string                  `json:"Port" validate:"required" example:"8080"`     // 1-65535
+	VMs           []string                `json:"VMs" validate:"required" example:"vm-01"`
+	HealthChecker NLBHealthCheckerRequest `json:"HealthChecker,omitempty" validate:"omitempty"` // if not specified, follows the Target Group's Protocol and Port
+	StickySession ALBStickySessionRequest `json:"StickySession,omitempty" validate:"omitempty"`
+}
+
+// ALBStickySessionRequest represents the request body for the sticky session of a Target Group.
+type ALBStickySessionRequest struct {
+	Enabled    string `json:"Enabled,omitempty" validate:"omitempty" example:"true"`         // true | false(default)
+	Type       string `json:"Type,omitempty" validate:"omitempty" example:"LB_COOKIE"`       // LB_COOKIE(V) | APP_COOKIE
+	CookieName string `json:"CookieName,omitempty" validate:"omitempty" example:"SESSIONID"` // required for APP_COOKIE
+	Duration   string `json:"Duration,omitempty" validate:"omitempty" example:"86400"`       // secs, if not specified, treated as "default"(86400)
+}
+
+// ALBListResponse represents the response body for listing ALBs.
+type ALBListResponse struct {
+	Result []*cres.ALBInfo `json:"alb" validate:"required" description:"A list of ALB information"`
+}
+
+func convertALBListenerInfo(listenerReq ALBListenerRequest) (cres.ALBListenerInfo, error) {
+	listenerInfo := cres.ALBListenerInfo{
+		Protocol:           listenerReq.Protocol,
+		Port:               listenerReq.Port,
+		CertificateRef:     listenerReq.CertificateRef,
+		DefaultTargetGroup: listenerReq.DefaultTargetGroup,
+	}
+	for _, ruleReq := range listenerReq.Rules {
+		priority, err := strconv.Atoi(ruleReq.Priority)
+		if err != nil {
+			return cres.ALBListenerInfo{}, fmt.Errorf("Rule Priority '%s' is not a number!", ruleReq.Priority)
+		}
+		listenerInfo.Rules = append(listenerInfo.Rules, cres.ALBRuleInfo{
+			Priority:    priority,
+			Host:        ruleReq.Host,
+			PathPattern: ruleReq.PathPattern,
+			TargetGroup: ruleReq.TargetGroup,
+		})
+	}
+	return listenerInfo, nil
+}
+
+func convertTargetGroupInfo(tgReq ALBTargetGroupRequest) (cres.TargetGroupInfo, error) {
+	vmIIDList := []cres.IID{}
+	for _, vm := range tgReq.VMs {
+		vmIIDList = append(vmIIDList, cres.IID{NameId: vm, SystemId: ""})
+	}
+
+	healthChecker, err := convertHealthCheckerInfo(tgReq.HealthChecker)
+	if err != nil {
+		return cres.TargetGroupInfo{}, err
+	}
+
+	stickySession := cres.StickySessionInfo{
+		Enabled:    strings.ToLower(tgReq.StickySession.Enabled) == "true",
+		Type:       tgReq.StickySession.Type,
+		CookieName: tgReq.StickySession.CookieName,
+	}
+	switch strings.ToLower(tgReq.StickySession.Duration) {
+	case "default", "", "-1":
+	default:
+		stickySession.Duration, err = strconv.Atoi(tgReq.StickySession.Duration)
+		if err != nil {
+			return cres.TargetGroupInfo{}, fmt.Errorf("Sticky Session Duration '%s' is not a number!", tgReq.StickySession.Duration)
+		}
+	}
+
+	return cres.TargetGroupInfo{
+		Name:          tgReq.Name,
+		Protocol:      tgReq.Protocol,
+		Port:          tgReq.Port,
+		VMs:           &vmIIDList,
+		HealthChecker: healthChecker,
+		StickySession: stickySession,
+	}, nil
+}
+
+// createALB godoc
+// @ID create-alb
+// @Summary Create ALB
+// @Description Create a new Application Load Balancer (ALB) with HTTP/HTTPS Listeners, host/path routing Rules and Target Groups. <br> HTTPS Listeners require the CSP's TLS certificate ID(CertificateRef).
+// @Tags [ALB Management]
+// @Accept  json
+// @Produce  json
+// @Param ALBCreateRequest body restruntime.ALBCreateRequest true "Request body for creating an ALB"
+// @Success 200 {object} cres.ALBInfo "Details of the created ALB"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /alb [post]
+func CreateALB(c echo.Context) error {
+	cblog.Info("call CreateALB()")
+
+	req := ALBCreateRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Rest RegInfo => Driver ReqInfo
+	reqInfo := cres.ALBInfo{
+		IId:     cres.IID{NameId: req.ReqInfo.Name, SystemId: ""},
+		VpcIID:  cres.IID{NameId: req.ReqInfo.VPCName, SystemId: ""},
+		Type:    req.ReqInfo.Type,
+		TagList: req.ReqInfo.TagList,
+	}
+	for _, listenerReq := range req.ReqInfo.Listeners {
+		listenerInfo, err := convertALBListenerInfo(listenerReq)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		reqInfo.Listeners = append(reqInfo.Listeners, listenerInfo)
+	}
+	for _, tgReq := range req.ReqInfo.TargetGroups {
+		tgInfo, err := convertTargetGroupInfo(tgReq)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		reqInfo.TargetGroups = append(reqInfo.TargetGroups, tgInfo)
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.CreateALB(req.ConnectionName, ALB, reqInfo, req.IDTransformMode)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// listALB godoc
+// @ID list-alb
+// @Summary List ALBs
+// @Description Retrieve a list of Application Load Balancers (ALBs) associated with a specific connection.
+// @Tags [ALB Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection to list ALBs for"
+// @Success 200 {object} ALBListResponse "List of ALBs"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid query parameter"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /alb [get]
+func ListALB(c echo.Context) error {
+	cblog.Info("call ListALB()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ListALB(req.ConnectionName, ALB)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	jsonResult := ALBListResponse{
+		Result: result,
+	}
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// getALB godoc
+// @ID get-alb
+// @Summary Get ALB
+// @Description Retrieve details of a specific Application Load Balancer (ALB).
+// @Tags [ALB Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection to get an ALB for"
+// @Param Name path string true "The name of the ALB to retrieve"
+// @Success 200 {object} cres.ALBInfo "Details of the ALB"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /alb/{Name} [get]
+func GetALB(c echo.Context) error {
+	cblog.Info("call GetALB()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.GetALB(req.ConnectionName, ALB, c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// ALBTargetVMsRequest represents the request body for adding or removing VMs of a Target Group in an ALB.
+type ALBTargetVMsRequest struct {
+	ConnectionName string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	ReqInfo        struct {
+		VMs []string `json:"VMs" validate:"required" example:"vm-01"`
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// addALBTargetVMs godoc
+// @ID add-alb-target-vm
+// @Summary Add VMs to ALB Target Group
+// @Description Add a new set of VMs to a Target Group of an existing Application Load Balancer (ALB).
+// @Tags [ALB Management]
+// @Accept  json
+// @Produce  json
+// @Param Name path string true "The name of the ALB"
+// @Param TargetGroupName path string true "The name of the Target Group to add VMs to"
+// @Param ALBTargetVMsRequest body restruntime.ALBTargetVMsRequest true "Request body for adding VMs to a Target Group"
+// @Success 200 {object} cres.ALBInfo "Details of the ALB including the added VMs"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /alb/{Name}/targetgroup/{TargetGroupName}/vms [post]
+func AddALBTargetVMs(c echo.Context) error {
+	cblog.Info("call AddALBTargetVMs()")
+
+	var req ALBTargetVMsRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.AddALBTargetVMs(req.ConnectionName, c.Param("Name"), c.Param("TargetGroupName"), req.ReqInfo.VMs)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// removeALBTargetVMs godoc
+// @ID remove-alb-target-vm
+// @Summary Remove VMs from ALB Target Group
+// @Description Remove a set of VMs from a Target Group of an existing Application Load Balancer (ALB).
+// @Tags [ALB Management]
+// @Accept  json
+// @Produce  json
+// @Param Name path string true "The name of the ALB"
+// @Param TargetGroupName path string true "The name of the Target Group to remove VMs from"
+// @Param ALBTargetVMsRequest body restruntime.ALBTargetVMsRequest true "Request body for removing VMs from a Target Group"
+// @Success 200 {object} BooleanInfo "Result of the remove operation"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /alb/{Name}/targetgroup/{TargetGroupName}/vms [delete]
+func RemoveALBTargetVMs(c echo.Context) error {
+	cblog.Info("call RemoveALBTargetVMs()")
+
+	var req ALBTargetVMsRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.RemoveALBTargetVMs(req.ConnectionName, c.Param("Name"), c.Param("TargetGroupName"), req.ReqInfo.VMs)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+
+	return c.JSON(http.StatusOK, &resultInfo)
+}
+
+// ALBGetTargetGroupHealthInfoResponse represents the response body for retrieving the health information of a Target Group in an ALB.
+type ALBGetTargetGroupHealthInfoResponse struct {
+	Result cres.HealthInfo `json:"healthinfo" validate:"required" description:"Health information of the Target Group"`
+}
+
+// getALBTargetGroupHealthInfo godoc
+// @ID get-alb-targetgroup-healthinfo
+// @Summary Get ALB Target Group Health Info
+// @Description Retrieve the health information of the VMs in a Target Group of a specified Application Load Balancer (ALB).
+// @Tags [ALB Management]
+// @Accept  json
+// @Produce  json
+// @Param Name path string true "The name of the ALB"
+// @Param TargetGroupName path string true "The name of the Target Group to get the Health Info for"
+// @Param ConnectionName query string true "The name of the Connection"
+// @Success 200 {object} ALBGetTargetGroupHealthInfoResponse "Health information of the Target Group"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid query parameter"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /alb/{Name}/targetgroup/{TargetGroupName}/health [get]
+func GetALBTargetGroupHealthInfo(c echo.Context) error {
+	cblog.Info("call GetALBTargetGroupHealthInfo()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.GetALBTargetGroupHealthInfo(req.ConnectionName, c.Param("Name"), c.Param("TargetGroupName"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	jsonResult := ALBGetTargetGroupHealthInfoResponse{
+		Result: *result,
+	}
+
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// deleteALB godoc
+// @ID delete-alb
+// @Summary Delete ALB
+// @Description Delete a specified Application Load Balancer (ALB) with its Listeners, Rules and Target Groups.
+// @Tags [ALB Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionRequest body restruntime.ConnectionRequest true "Request body for deleting an ALB"
+// @Param Name path string true "The name of the ALB to delete"
+// @Param force query string false "Force delete the ALB. ex) true or false(default: false)"
+// @Success 200 {object} BooleanInfo "Result of the delete operation"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /alb/{Name} [delete]
+func DeleteALB(c echo.Context) error {
+	cblog.Info("call DeleteALB()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.DeleteALB(req.ConnectionName, ALB, c.Param("Name"), c.QueryParam("force"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+
+	return c.JSON(http.StatusOK, &resultInfo)
+}
+
+// countAllALBs godoc
+// @ID count-all-alb
+// @Summary Count All ALBs
+// @Description Get the total number of Application Load Balancers (ALBs) across all connections.
+// @Tags [ALB Management]
+// @Produce  json
+// @Success 200 {object} CountResponse "Total count of ALBs"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /countalb [get]
+func CountAllALBs(c echo.Context) error {
+	// Call common-runtime API to get count of ALBs
+	count, err := cmrt.CountAllALBs()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Prepare JSON result
+	var jsonResult struct {
+		Count int `json:"count"`
+	}
+	jsonResult.Count = int(count)
+
+	// Return JSON response
+	return c.JSON(http.StatusOK, jsonResult)
+}
+
+// countALBsByConnection godoc
+// @ID count-alb-by-connection
+// @Summary Count ALBs by Connection
+// @Description Get the total number of Application Load Balancers (ALBs) for a specific connection.
+// @Tags [ALB Management]
+// @Produce  json
+// @Param ConnectionName path string true "The name of the Connection"
+// @Success 200 {object} CountResponse "Total count of ALBs for the connection"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /countalb/{ConnectionName} [get]
+func CountALBsByConnection(c echo.Context) error {
+	// Call common-runtime API to get count of ALBs
+	count, err := cmrt.CountALBsByConnection(c.Param("ConnectionName"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Prepare JSON result
+	var jsonResult struct {
+		Count int `json:"count"`
+	}
+	jsonResult.Count = int(count)
+
+	// Return JSON response
+	return c.JSON(http.StatusOK, jsonResult)
+}
@@ -173,6 +173,7 @@ func getServicePort(env string) string {
 func RunServer() {
 
 	//======================================= setup routes
+	// the create routes wrapped with IdempotencyMiddleware honor the Idempotency-Key header
 	routes := []route{
 		//----------root
 		{"GET", "", aw.SpiderInfo},
@@ -244,6 +245,7 @@ func RunServer() {
 		{"GET", "/productfamily/:RegionName", ListProductFamily},
 		{"GET", "/priceinfo/:ProductFamily/:RegionName", GetPriceInfo},  // GET with a body for backward compatibility
 		{"POST", "/priceinfo/:ProductFamily/:RegionName", GetPriceInfo}, // POST with a body for standard
+		{"GET", "/vmprice/:VMSpecName/:RegionName", GetVMPrice},         // price by PurchaseOption(OnDemand, Spot, Preemptible)
 
 		//----------Image Handler
 		{"GET", "/vmimage", ListImage},
@@ -261,13 +263,15 @@ func RunServer() {
 		{"POST", "/regsubnet", RegisterSubnet},
 		{"DELETE", "/regsubnet/:Name", UnregisterSubnet},
 
-		{"POST", "/vpc", CreateVPC},
+		{"POST", "/vpc", IdempotencyMiddleware(CreateVPC)},
 		{"GET", "/vpc", ListVPC},
 		{"GET", "/vpc/:Name", GetVPC},
 		{"DELETE", "/vpc/:Name", DeleteVPC},
 		//-- for subnet
-		{"POST", "/vpc/:VPCName/subnet", AddSubnet},
+		{"POST", "/vpc/:VPCName/subnet", IdempotencyMiddleware(AddSubnet)},
 		{"GET", "/vpc/:VPCName/subnet/:Name", GetSubnet},
+		{"PUT", "/vpc/:VPCName/subnet/:Name", UpdateSubnet},
+		{"GET", "/vpc/:VPCName/subnet/:Name/usage", GetSubnetUsage},
 		{"DELETE", "/vpc/:VPCName/subnet/:SubnetName", RemoveSubnet},
 		{"DELETE", "/vpc/:VPCName/cspsubnet/:Id", RemoveCSPSubnet},
 		//-- for management
@@ -279,19 +283,79 @@ func RunServer() {
 		{"GET", "/countsubnet", CountAllSubnets},
 		{"GET", "/countsubnet/:ConnectionName", CountSubnetsByConnection},
 
+		//----------VPC Peering Handler
+		{"POST", "/vpcpeering", IdempotencyMiddleware(RequestVPCPeering)},
+		{"GET", "/vpcpeering", ListVPCPeering},
+		{"GET", "/vpcpeering/:Name", GetVPCPeering},
+		{"PUT", "/vpcpeering/:Name/accept", AcceptVPCPeering},
+		{"DELETE", "/vpcpeering/:Name", DeleteVPCPeering},
+		//-- for dashboard
+		{"GET", "/countvpcpeering", CountAllVPCPeerings},
+		{"GET", "/countvpcpeering/:ConnectionName", CountVPCPeeringsByConnection},
+
+		//----------Multi-Cloud VPN Handler
+		{"POST", "/multicloudvpn", IdempotencyMiddleware(CreateMultiCloudVPN)},
+		{"GET", "/multicloudvpn", ListMultiCloudVPN},
+		{"GET", "/multicloudvpn/:Name", GetMultiCloudVPN},
+		{"DELETE", "/multicloudvpn/:Name", DeleteMultiCloudVPN},
+
+		//----------Route Table Handler
+		{"GET", "/vpc/:VPCName/routetable", ListRouteTable},
+		{"GET", "/vpc/:VPCName/route", GetRouteTable},
+		{"POST", "/vpc/:VPCName/route", AddRoute},
+		{"DELETE", "/vpc/:VPCName/route", RemoveRoute},
+
+		//----------NAT Gateway Handler
+		{"POST", "/natgateway", IdempotencyMiddleware(CreateNATGateway)},
+		{"GET", "/natgateway", ListNATGateway},
+		{"GET", "/natgateway/:Name", GetNATGateway},
+		{"DELETE", "/natgateway/:Name", DeleteNATGateway},
+		//-- for dashboard
+		{"GET", "/countnatgateway", CountAllNATGateways},
+		{"GET", "/countnatgateway/:ConnectionName", CountNATGatewaysByConnection},
+
+		//----------DNS Handler
+		{"POST", "/dnszone", IdempotencyMiddleware(CreateDNSZone)},
+		{"GET", "/dnszone", ListDNSZone},
+		{"GET", "/dnszone/:Name", GetDNSZone},
+		{"DELETE", "/dnszone/:Name", DeleteDNSZone},
+		//-- for record
+		{"GET", "/dnszone/:ZoneName/record", ListDNSRecord},
+		{"PUT", "/dnszone/:ZoneName/record", UpsertDNSRecord},
+		{"GET", "/dnszone/:ZoneName/record/:Name", GetDNSRecord},
+		{"DELETE", "/dnszone/:ZoneName/record/:Name", DeleteDNSRecord},
+		//-- for dashboard
+		{"GET", "/countdnszone", CountAllDNSZones},
+		{"GET", "/countdnszone/:ConnectionName", CountDNSZonesByConnection},
+
+		//----------ALB Handler
+		{"POST", "/alb", IdempotencyMiddleware(CreateALB)},
+		{"GET", "/alb", ListALB},
+		{"GET", "/alb/:Name", GetALB},
+		{"DELETE", "/alb/:Name", DeleteALB},
+		//-- for target group
+		{"POST", "/alb/:Name/targetgroup/:TargetGroupName/vms", AddALBTargetVMs},
+		{"DELETE", "/alb/:Name/targetgroup/:TargetGroupName/vms", RemoveALBTargetVMs},
+		{"GET", "/alb/:Name/targetgroup/:TargetGroupName/health", GetALBTargetGroupHealthInfo},
+		//-- for dashboard
+		{"GET", "/countalb", CountAllALBs},
+		{"GET", "/countalb/:ConnectionName", CountALBsByConnection},
+
 		//----------SecurityGroup Handler
 		{"GET", "/getsecuritygroupowner", GetSGOwnerVPC},
 		{"POST", "/getsecuritygroupowner", GetSGOwnerVPC},
 		{"POST", "/regsecuritygroup", RegisterSecurity},
 		{"DELETE", "/regsecuritygroup/:Name", UnregisterSecurity},
 
-		{"POST", "/securitygroup", CreateSecurity},
+		{"POST", "/securitygroup", IdempotencyMiddleware(CreateSecurity)},
 		{"GET", "/securitygroup", ListSecurity},
+		{"GET", "/securitygroup/audit", AuditSecurity},
 		{"GET", "/securitygroup/:Name", GetSecurity},
 		{"DELETE", "/securitygroup/:Name", DeleteSecurity},
 		//-- for rule
 		{"POST", "/securitygroup/:SGName/rules", AddRules},
 		{"DELETE", "/securitygroup/:SGName/rules", RemoveRules}, // no force option
+		{"PUT", "/securitygroup/:SGName/rules", SyncRules},
 		// no CSP Option, {"DELETE", "/securitygroup/:SGName/csprules", RemoveCSPRules},
 		//-- for management
 		{"GET", "/allsecuritygroup", ListAllSecurity},
@@ -299,12 +363,19 @@ func RunServer() {
 		//-- for dashboard
 		{"GET", "/countsecuritygroup", CountAllSecurityGroups},
 		{"GET", "/countsecuritygroup/:ConnectionName", CountSecurityGroupsByConnection},
+		//-- rule templates shared by all connections
+		{"POST", "/sgtemplate", IdempotencyMiddleware(CreateSGTemplate)},
+		{"GET", "/sgtemplate", ListSGTemplate},
+		{"GET", "/sgtemplate/:Name", GetSGTemplate},
+		{"PUT", "/sgtemplate/:Name", UpdateSGTemplate},
+		{"DELETE", "/sgtemplate/:Name", DeleteSGTemplate},
+		{"GET", "/sgtemplate/:Name/version", ListSGTemplateVersion},
 
 		//----------KeyPair Handler
 		{"POST", "/regkeypair", RegisterKey},
 		{"DELETE", "/regkeypair/:Name", UnregisterKey},
 
-		{"POST", "/keypair", CreateKey},
+		{"POST", "/keypair", IdempotencyMiddleware(CreateKey)},
 		{"GET", "/keypair", ListKey},
 		{"GET", "/keypair/:Name", GetKey},
 		{"DELETE", "/keypair/:Name", DeleteKey},
@@ -333,7 +404,8 @@ func RunServer() {
 		{"POST", "/regvm", RegisterVM},
 		{"DELETE", "/regvm/:Name", UnregisterVM},
 
-		{"POST", "/vm", StartVM},
+		{"POST", "/vm", IdempotencyMiddleware(StartVM)},
+		{"POST", "/vmgroup", IdempotencyMiddleware(StartVMGroup)},
 		{"GET", "/vm", ListVM},
 		{"GET", "/vm/:Name", GetVM},
 		{"DELETE", "/vm/:Name", TerminateVM},
@@ -345,6 +417,9 @@ func RunServer() {
 		// only for AdminWeb
 		{"PUT", "/controlvm/:Name", ControlVM}, // suspend, resume, reboot
 
+		{"PUT", "/vm/:Name/spec", ChangeVMSpec},
+		{"GET", "/vm/:Name/console", GetVMConsoleOutput},
+
 		//-- for management
 		{"GET", "/allvm", ListAllVM},
 		{"DELETE", "/cspvm/:Id", TerminateCSPVM},
@@ -358,7 +433,7 @@ func RunServer() {
 		{"POST", "/regnlb", RegisterNLB},
 		{"DELETE", "/regnlb/:Name", UnregisterNLB},
 
-		{"POST", "/nlb", CreateNLB},
+		{"POST", "/nlb", IdempotencyMiddleware(CreateNLB)},
 		{"GET", "/nlb", ListNLB},
 		{"GET", "/nlb/:Name", GetNLB},
 		{"DELETE", "/nlb/:Name", DeleteNLB},
@@ -381,7 +456,7 @@ func RunServer() {
 		{"POST", "/regdisk", RegisterDisk},
 		{"DELETE", "/regdisk/:Name", UnregisterDisk},
 
-		{"POST", "/disk", CreateDisk},
+		{"POST", "/disk", IdempotencyMiddleware(CreateDisk)},
 		{"GET", "/disk", ListDisk},
 		{"GET", "/disk/:Name", GetDisk},
 		{"PUT", "/disk/:Name/size", IncreaseDiskSize},
@@ -401,7 +476,7 @@ func RunServer() {
 		{"POST", "/regmyimage", RegisterMyImage},
 		{"DELETE", "/regmyimage/:Name", UnregisterMyImage},
 
-		{"POST", "/myimage", SnapshotVM},
+		{"POST", "/myimage", IdempotencyMiddleware(SnapshotVM)},
 		{"GET", "/myimage", ListMyImage},
 		{"GET", "/myimage/:Name", GetMyImage},
 		{"DELETE", "/myimage/:Name", DeleteMyImage},
@@ -419,12 +494,12 @@ func RunServer() {
 		{"POST", "/regcluster", RegisterCluster},
 		{"DELETE", "/regcluster/:Name", UnregisterCluster},
 
-		{"POST", "/cluster", CreateCluster},
+		{"POST", "/cluster", IdempotencyMiddleware(CreateCluster)},
 		{"GET", "/cluster", ListCluster},
 		{"GET", "/cluster/:Name", GetCluster},
 		{"DELETE", "/cluster/:Name", DeleteCluster},
 		//-- for NodeGroup
-		{"POST", "/cluster/:Name/nodegroup", AddNodeGroup},
+		{"POST", "/cluster/:Name/nodegroup", IdempotencyMiddleware(AddNodeGroup)},
 		{"DELETE", "/cluster/:Name/nodegroup/:NodeGroupName", RemoveNodeGroup},
 		{"PUT", "/cluster/:Name/nodegroup/:NodeGroupName/onautoscaling", SetNodeGroupAutoScaling},
 		{"PUT", "/cluster/:Name/nodegroup/:NodeGroupName/autoscalesize", ChangeNodeGroupScaling},
@@ -447,6 +522,27 @@ func RunServer() {
 		//----------Destory All Resources in a Connection
 		{"DELETE", "/destroy", Destroy},
 
+		//----------Reconcile Spider IIDs and CSP Resources
+		{"GET", "/reconcile", ReconcileResources},
+		{"GET", "/reconcile/report", GetLastReconcileReport},
+		{"POST", "/reconcile/action", ApplyReconcileAction},
+		{"POST", "/reconcile/schedule", StartReconcileSchedule},
+		{"GET", "/reconcile/schedule", ListReconcileSchedule},
+		{"DELETE", "/reconcile/schedule/:ConnectionName", StopReconcileSchedule},
+
+		//----------Drift of Resource Attributes against Snapshots
+		{"GET", "/drift", DetectDrift},
+		{"PUT", "/drift/snapshot", UpdateDriftSnapshot},
+
+		//----------IPAM: CIDR Pools and Overlaps across Connections
+		{"POST", "/ipam/pool", IdempotencyMiddleware(CreateIPAMPool)},
+		{"GET", "/ipam/pool", ListIPAMPool},
+		{"DELETE", "/ipam/pool/:Name", DeleteIPAMPool},
+		{"GET", "/ipam/usage", GetIPAMUsage},
+
+		//----------Topology of the deployed Resources
+		{"GET", "/topology", GetTopology},
+
 		//----------checking TCP and UDP ports for NLB
 		{"GET", "/check/tcp", CheckTCPPort},
 		{"GET", "/check/udp", CheckUDPPort},
@@ -524,6 +620,9 @@ func RunServer() {
 	}
 	//======================================= setup routes
 
+	// record the CIDRs of the VPCs created before IPAM in the background
+	cr.StartIPAMBackfill()
+
 	// Run API Server
 	ApiServer(routes)
 
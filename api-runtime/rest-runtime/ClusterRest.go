@@ -178,6 +178,7 @@ type ClusterNodeGroupRequest struct {
 // @Accept  json
 // @Produce  json
 // @Param ClusterCreateRequest body restruntime.ClusterCreateRequest true "Request body for creating a Cluster"
+// @Param dryRun query boolean false "Check the request without creating the resource, returns a DryRunReportInfo"
 // @Success 200 {object} cres.ClusterInfo "Details of the created Cluster"
 // @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
 // @Failure 404 {object} SimpleMsg "Resource Not Found"
@@ -205,6 +206,18 @@ func CreateCluster(c echo.Context) error {
 		TagList:       req.ReqInfo.TagList,
 	}
 
+	dryRun, err := isDryRun(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if dryRun {
+		report, err := cmrt.DryRunCreateCluster(req.ConnectionName, CLUSTER, reqInfo, req.IDTransformMode)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		return c.JSON(http.StatusOK, report)
+	}
+
 	// Call common-runtime API
 	result, err := cmrt.CreateCluster(req.ConnectionName, CLUSTER, reqInfo, req.IDTransformMode)
 	if err != nil {
@@ -38,6 +38,11 @@ const (
 	MYIMAGE   string = string(cres.MYIMAGE)
 	CLUSTER   string = string(cres.CLUSTER)
 	NODEGROUP string = string(cres.NODEGROUP)
+
+	VPCPEERING string = string(cres.VPCPEERING)
+	NATGATEWAY string = string(cres.NATGATEWAY)
+	DNSZONE    string = string(cres.DNSZONE)
+	ALB        string = string(cres.ALB)
 )
 
 //================ Common Request & Response
@@ -332,3 +337,16 @@ func checkUDPPort(hostname string, port int) error {
 
 	return nil
 }
+
+// isDryRun returns true if the create request has the query parameter 'dryRun=true'
+func isDryRun(c echo.Context) (bool, error) {
+	dryRun := c.QueryParam("dryRun")
+	if dryRun == "" {
+		return false, nil
+	}
+	on, err := strconv.ParseBool(dryRun)
+	if err != nil {
+		return false, fmt.Errorf("%s is not a valid dryRun, use true or false!", dryRun)
+	}
+	return on, nil
+}
@@ -0,0 +1,425 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package restruntime
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"strconv"
+)
+
+//================ DNS Handler
+
+// DNSZoneCreateRequest represents the request body for creating a DNS Zone.
+type DNSZoneCreateRequest struct {
+	ConnectionName  string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	IDTransformMode string `json:"IDTransformMode,omitempty" validate:"omitempty" example:"ON"` // ON: transform CSP ID, OFF: no-transform CSP ID
+	ReqInfo         struct {
+		Name       string          `json:"Name" validate:"required" example:"zone-01"`
+		DomainName string          `json:"DomainName" validate:"required" example:"example.com"`
+		TagList    []cres.KeyValue `json:"TagList,omitempty" validate:"omitempty"`
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// DNSZoneListResponse represents the response body for listing DNS Zones.
+type DNSZoneListResponse struct {
+	Result []*cres.DNSZoneInfo `json:"dnszone" validate:"required" description:"A list of DNS Zone information"`
+}
+
+// DNSRecordUpsertRequest represents the request body for creating or replacing a DNS Record.
+type DNSRecordUpsertRequest struct {
+	ConnectionName string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	ReqInfo        struct {
+		Name   string   `json:"Name" validate:"required" example:"www"`           // relative name or FQDN, "@" for the apex
+		Type   string   `json:"Type" validate:"required" example:"A"`             // A | AAAA | CNAME | TXT
+		TTL    string   `json:"TTL,omitempty" validate:"omitempty" example:"300"` // default: 300
+		Values []string `json:"Values" validate:"required" example:"3.34.100.10"`
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// DNSRecordListResponse represents the response body for listing DNS Records.
+type DNSRecordListResponse struct {
+	Result []*cres.DNSRecordInfo `json:"dnsrecord" validate:"required" description:"A list of DNS Record information"`
+}
+
+// createDNSZone godoc
+// @ID create-dnszone
+// @Summary Create DNS Zone
+// @Description Create a new DNS hosted Zone for a domain.
+// @Tags [DNS Management]
+// @Accept  json
+// @Produce  json
+// @Param DNSZoneCreateRequest body restruntime.DNSZoneCreateRequest true "Request body for creating a DNS Zone"
+// @Success 200 {object} cres.DNSZoneInfo "Details of the created DNS Zone"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /dnszone [post]
+func CreateDNSZone(c echo.Context) error {
+	cblog.Info("call CreateDNSZone()")
+
+	req := DNSZoneCreateRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Rest RegInfo => Driver ReqInfo
+	reqInfo := cres.DNSZoneReqInfo{
+		IId:        cres.IID{NameId: req.ReqInfo.Name, SystemId: ""},
+		DomainName: req.ReqInfo.DomainName,
+		TagList:    req.ReqInfo.TagList,
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.CreateDNSZone(req.ConnectionName, DNSZONE, reqInfo, req.IDTransformMode)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// listDNSZone godoc
+// @ID list-dnszone
+// @Summary List DNS Zones
+// @Description Retrieve a list of DNS Zones associated with a specific connection.
+// @Tags [DNS Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection to list DNS Zones for"
+// @Success 200 {object} DNSZoneListResponse "List of DNS Zones"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid query parameter"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /dnszone [get]
+func ListDNSZone(c echo.Context) error {
+	cblog.Info("call ListDNSZone()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ListDNSZone(req.ConnectionName, DNSZONE)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	jsonResult := DNSZoneListResponse{
+		Result: result,
+	}
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// getDNSZone godoc
+// @ID get-dnszone
+// @Summary Get DNS Zone
+// @Description Retrieve details of a specific DNS Zone.
+// @Tags [DNS Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection to get a DNS Zone for"
+// @Param Name path string true "The name of the DNS Zone to retrieve"
+// @Success 200 {object} cres.DNSZoneInfo "Details of the DNS Zone"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /dnszone/{Name} [get]
+func GetDNSZone(c echo.Context) error {
+	cblog.Info("call GetDNSZone()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.GetDNSZone(req.ConnectionName, DNSZONE, c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// deleteDNSZone godoc
+// @ID delete-dnszone
+// @Summary Delete DNS Zone
+// @Description Delete a specified DNS Zone with its Records.
+// @Tags [DNS Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionRequest body restruntime.ConnectionRequest true "Request body for deleting a DNS Zone"
+// @Param Name path string true "The name of the DNS Zone to delete"
+// @Param force query string false "Force delete the DNS Zone. ex) true or false(default: false)"
+// @Success 200 {object} BooleanInfo "Result of the delete operation"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /dnszone/{Name} [delete]
+func DeleteDNSZone(c echo.Context) error {
+	cblog.Info("call DeleteDNSZone()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.DeleteDNSZone(req.ConnectionName, DNSZONE, c.Param("Name"), c.QueryParam("force"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+
+	return c.JSON(http.StatusOK, &resultInfo)
+}
+
+// listDNSRecord godoc
+// @ID list-dnsrecord
+// @Summary List DNS Records
+// @Description Retrieve a list of Records of a specific DNS Zone.
+// @Tags [DNS Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection"
+// @Param ZoneName path string true "The name of the DNS Zone"
+// @Success 200 {object} DNSRecordListResponse "List of DNS Records"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid query parameter"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /dnszone/{ZoneName}/record [get]
+func ListDNSRecord(c echo.Context) error {
+	cblog.Info("call ListDNSRecord()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ListDNSRecord(req.ConnectionName, c.Param("ZoneName"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	jsonResult := DNSRecordListResponse{
+		Result: result,
+	}
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// getDNSRecord godoc
+// @ID get-dnsrecord
+// @Summary Get DNS Record
+// @Description Retrieve a Record of a specific DNS Zone with the name and the type.
+// @Tags [DNS Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection"
+// @Param ZoneName path string true "The name of the DNS Zone"
+// @Param Name path string true "The name of the Record, relative name or FQDN"
+// @Param Type query string true "The type of the Record. ex) A, AAAA, CNAME, TXT"
+// @Success 200 {object} cres.DNSRecordInfo "Details of the DNS Record"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid query parameter"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /dnszone/{ZoneName}/record/{Name} [get]
+func GetDNSRecord(c echo.Context) error {
+	cblog.Info("call GetDNSRecord()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.GetDNSRecord(req.ConnectionName, c.Param("ZoneName"), c.Param("Name"), c.QueryParam("Type"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// upsertDNSRecord godoc
+// @ID upsert-dnsrecord
+// @Summary Upsert DNS Record
+// @Description Create a Record or replace the Record with the same name and type in a specific DNS Zone.
+// @Tags [DNS Management]
+// @Accept  json
+// @Produce  json
+// @Param ZoneName path string true "The name of the DNS Zone"
+// @Param DNSRecordUpsertRequest body restruntime.DNSRecordUpsertRequest true "Request body for creating or replacing a DNS Record"
+// @Success 200 {object} cres.DNSRecordInfo "Details of the DNS Record"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /dnszone/{ZoneName}/record [put]
+func UpsertDNSRecord(c echo.Context) error {
+	cblog.Info("call UpsertDNSRecord()")
+
+	req := DNSRecordUpsertRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	ttl := 0
+	if req.ReqInfo.TTL != "" {
+		var err error
+		ttl, err = strconv.Atoi(req.ReqInfo.TTL)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+	}
+
+	// Rest RegInfo => Driver ReqInfo
+	record := cres.DNSRecordInfo{
+		Name:   req.ReqInfo.Name,
+		Type:   cres.DNSRecordType(req.ReqInfo.Type),
+		TTL:    ttl,
+		Values: req.ReqInfo.Values,
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.UpsertDNSRecord(req.ConnectionName, c.Param("ZoneName"), record)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// deleteDNSRecord godoc
+// @ID delete-dnsrecord
+// @Summary Delete DNS Record
+// @Description Delete a Record of a specific DNS Zone with the name and the type.
+// @Tags [DNS Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionRequest body restruntime.ConnectionRequest true "Request body for deleting a DNS Record"
+// @Param ZoneName path string true "The name of the DNS Zone"
+// @Param Name path string true "The name of the Record, relative name or FQDN"
+// @Param Type query string true "The type of the Record. ex) A, AAAA, CNAME, TXT"
+// @Success 200 {object} BooleanInfo "Result of the delete operation"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /dnszone/{ZoneName}/record/{Name} [delete]
+func DeleteDNSRecord(c echo.Context) error {
+	cblog.Info("call DeleteDNSRecord()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.DeleteDNSRecord(req.ConnectionName, c.Param("ZoneName"), c.Param("Name"), c.QueryParam("Type"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+
+	return c.JSON(http.StatusOK, &resultInfo)
+}
+
+// countAllDNSZones godoc
+// @ID count-all-dnszone
+// @Summary Count All DNS Zones
+// @Description Get the total number of DNS Zones across all connections.
+// @Tags [DNS Management]
+// @Produce  json
+// @Success 200 {object} CountResponse "Total count of DNS Zones"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /countdnszone [get]
+func CountAllDNSZones(c echo.Context) error {
+	// Call common-runtime API to get count of DNS Zones
+	count, err := cmrt.CountAllDNSZones()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Prepare JSON result
+	var jsonResult struct {
+		Count int `json:"count"`
+	}
+	jsonResult.Count = int(count)
+
+	// Return JSON response
+	return c.JSON(http.StatusOK, jsonResult)
+}
+
+// countDNSZonesByConnection godoc
+// @ID count-dnszone-by-connection
+// @Summary Count DNS Zones by Connection
+// @Description Get the total number of DNS Zones for a specific connection.
+// @Tags [DNS Management]
+// @Produce  json
+// @Param ConnectionName path string true "The name of the Connection"
+// @Success 200 {object} CountResponse "Total count of DNS Zones for the connection"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /countdnszone/{ConnectionName} [get]
+func CountDNSZonesByConnection(c echo.Context) error {
+	// Call common-runtime API to get count of DNS Zones
+	count, err := cmrt.CountDNSZonesByConnection(c.Param("ConnectionName"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Prepare JSON result
+	var jsonResult struct {
+		Count int `json:"count"`
+	}
+	jsonResult.Count = int(count)
+
+	// Return JSON response
+	return c.JSON(http.StatusOK, jsonResult)
+}
@@ -123,6 +123,7 @@ type DiskCreateRequest struct {
 // @Accept  json
 // @Produce  json
 // @Param DiskCreateRequest body restruntime.DiskCreateRequest true "Request body for creating a Disk"
+// @Param dryRun query boolean false "Check the request without creating the resource, returns a DryRunReportInfo"
 // @Success 200 {object} cres.DiskInfo "Details of the created Disk"
 // @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
 // @Failure 404 {object} SimpleMsg "Resource Not Found"
@@ -146,6 +147,18 @@ func CreateDisk(c echo.Context) error {
 		TagList:  req.ReqInfo.TagList,
 	}
 
+	dryRun, err := isDryRun(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if dryRun {
+		report, err := cmrt.DryRunCreateDisk(req.ConnectionName, DISK, reqInfo, req.IDTransformMode)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		return c.JSON(http.StatusOK, report)
+	}
+
 	// Call common-runtime API
 	result, err := cmrt.CreateDisk(req.ConnectionName, DISK, reqInfo, req.IDTransformMode)
 	if err != nil {
@@ -0,0 +1,104 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package restruntime
+
+import (
+	"strings"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//================ Drift Handler
+
+// detectDrift godoc
+// @ID detect-drift
+// @Summary Detect Drift
+// @Description Diff the current CSP state of managed resources against the last-known snapshots field by field. <br> A resource without a snapshot is baselined with the current state.
+// @Tags [Drift Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection to detect the drift"
+// @Param ResourceType query string false "Comma-separated Resource Types to detect the drift, default is all: vpc,sg,keypair,vm,disk,myimage,nlb,cluster"
+// @Success 200 {object} cmrt.DriftReportInfo "Drift report of the connection"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid query parameter"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /drift [get]
+func DetectDrift(c echo.Context) error {
+	cblog.Info("call DetectDrift()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	rsTypeList := []string{}
+	if c.QueryParam("ResourceType") != "" {
+		for _, rsType := range strings.Split(c.QueryParam("ResourceType"), ",") {
+			rsTypeList = append(rsTypeList, strings.TrimSpace(rsType))
+		}
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.DetectDrift(req.ConnectionName, rsTypeList)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// DriftSnapshotRequest represents the request body for updating the snapshot of a resource.
+type DriftSnapshotRequest struct {
+	ConnectionName string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	ReqInfo        struct {
+		ResourceType string `json:"ResourceType" validate:"required" example:"vm"`
+		Name         string `json:"Name" validate:"required" example:"vm-01"`
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// updateDriftSnapshot godoc
+// @ID update-drift-snapshot
+// @Summary Update Drift Snapshot
+// @Description Accept the current CSP state of a resource as the new snapshot, to clear an accepted drift.
+// @Tags [Drift Management]
+// @Accept  json
+// @Produce  json
+// @Param DriftSnapshotRequest body restruntime.DriftSnapshotRequest true "Request body for updating the snapshot of a resource"
+// @Success 200 {object} cmrt.DriftResourceInfo "Baselined resource"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /drift/snapshot [put]
+func UpdateDriftSnapshot(c echo.Context) error {
+	cblog.Info("call UpdateDriftSnapshot()")
+
+	req := DriftSnapshotRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.UpdateResourceSnapshot(req.ConnectionName, req.ReqInfo.ResourceType, req.ReqInfo.Name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
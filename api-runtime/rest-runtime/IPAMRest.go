@@ -0,0 +1,147 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package restruntime
+
+import (
+	"strconv"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//================ IPAM Handler
+
+// IPAMPoolCreateRequest represents the request body for creating an IPAM Pool.
+type IPAMPoolCreateRequest struct {
+	ReqInfo struct {
+		Name               string `json:"Name" validate:"required" example:"pool-01"`
+		CIDR               string `json:"CIDR" validate:"required" example:"10.0.0.0/8"`
+		VPCPrefixLength    int    `json:"VPCPrefixLength,omitempty" validate:"omitempty" example:"16"`    // default: 16
+		SubnetPrefixLength int    `json:"SubnetPrefixLength,omitempty" validate:"omitempty" example:"24"` // default: 24
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// createIPAMPool godoc
+// @ID create-ipam-pool
+// @Summary Create IPAM Pool
+// @Description Create an IPv4 address pool to allocate the omitted VPC and Subnet CIDRs. <br> Pools are shared by all connections and must not overlap each other.
+// @Tags [IPAM Management]
+// @Accept  json
+// @Produce  json
+// @Param IPAMPoolCreateRequest body restruntime.IPAMPoolCreateRequest true "Request body for creating an IPAM Pool"
+// @Success 200 {object} cmrt.IPAMPoolInfo "Details of the created IPAM Pool"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /ipam/pool [post]
+func CreateIPAMPool(c echo.Context) error {
+	cblog.Info("call CreateIPAMPool()")
+
+	req := IPAMPoolCreateRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	reqInfo := cmrt.IPAMPoolInfo{
+		Name:               req.ReqInfo.Name,
+		CIDR:               req.ReqInfo.CIDR,
+		VPCPrefixLength:    req.ReqInfo.VPCPrefixLength,
+		SubnetPrefixLength: req.ReqInfo.SubnetPrefixLength,
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.CreateIPAMPool(reqInfo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+type IPAMPoolListResponse struct {
+	Result []*cmrt.IPAMPoolInfo `json:"pool" validate:"required" description:"A list of IPAM Pool information"`
+}
+
+// listIPAMPool godoc
+// @ID list-ipam-pool
+// @Summary List IPAM Pools
+// @Description Retrieve a list of IPAM Pools.
+// @Tags [IPAM Management]
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} IPAMPoolListResponse "List of IPAM Pools"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /ipam/pool [get]
+func ListIPAMPool(c echo.Context) error {
+	cblog.Info("call ListIPAMPool()")
+
+	// Call common-runtime API
+	result, err := cmrt.ListIPAMPool()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	var jsonResult IPAMPoolListResponse
+	jsonResult.Result = result
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// deleteIPAMPool godoc
+// @ID delete-ipam-pool
+// @Summary Delete IPAM Pool
+// @Description Delete an IPAM Pool which has no allocated VPC.
+// @Tags [IPAM Management]
+// @Accept  json
+// @Produce  json
+// @Param Name path string true "The name of the IPAM Pool to delete"
+// @Success 200 {object} BooleanInfo "Result of the delete operation"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /ipam/pool/{Name} [delete]
+func DeleteIPAMPool(c echo.Context) error {
+	cblog.Info("call DeleteIPAMPool()")
+
+	// Call common-runtime API
+	result, err := cmrt.DeleteIPAMPool(c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+
+	return c.JSON(http.StatusOK, &resultInfo)
+}
+
+// getIPAMUsage godoc
+// @ID get-ipam-usage
+// @Summary Get IPAM Usage
+// @Description Get the utilization of all IPAM Pools and the overlapping CIDRs between the VPCs of all connections.
+// @Tags [IPAM Management]
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} cmrt.IPAMUsageInfo "Utilization of IPAM Pools and overlapping CIDRs"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /ipam/usage [get]
+func GetIPAMUsage(c echo.Context) error {
+	cblog.Info("call GetIPAMUsage()")
+
+	// Call common-runtime API
+	result, err := cmrt.GetIPAMUsage()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,95 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package restruntime
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//================ Idempotency Middleware
+
+const IDEMPOTENCY_KEY_HEADER = "Idempotency-Key"
+const IDEMPOTENT_REPLAYED_HEADER = "Idempotent-Replayed"
+
+// keeps a copy of the response body to store the result of the request
+type idempotencyResponseWriter struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyResponseWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// IdempotencyMiddleware runs a create request with an Idempotency-Key only once,
+// it wraps the create handlers in the route table, ex) {"POST", "/vpc", IdempotencyMiddleware(CreateVPC)}.
+// A retried request returns the result of the first request with the Idempotent-Replayed header,
+// and a duplicate request in-flight waits for the first request.
+// The result is kept for every status code, except for a canceled or panicked request.
+func IdempotencyMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		key := c.Request().Header.Get(IDEMPOTENCY_KEY_HEADER)
+		if key == "" || c.Request().Method != http.MethodPost {
+			return next(c)
+		}
+		// a dry-run request does not create any resource, an invalid dryRun is rejected by the handler
+		if dryRun, err := isDryRun(c); dryRun || err != nil {
+			return next(c)
+		}
+
+		reqBody, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		c.Request().Body = io.NopCloser(bytes.NewReader(reqBody))
+
+		hash := sha256.New()
+		hash.Write([]byte(c.Request().Method + " " + c.Request().URL.RequestURI() + "\n"))
+		hash.Write(reqBody)
+		requestHash := hex.EncodeToString(hash.Sum(nil))
+
+		statusCode, body, replayed, err := cmrt.DoIdempotent(key, requestHash, func() (int, string, bool) {
+			writer := &idempotencyResponseWriter{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = writer
+			defer func() { c.Response().Writer = writer.ResponseWriter }()
+			if err := next(c); err != nil {
+				// write the error response now to keep it as the result
+				c.Error(err)
+			}
+			// the result of a canceled request is not kept, the client did not get it
+			finished := c.Request().Context().Err() == nil
+			return c.Response().Status, writer.body.String(), finished
+		})
+		if err != nil {
+			var conflictErr *cmrt.IdempotencyConflictError
+			if errors.As(err, &conflictErr) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		if !replayed {
+			return nil
+		}
+
+		c.Response().Header().Set(IDEMPOTENT_REPLAYED_HEADER, "true")
+		return c.Blob(statusCode, echo.MIMEApplicationJSONCharsetUTF8, []byte(body))
+	}
+}
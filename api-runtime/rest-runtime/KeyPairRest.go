@@ -119,6 +119,7 @@ type KeyPairCreateRequest struct {
 // @Accept  json
 // @Produce  json
 // @Param KeyPairCreateRequest body restruntime.KeyPairCreateRequest true "Request body for creating a KeyPair"
+// @Param dryRun query boolean false "Check the request without creating the resource, returns a DryRunReportInfo"
 // @Success 200 {object} cres.KeyPairInfo "Details of the created KeyPair"
 // @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
 // @Failure 404 {object} SimpleMsg "Resource Not Found"
@@ -139,6 +140,18 @@ func CreateKey(c echo.Context) error {
 		TagList: req.ReqInfo.TagList,
 	}
 
+	dryRun, err := isDryRun(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if dryRun {
+		report, err := cmrt.DryRunCreateKey(req.ConnectionName, KEY, reqInfo, req.IDTransformMode)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		return c.JSON(http.StatusOK, report)
+	}
+
 	// Call common-runtime API
 	result, err := cmrt.CreateKey(req.ConnectionName, KEY, reqInfo, req.IDTransformMode)
 	if err != nil {
@@ -0,0 +1,178 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package restruntime
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"strconv"
+)
+
+//================ Multi-Cloud VPN Handler
+
+// MultiCloudVPNCreateRequest represents the request body for creating a Multi-Cloud VPN.
+type MultiCloudVPNCreateRequest struct {
+	ConnectionName  string                    `json:"ConnectionName" validate:"required" example:"aws-seoul-connection"`
+	IDTransformMode string                    `json:"IDTransformMode,omitempty" validate:"omitempty" example:"ON"` // ON: transform CSP ID, OFF: no-transform CSP ID
+	ReqInfo         cmrt.MultiCloudVPNReqInfo `json:"ReqInfo" validate:"required"`
+}
+
+// MultiCloudVPNListResponse represents the response body for listing Multi-Cloud VPNs.
+type MultiCloudVPNListResponse struct {
+	Result []*cmrt.MultiCloudVPNInfo `json:"multicloudvpn" validate:"required" description:"A list of Multi-Cloud VPN information"`
+}
+
+// createMultiCloudVPN godoc
+// @ID create-multicloudvpn
+// @Summary Create Multi-Cloud VPN
+// @Description Create a site-to-site VPN between two VPCs registered in CB-Spider in different connections. <br> A VPN Gateway and a Tunnel are provisioned on both sides with the peer's public IP, CIDR and a shared pre-shared key. <br> The pre-shared key is generated if not specified and is returned only in this response.
+// @Tags [Multi-Cloud VPN Management]
+// @Accept  json
+// @Produce  json
+// @Param MultiCloudVPNCreateRequest body restruntime.MultiCloudVPNCreateRequest true "Request body for creating a Multi-Cloud VPN"
+// @Success 200 {object} cmrt.MultiCloudVPNInfo "Details of the created Multi-Cloud VPN"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /multicloudvpn [post]
+func CreateMultiCloudVPN(c echo.Context) error {
+	cblog.Info("call CreateMultiCloudVPN()")
+
+	req := MultiCloudVPNCreateRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.CreateMultiCloudVPN(req.ConnectionName, req.ReqInfo, req.IDTransformMode)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// listMultiCloudVPN godoc
+// @ID list-multicloudvpn
+// @Summary List Multi-Cloud VPNs
+// @Description Retrieve a list of Multi-Cloud VPNs created in a specific connection, with both halves of each VPN.
+// @Tags [Multi-Cloud VPN Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection to list Multi-Cloud VPNs for"
+// @Success 200 {object} MultiCloudVPNListResponse "List of Multi-Cloud VPNs"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid query parameter"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /multicloudvpn [get]
+func ListMultiCloudVPN(c echo.Context) error {
+	cblog.Info("call ListMultiCloudVPN()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ListMultiCloudVPN(req.ConnectionName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	jsonResult := MultiCloudVPNListResponse{
+		Result: result,
+	}
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// getMultiCloudVPN godoc
+// @ID get-multicloudvpn
+// @Summary Get Multi-Cloud VPN
+// @Description Retrieve details of a specific Multi-Cloud VPN. <br> The Status is Up only when the Tunnels of both sides are Up.
+// @Tags [Multi-Cloud VPN Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection to get a Multi-Cloud VPN for"
+// @Param Name path string true "The name of the Multi-Cloud VPN to retrieve"
+// @Success 200 {object} cmrt.MultiCloudVPNInfo "Details of the Multi-Cloud VPN"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /multicloudvpn/{Name} [get]
+func GetMultiCloudVPN(c echo.Context) error {
+	cblog.Info("call GetMultiCloudVPN()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.GetMultiCloudVPN(req.ConnectionName, c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// deleteMultiCloudVPN godoc
+// @ID delete-multicloudvpn
+// @Summary Delete Multi-Cloud VPN
+// @Description Delete a specified Multi-Cloud VPN with the Tunnels and VPN Gateways of both sides.
+// @Tags [Multi-Cloud VPN Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionRequest body restruntime.ConnectionRequest true "Request body for deleting a Multi-Cloud VPN"
+// @Param Name path string true "The name of the Multi-Cloud VPN to delete"
+// @Param force query string false "Force delete the Multi-Cloud VPN. ex) true or false(default: false)"
+// @Success 200 {object} BooleanInfo "Result of the delete operation"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /multicloudvpn/{Name} [delete]
+func DeleteMultiCloudVPN(c echo.Context) error {
+	cblog.Info("call DeleteMultiCloudVPN()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.DeleteMultiCloudVPN(req.ConnectionName, c.Param("Name"), c.QueryParam("force"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+
+	return c.JSON(http.StatusOK, &resultInfo)
+}
@@ -0,0 +1,245 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package restruntime
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"strconv"
+)
+
+//================ NAT Gateway Handler
+
+// NATGatewayCreateRequest represents the request body for creating a NAT Gateway.
+type NATGatewayCreateRequest struct {
+	ConnectionName  string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	IDTransformMode string `json:"IDTransformMode,omitempty" validate:"omitempty" example:"ON"` // ON: transform CSP ID, OFF: no-transform CSP ID
+	ReqInfo         struct {
+		Name       string          `json:"Name" validate:"required" example:"nat-01"`
+		VPCName    string          `json:"VPCName" validate:"required" example:"vpc-01"`
+		SubnetName string          `json:"SubnetName" validate:"required" example:"public-subnet-01"` // Subnet where the NAT Gateway is placed
+		TagList    []cres.KeyValue `json:"TagList,omitempty" validate:"omitempty"`
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// NATGatewayListResponse represents the response body for listing NAT Gateways.
+type NATGatewayListResponse struct {
+	Result []*cres.NATGatewayInfo `json:"natgateway" validate:"required" description:"A list of NAT Gateway information"`
+}
+
+// createNATGateway godoc
+// @ID create-natgateway
+// @Summary Create NAT Gateway
+// @Description Create a new NAT Gateway in a Subnet with an allocated public IP. <br> Add a route with the NATGateway target to the Route Table of private Subnets for outbound traffic.
+// @Tags [NAT Gateway Management]
+// @Accept  json
+// @Produce  json
+// @Param NATGatewayCreateRequest body restruntime.NATGatewayCreateRequest true "Request body for creating a NAT Gateway"
+// @Success 200 {object} cres.NATGatewayInfo "Details of the created NAT Gateway"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /natgateway [post]
+func CreateNATGateway(c echo.Context) error {
+	cblog.Info("call CreateNATGateway()")
+
+	req := NATGatewayCreateRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Rest RegInfo => Driver ReqInfo
+	reqInfo := cres.NATGatewayReqInfo{
+		IId:       cres.IID{NameId: req.ReqInfo.Name, SystemId: ""},
+		VpcIID:    cres.IID{NameId: req.ReqInfo.VPCName, SystemId: ""},
+		SubnetIID: cres.IID{NameId: req.ReqInfo.SubnetName, SystemId: ""},
+		TagList:   req.ReqInfo.TagList,
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.CreateNATGateway(req.ConnectionName, NATGATEWAY, reqInfo, req.IDTransformMode)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// listNATGateway godoc
+// @ID list-natgateway
+// @Summary List NAT Gateways
+// @Description Retrieve a list of NAT Gateways associated with a specific connection.
+// @Tags [NAT Gateway Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection to list NAT Gateways for"
+// @Success 200 {object} NATGatewayListResponse "List of NAT Gateways"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid query parameter"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /natgateway [get]
+func ListNATGateway(c echo.Context) error {
+	cblog.Info("call ListNATGateway()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ListNATGateway(req.ConnectionName, NATGATEWAY)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	jsonResult := NATGatewayListResponse{
+		Result: result,
+	}
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// getNATGateway godoc
+// @ID get-natgateway
+// @Summary Get NAT Gateway
+// @Description Retrieve details of a specific NAT Gateway.
+// @Tags [NAT Gateway Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection to get a NAT Gateway for"
+// @Param Name path string true "The name of the NAT Gateway to retrieve"
+// @Success 200 {object} cres.NATGatewayInfo "Details of the NAT Gateway"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /natgateway/{Name} [get]
+func GetNATGateway(c echo.Context) error {
+	cblog.Info("call GetNATGateway()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.GetNATGateway(req.ConnectionName, NATGATEWAY, c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// deleteNATGateway godoc
+// @ID delete-natgateway
+// @Summary Delete NAT Gateway
+// @Description Delete a specified NAT Gateway and release its public IP.
+// @Tags [NAT Gateway Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionRequest body restruntime.ConnectionRequest true "Request body for deleting a NAT Gateway"
+// @Param Name path string true "The name of the NAT Gateway to delete"
+// @Param force query string false "Force delete the NAT Gateway. ex) true or false(default: false)"
+// @Success 200 {object} BooleanInfo "Result of the delete operation"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /natgateway/{Name} [delete]
+func DeleteNATGateway(c echo.Context) error {
+	cblog.Info("call DeleteNATGateway()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.DeleteNATGateway(req.ConnectionName, NATGATEWAY, c.Param("Name"), c.QueryParam("force"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+
+	return c.JSON(http.StatusOK, &resultInfo)
+}
+
+// countAllNATGateways godoc
+// @ID count-all-natgateway
+// @Summary Count All NAT Gateways
+// @Description Get the total number of NAT Gateways across all connections.
+// @Tags [NAT Gateway Management]
+// @Produce  json
+// @Success 200 {object} CountResponse "Total count of NAT Gateways"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /countnatgateway [get]
+func CountAllNATGateways(c echo.Context) error {
+	// Call common-runtime API to get count of NAT Gateways
+	count, err := cmrt.CountAllNATGateways()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Prepare JSON result
+	var jsonResult struct {
+		Count int `json:"count"`
+	}
+	jsonResult.Count = int(count)
+
+	// Return JSON response
+	return c.JSON(http.StatusOK, jsonResult)
+}
+
+// countNATGatewaysByConnection godoc
+// @ID count-natgateway-by-connection
+// @Summary Count NAT Gateways by Connection
+// @Description Get the total number of NAT Gateways for a specific connection.
+// @Tags [NAT Gateway Management]
+// @Produce  json
+// @Param ConnectionName path string true "The name of the Connection"
+// @Success 200 {object} CountResponse "Total count of NAT Gateways for the connection"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /countnatgateway/{ConnectionName} [get]
+func CountNATGatewaysByConnection(c echo.Context) error {
+	// Call common-runtime API to get count of NAT Gateways
+	count, err := cmrt.CountNATGatewaysByConnection(c.Param("ConnectionName"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Prepare JSON result
+	var jsonResult struct {
+		Count int `json:"count"`
+	}
+	jsonResult.Count = int(count)
+
+	// Return JSON response
+	return c.JSON(http.StatusOK, jsonResult)
+}
@@ -172,6 +172,7 @@ type NLBListenerCreateRequest struct {
 // @Accept  json
 // @Produce  json
 // @Param NLBCreateRequest body restruntime.NLBCreateRequest true "Request body for creating an NLB"
+// @Param dryRun query boolean false "Check the request without creating the resource, returns a DryRunReportInfo"
 // @Success 200 {object} cres.NLBInfo "Details of the created NLB"
 // @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
 // @Failure 404 {object} SimpleMsg "Resource Not Found"
@@ -203,6 +204,18 @@ func CreateNLB(c echo.Context) error {
 	}
 	reqInfo.HealthChecker = healthChecker
 
+	dryRun, err := isDryRun(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if dryRun {
+		report, err := cmrt.DryRunCreateNLB(req.ConnectionName, NLB, reqInfo, req.IDTransformMode)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		return c.JSON(http.StatusOK, report)
+	}
+
 	// Call common-runtime API
 	result, err := cmrt.CreateNLB(req.ConnectionName, NLB, reqInfo, req.IDTransformMode)
 	if err != nil {
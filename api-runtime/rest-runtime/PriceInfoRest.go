@@ -113,3 +113,60 @@ func GetPriceInfo(c echo.Context) error {
 	json.Unmarshal([]byte(result), &response)
 	return c.JSON(http.StatusOK, response)
 }
+
+// VMPriceResponse represents the response body structure for the GetVMPrice API.
+type VMPriceResponse struct {
+	VMSpecName      string                 `json:"VMSpecName" validate:"required" example:"t3.medium"`
+	PurchaseOption  string                 `json:"PurchaseOption" validate:"required" example:"Spot"`
+	PricingPolicies []cres.PricingPolicies `json:"PricingPolicies" validate:"required"`
+}
+
+// getVMPrice godoc
+// @ID get-vm-price
+// @Summary Get VM Price by Purchase Option
+// @Description Retrieve the price of a VMSpec for a Purchase Option(OnDemand, Spot, Preemptible) in a specific Region.
+// @Tags [Cloud Metadata] Price
+// @Accept  json
+// @Produce  json
+// @Param VMSpecName path string true "The name of the VMSpec to retrieve the price for"
+// @Param RegionName path string true "The name of the Region to retrieve the price for"
+// @Param ConnectionName query string true "The name of the Connection to get the price for"
+// @Param PurchaseOption query string false "OnDemand(default) | Spot | Preemptible"
+// @Success 200 {object} VMPriceResponse "Pricing Policies of the VMSpec"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid query parameter"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /vmprice/{VMSpecName}/{RegionName} [get]
+func GetVMPrice(c echo.Context) error {
+	cblog.Info("call GetVMPrice()")
+
+	req := ConnectionRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// echo the normalized PurchaseOption, ex) "spot" => "Spot"
+	purchaseOption, err := cmrt.NormalizePurchaseOptionType(c.QueryParam("PurchaseOption"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.GetVMPrice(req.ConnectionName, c.Param("RegionName"), c.Param("VMSpecName"), string(purchaseOption))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	response := VMPriceResponse{
+		VMSpecName:      c.Param("VMSpecName"),
+		PurchaseOption:  string(purchaseOption),
+		PricingPolicies: result,
+	}
+	return c.JSON(http.StatusOK, &response)
+}
@@ -0,0 +1,240 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package restruntime
+
+import (
+	"strconv"
+	"strings"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//================ Reconcile Handler
+
+// reconcileResources godoc
+// @ID reconcile-resources
+// @Summary Reconcile Resources
+// @Description Report the drift between Spider's IIDs and the CSP's resources of a connection. <br> OnlySpiderList: stale Spider IIDs, OnlyCSPList: orphan CSP resources.
+// @Tags [Reconcile Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection to reconcile"
+// @Param ResourceType query string false "Comma-separated Resource Types to reconcile, default is all: vpc,sg,keypair,vm,disk,myimage,nlb,cluster"
+// @Success 200 {object} cmrt.ReconcileReportInfo "Drift report of the connection"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid query parameter"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /reconcile [get]
+func ReconcileResources(c echo.Context) error {
+	cblog.Info("call ReconcileResources()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	rsTypeList := []string{}
+	if c.QueryParam("ResourceType") != "" {
+		for _, rsType := range strings.Split(c.QueryParam("ResourceType"), ",") {
+			rsTypeList = append(rsTypeList, strings.TrimSpace(rsType))
+		}
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ReconcileResources(req.ConnectionName, rsTypeList)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// getLastReconcileReport godoc
+// @ID get-last-reconcile-report
+// @Summary Get Last Reconcile Report
+// @Description Retrieve the last drift report of a connection, by an on-demand or a scheduled reconcile.
+// @Tags [Reconcile Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection"
+// @Success 200 {object} cmrt.ReconcileReportInfo "Last drift report of the connection"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid query parameter"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /reconcile/report [get]
+func GetLastReconcileReport(c echo.Context) error {
+	cblog.Info("call GetLastReconcileReport()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.GetLastReconcileReport(req.ConnectionName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// ReconcileActionRequest represents the request body for a bulk reconcile action.
+type ReconcileActionRequest struct {
+	ConnectionName string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	ReqInfo        struct {
+		ResourceType string   `json:"ResourceType" validate:"required" example:"vm"`
+		Action       string   `json:"Action" validate:"required" example:"adopt"` // unregister | adopt | delete
+		IdList       []string `json:"IdList" validate:"required"`                 // NameIds for unregister, SystemIds for adopt and delete
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// ReconcileActionResponse represents the response body of a bulk reconcile action.
+type ReconcileActionResponse struct {
+	Result []*cmrt.ReconcileActionResultInfo `json:"Result" validate:"required"`
+}
+
+// applyReconcileAction godoc
+// @ID apply-reconcile-action
+// @Summary Apply Reconcile Action
+// @Description Apply a bulk action to the drift of a Resource Type. <br> unregister: unregister stale Spider IIDs(NameIds of OnlySpiderList). <br> adopt: register orphan CSP resources with generated names(SystemIds of OnlyCSPList). <br> delete: delete orphan CSP resources(SystemIds of OnlyCSPList). <br> All ids should be in the current drift.
+// @Tags [Reconcile Management]
+// @Accept  json
+// @Produce  json
+// @Param ReconcileActionRequest body restruntime.ReconcileActionRequest true "Request body for a bulk reconcile action"
+// @Success 200 {object} ReconcileActionResponse "Result of each id"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /reconcile/action [post]
+func ApplyReconcileAction(c echo.Context) error {
+	cblog.Info("call ApplyReconcileAction()")
+
+	req := ReconcileActionRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ApplyReconcileAction(req.ConnectionName, req.ReqInfo.ResourceType, req.ReqInfo.Action, req.ReqInfo.IdList)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, &ReconcileActionResponse{Result: result})
+}
+
+// ReconcileScheduleRequest represents the request body for starting a reconcile schedule.
+type ReconcileScheduleRequest struct {
+	ConnectionName string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	ReqInfo        struct {
+		Interval string `json:"Interval" validate:"required" example:"3600"` // secs, at least 60
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// ReconcileScheduleListResponse represents the response body for listing reconcile schedules.
+type ReconcileScheduleListResponse struct {
+	Result []*cmrt.ReconcileScheduleInfo `json:"schedule" validate:"required"`
+}
+
+// startReconcileSchedule godoc
+// @ID start-reconcile-schedule
+// @Summary Start Reconcile Schedule
+// @Description Reconcile a connection periodically. A scheduled reconcile only updates the last report without any action. <br> Schedules are kept in memory and cleared when the server restarts.
+// @Tags [Reconcile Management]
+// @Accept  json
+// @Produce  json
+// @Param ReconcileScheduleRequest body restruntime.ReconcileScheduleRequest true "Request body for starting a reconcile schedule"
+// @Success 200 {object} cmrt.ReconcileScheduleInfo "Started schedule"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /reconcile/schedule [post]
+func StartReconcileSchedule(c echo.Context) error {
+	cblog.Info("call StartReconcileSchedule()")
+
+	req := ReconcileScheduleRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	interval, err := strconv.Atoi(req.ReqInfo.Interval)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Interval should be a number(secs): "+err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.StartReconcileSchedule(req.ConnectionName, interval)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// listReconcileSchedule godoc
+// @ID list-reconcile-schedule
+// @Summary List Reconcile Schedules
+// @Description Retrieve all reconcile schedules.
+// @Tags [Reconcile Management]
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} ReconcileScheduleListResponse "List of reconcile schedules"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /reconcile/schedule [get]
+func ListReconcileSchedule(c echo.Context) error {
+	cblog.Info("call ListReconcileSchedule()")
+
+	// Call common-runtime API
+	result := cmrt.ListReconcileSchedule()
+
+	return c.JSON(http.StatusOK, &ReconcileScheduleListResponse{Result: result})
+}
+
+// stopReconcileSchedule godoc
+// @ID stop-reconcile-schedule
+// @Summary Stop Reconcile Schedule
+// @Description Stop the reconcile schedule of a connection.
+// @Tags [Reconcile Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName path string true "The name of the Connection"
+// @Success 200 {object} BooleanInfo "Result of the stop operation"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /reconcile/schedule/{ConnectionName} [delete]
+func StopReconcileSchedule(c echo.Context) error {
+	cblog.Info("call StopReconcileSchedule()")
+
+	// Call common-runtime API
+	result, err := cmrt.StopReconcileSchedule(c.Param("ConnectionName"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+
+	return c.JSON(http.StatusOK, &resultInfo)
+}
@@ -0,0 +1,194 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package restruntime
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"strconv"
+)
+
+//================ Route Table Handler
+
+// RouteRequest represents the request body for adding or removing a route.
+type RouteRequest struct {
+	ConnectionName string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	ReqInfo        struct {
+		SubnetName      string `json:"SubnetName,omitempty" validate:"omitempty" example:"subnet-01"` // empty: main Route Table of the VPC
+		DestinationCIDR string `json:"DestinationCIDR" validate:"required" example:"0.0.0.0/0"`
+		TargetType      string `json:"TargetType" validate:"required" example:"InternetGateway"`             // InternetGateway | NATGateway | VPCPeering | VM
+		TargetName      string `json:"TargetName,omitempty" validate:"omitempty" example:"nat-01"`           // empty for InternetGateway
+		TargetSystemId  string `json:"TargetSystemId,omitempty" validate:"omitempty" example:"nat-0a1b2c3d"` // RemoveRoute only, the SystemId of a target not registered anymore
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// RouteTableListResponse represents the response body for listing Route Tables.
+type RouteTableListResponse struct {
+	Result []*cres.RouteTableInfo `json:"routetable" validate:"required" description:"A list of Route Table information"`
+}
+
+func convertRouteInfo(req RouteRequest) cres.RouteInfo {
+	return cres.RouteInfo{
+		DestinationCIDR: req.ReqInfo.DestinationCIDR,
+		TargetType:      cres.RouteTargetType(req.ReqInfo.TargetType),
+		TargetIID:       cres.IID{NameId: req.ReqInfo.TargetName, SystemId: req.ReqInfo.TargetSystemId},
+	}
+}
+
+// listRouteTable godoc
+// @ID list-routetable
+// @Summary List Route Tables
+// @Description Retrieve a list of Route Tables of a specific VPC.
+// @Tags [VPC Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection"
+// @Param VPCName path string true "The name of the VPC"
+// @Success 200 {object} RouteTableListResponse "List of Route Tables"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid query parameter"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /vpc/{VPCName}/routetable [get]
+func ListRouteTable(c echo.Context) error {
+	cblog.Info("call ListRouteTable()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ListRouteTable(req.ConnectionName, c.Param("VPCName"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	jsonResult := RouteTableListResponse{
+		Result: result,
+	}
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// getRouteTable godoc
+// @ID get-routetable
+// @Summary Get Route Table
+// @Description Retrieve the Route Table applied to a Subnet, or the main Route Table of the VPC if SubnetName is not specified.
+// @Tags [VPC Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection"
+// @Param VPCName path string true "The name of the VPC"
+// @Param SubnetName query string false "The name of the Subnet"
+// @Success 200 {object} cres.RouteTableInfo "Details of the Route Table"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid query parameter"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /vpc/{VPCName}/route [get]
+func GetRouteTable(c echo.Context) error {
+	cblog.Info("call GetRouteTable()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.GetRouteTable(req.ConnectionName, c.Param("VPCName"), c.QueryParam("SubnetName"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// addRoute godoc
+// @ID add-route
+// @Summary Add Route
+// @Description Add a static route to the Route Table of a Subnet or the main Route Table of the VPC. <br> The target must be registered in CB-Spider except InternetGateway.
+// @Tags [VPC Management]
+// @Accept  json
+// @Produce  json
+// @Param VPCName path string true "The name of the VPC"
+// @Param RouteRequest body restruntime.RouteRequest true "Request body for adding a route"
+// @Success 200 {object} cres.RouteTableInfo "Details of the updated Route Table"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /vpc/{VPCName}/route [post]
+func AddRoute(c echo.Context) error {
+	cblog.Info("call AddRoute()")
+
+	req := RouteRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.AddRoute(req.ConnectionName, c.Param("VPCName"), req.ReqInfo.SubnetName, convertRouteInfo(req))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// removeRoute godoc
+// @ID remove-route
+// @Summary Remove Route
+// @Description Remove a static route from the Route Table of a Subnet or the main Route Table of the VPC. <br> A route to a target deleted or unregistered in Spider can be removed with TargetSystemId.
+// @Tags [VPC Management]
+// @Accept  json
+// @Produce  json
+// @Param VPCName path string true "The name of the VPC"
+// @Param RouteRequest body restruntime.RouteRequest true "Request body for removing a route"
+// @Success 200 {object} BooleanInfo "Result of the remove operation"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /vpc/{VPCName}/route [delete]
+func RemoveRoute(c echo.Context) error {
+	cblog.Info("call RemoveRoute()")
+
+	req := RouteRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.RemoveRoute(req.ConnectionName, c.Param("VPCName"), req.ReqInfo.SubnetName, convertRouteInfo(req))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+
+	return c.JSON(http.StatusOK, &resultInfo)
+}
@@ -0,0 +1,200 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package restruntime
+
+import (
+	"strconv"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//================ SecurityGroup Rule Template Handler
+
+// SGTemplateRequest represents the request body for creating or updating a SecurityGroup rule template.
+type SGTemplateRequest struct {
+	ReqInfo cmrt.SGTemplateReqInfo `json:"ReqInfo" validate:"required"`
+}
+
+// SGTemplateListResponse represents the response body for listing SecurityGroup rule templates.
+type SGTemplateListResponse struct {
+	Result []*cmrt.SGTemplateInfo `json:"sgtemplate" validate:"required" description:"A list of SecurityGroup rule template information"`
+}
+
+// createSGTemplate godoc
+// @ID create-sgtemplate
+// @Summary Create SecurityGroup Rule Template
+// @Description Create a named rule template as the version 1. <br> Templates are shared by all connections. <br> The string fields of the rules can refer to the parameters with "{{Name}}", ex) "CIDR": "{{CIDR}}".
+// @Tags [SecurityGroup Management]
+// @Accept  json
+// @Produce  json
+// @Param SGTemplateRequest body restruntime.SGTemplateRequest true "Request body for creating a SecurityGroup rule template"
+// @Success 200 {object} cmrt.SGTemplateInfo "Details of the created SecurityGroup rule template"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /sgtemplate [post]
+func CreateSGTemplate(c echo.Context) error {
+	cblog.Info("call CreateSGTemplate()")
+
+	req := SGTemplateRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.CreateSGTemplate(req.ReqInfo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// listSGTemplate godoc
+// @ID list-sgtemplate
+// @Summary List SecurityGroup Rule Templates
+// @Description Retrieve the latest version of each SecurityGroup rule template.
+// @Tags [SecurityGroup Management]
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} SGTemplateListResponse "List of SecurityGroup rule templates"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /sgtemplate [get]
+func ListSGTemplate(c echo.Context) error {
+	cblog.Info("call ListSGTemplate()")
+
+	// Call common-runtime API
+	result, err := cmrt.ListSGTemplate()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	jsonResult := SGTemplateListResponse{
+		Result: result,
+	}
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// listSGTemplateVersion godoc
+// @ID list-sgtemplate-version
+// @Summary List Versions of SecurityGroup Rule Template
+// @Description Retrieve all versions of a SecurityGroup rule template in ascending order.
+// @Tags [SecurityGroup Management]
+// @Accept  json
+// @Produce  json
+// @Param Name path string true "The name of the SecurityGroup rule template"
+// @Success 200 {object} SGTemplateListResponse "List of the versions"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /sgtemplate/{Name}/version [get]
+func ListSGTemplateVersion(c echo.Context) error {
+	cblog.Info("call ListSGTemplateVersion()")
+
+	// Call common-runtime API
+	result, err := cmrt.ListSGTemplateVersion(c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	jsonResult := SGTemplateListResponse{
+		Result: result,
+	}
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// getSGTemplate godoc
+// @ID get-sgtemplate
+// @Summary Get SecurityGroup Rule Template
+// @Description Retrieve a version of a SecurityGroup rule template, the latest version if the version is not specified.
+// @Tags [SecurityGroup Management]
+// @Accept  json
+// @Produce  json
+// @Param Name path string true "The name of the SecurityGroup rule template"
+// @Param version query string false "The version of the template, ex) 2 (default: latest)"
+// @Success 200 {object} cmrt.SGTemplateInfo "Details of the SecurityGroup rule template"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /sgtemplate/{Name} [get]
+func GetSGTemplate(c echo.Context) error {
+	cblog.Info("call GetSGTemplate()")
+
+	// Call common-runtime API
+	result, err := cmrt.GetSGTemplate(c.Param("Name"), c.QueryParam("version"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// updateSGTemplate godoc
+// @ID update-sgtemplate
+// @Summary Update SecurityGroup Rule Template
+// @Description Add a new version of a SecurityGroup rule template, the previous versions are kept. <br> The SecurityGroups created with the previous versions are not changed.
+// @Tags [SecurityGroup Management]
+// @Accept  json
+// @Produce  json
+// @Param Name path string true "The name of the SecurityGroup rule template"
+// @Param SGTemplateRequest body restruntime.SGTemplateRequest true "Request body for the new version, the Name is ignored"
+// @Success 200 {object} cmrt.SGTemplateInfo "Details of the new version"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /sgtemplate/{Name} [put]
+func UpdateSGTemplate(c echo.Context) error {
+	cblog.Info("call UpdateSGTemplate()")
+
+	req := SGTemplateRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.UpdateSGTemplate(c.Param("Name"), req.ReqInfo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// deleteSGTemplate godoc
+// @ID delete-sgtemplate
+// @Summary Delete SecurityGroup Rule Template
+// @Description Delete a version of a SecurityGroup rule template, all versions if the version is not specified. <br> The SecurityGroups created with the template are not changed.
+// @Tags [SecurityGroup Management]
+// @Accept  json
+// @Produce  json
+// @Param Name path string true "The name of the SecurityGroup rule template"
+// @Param version query string false "The version of the template to delete, ex) 1 (default: all versions)"
+// @Success 200 {object} BooleanInfo "Result of the delete operation"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /sgtemplate/{Name} [delete]
+func DeleteSGTemplate(c echo.Context) error {
+	cblog.Info("call DeleteSGTemplate()")
+
+	// Call common-runtime API
+	result, err := cmrt.DeleteSGTemplate(c.Param("Name"), c.QueryParam("version"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+
+	return c.JSON(http.StatusOK, &resultInfo)
+}
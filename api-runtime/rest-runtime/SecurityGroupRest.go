@@ -19,6 +19,7 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"strconv"
+	"strings"
 )
 
 //================ SecurityGroup Handler
@@ -112,17 +113,20 @@ type SecurityGroupCreateRequest struct {
 		VPCName       string                   `json:"VPCName" validate:"required" example:"vpc-01"`
 		SecurityRules *[]cres.SecurityRuleInfo `json:"SecurityRules" validate:"required"`
 		TagList       []dri.KeyValue           `json:"TagList,omitempty" validate:"omitempty"`
+
+		RuleTemplateList []cmrt.SGTemplateRefInfo `json:"RuleTemplateList,omitempty" validate:"omitempty"` // expanded into the rules, ex) [{"Name": "web", "ParameterList": [{"Key": "CIDR", "Value": "10.0.0.0/16"}]}]
 	} `json:"ReqInfo" validate:"required"`
 }
 
 // createSecurity godoc
 // @ID create-securitygroup
 // @Summary Create SecurityGroup
-// @Description Create a new Security Group with specified rules and tags. 🕷️ [[Concept Guide](https://github.com/cloud-barista/cb-spider/wiki/Security-Group-Rules-and-Driver-API)], 🕷️ [[User Guide](https://github.com/cloud-barista/cb-spider/wiki/features-and-usages#4-securitygroup-%EC%83%9D%EC%84%B1-%EB%B0%8F-%EC%A0%9C%EC%96%B4)]
+// @Description Create a new Security Group with specified rules and tags. The rule templates in RuleTemplateList are expanded and added to the rules. 🕷️ [[Concept Guide](https://github.com/cloud-barista/cb-spider/wiki/Security-Group-Rules-and-Driver-API)], 🕷️ [[User Guide](https://github.com/cloud-barista/cb-spider/wiki/features-and-usages#4-securitygroup-%EC%83%9D%EC%84%B1-%EB%B0%8F-%EC%A0%9C%EC%96%B4)]
 // @Tags [SecurityGroup Management]
 // @Accept  json
 // @Produce  json
 // @Param SecurityGroupCreateRequest body restruntime.SecurityGroupCreateRequest true "Request body for creating a SecurityGroup"
+// @Param dryRun query boolean false "Check the request without creating the resource, returns a DryRunReportInfo"
 // @Success 200 {object} cres.SecurityInfo "Details of the created SecurityGroup"
 // @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
 // @Failure 404 {object} SimpleMsg "Resource Not Found"
@@ -145,8 +149,20 @@ func CreateSecurity(c echo.Context) error {
 		TagList:       req.ReqInfo.TagList,
 	}
 
+	dryRun, err := isDryRun(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if dryRun {
+		report, err := cmrt.DryRunCreateSecurity(req.ConnectionName, SG, reqInfo, req.ReqInfo.RuleTemplateList, req.IDTransformMode)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		return c.JSON(http.StatusOK, report)
+	}
+
 	// Call common-runtime API
-	result, err := cmrt.CreateSecurity(req.ConnectionName, SG, reqInfo, req.IDTransformMode)
+	result, err := cmrt.CreateSecurity(req.ConnectionName, SG, reqInfo, req.ReqInfo.RuleTemplateList, req.IDTransformMode)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
@@ -347,14 +363,22 @@ type RuleControlRequest struct {
 			FromPort   string `json:"FromPort" validate:"required" example:"22"`
 			ToPort     string `json:"ToPort" validate:"required" example:"22"`
 			CIDR       string `json:"CIDR,omitempty" validate:"omitempty" example:"0.0.0.0/0(default)"`
+
+			Description string `json:"Description,omitempty" validate:"omitempty" example:"allow ssh"`
+			Priority    string `json:"Priority,omitempty" validate:"omitempty" example:"100"`
+			Action      string `json:"Action,omitempty" validate:"omitempty" example:"allow(default)"` // allow | deny
+
+			SecurityGroupIID *cres.IID `json:"SecurityGroupIID,omitempty" validate:"omitempty"` // referenced SG instead of CIDR, set NameId only
 		} `json:"RuleInfoList" validate:"required"`
+
+		RuleTemplateList []cmrt.SGTemplateRefInfo `json:"RuleTemplateList,omitempty" validate:"omitempty"` // AddRules only, expanded into the rules, rejected by RemoveRules and SyncRules
 	} `json:"ReqInfo" validate:"required"`
 }
 
 // addRules godoc
 // @ID add-rule
 // @Summary Add Rules to SecurityGroup
-// @Description Add new rules to a Security Group.
+// @Description Add new rules to a Security Group. <br> The rule templates in RuleTemplateList are expanded and added with the rules.
 // @Tags [SecurityGroup Management]
 // @Accept  json
 // @Produce  json
@@ -382,11 +406,17 @@ func AddRules(c echo.Context) error {
 			FromPort:   info.FromPort,
 			ToPort:     info.ToPort,
 			CIDR:       info.CIDR,
+
+			Description: info.Description,
+			Priority:    info.Priority,
+			Action:      info.Action,
+
+			SecurityGroupIID: info.SecurityGroupIID,
 		}
 		reqRuleInfoList = append(reqRuleInfoList, ruleInfo)
 	}
 
-	result, err := cmrt.AddRules(req.ConnectionName, c.Param("SGName"), reqRuleInfoList)
+	result, err := cmrt.AddRules(req.ConnectionName, c.Param("SGName"), reqRuleInfoList, req.ReqInfo.RuleTemplateList)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
@@ -397,7 +427,7 @@ func AddRules(c echo.Context) error {
 // removeRules godoc
 // @ID remove-rule
 // @Summary Remove Rules from SecurityGroup
-// @Description Remove existing rules from a Security Group.
+// @Description Remove existing rules from a Security Group. <br> RuleTemplateList is not supported, list the rules to remove in RuleInfoList.
 // @Tags [SecurityGroup Management]
 // @Accept  json
 // @Produce  json
@@ -417,6 +447,11 @@ func RemoveRules(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
+	// the templates are expanded only by AddRules, ignoring them here would drop their rules silently
+	if len(req.ReqInfo.RuleTemplateList) > 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "RuleTemplateList is not supported by RemoveRules, use RuleInfoList!")
+	}
+
 	reqRuleInfoList := []cres.SecurityRuleInfo{}
 	for _, info := range req.ReqInfo.RuleInfoList {
 		ruleInfo := cres.SecurityRuleInfo{
@@ -425,6 +460,12 @@ func RemoveRules(c echo.Context) error {
 			FromPort:   info.FromPort,
 			ToPort:     info.ToPort,
 			CIDR:       info.CIDR,
+
+			Description: info.Description,
+			Priority:    info.Priority,
+			Action:      info.Action,
+
+			SecurityGroupIID: info.SecurityGroupIID,
 		}
 		reqRuleInfoList = append(reqRuleInfoList, ruleInfo)
 	}
@@ -441,6 +482,107 @@ func RemoveRules(c echo.Context) error {
 	return c.JSON(http.StatusOK, &resultInfo)
 }
 
+// syncRules godoc
+// @ID sync-rule
+// @Summary Sync Rules of SecurityGroup
+// @Description Sync the rules of a Security Group to the desired rule set. <br> Rules not in the set are removed and missing rules are added, the applied diff is returned. <br> RuleTemplateList is not supported, list the full rule set in RuleInfoList.
+// @Tags [SecurityGroup Management]
+// @Accept  json
+// @Produce  json
+// @Param SGName path string true "The name of the SecurityGroup to sync rules"
+// @Param RuleControlRequest body restruntime.RuleControlRequest true "Request body with the full desired rule set"
+// @Param dryRun query boolean false "Compute the diff without applying it"
+// @Success 200 {object} cmrt.SecurityRuleSyncInfo "Diff of the rules applied to the SecurityGroup"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /securitygroup/{SGName}/rules [put]
+func SyncRules(c echo.Context) error {
+	cblog.Info("call SyncRules()")
+
+	req := RuleControlRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// the templates are expanded only by AddRules, ignoring them here would drop their rules silently
+	if len(req.ReqInfo.RuleTemplateList) > 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "RuleTemplateList is not supported by SyncRules, use RuleInfoList!")
+	}
+
+	reqRuleInfoList := []cres.SecurityRuleInfo{}
+	for _, info := range req.ReqInfo.RuleInfoList {
+		ruleInfo := cres.SecurityRuleInfo{
+			Direction:  info.Direction,
+			IPProtocol: info.IPProtocol,
+			FromPort:   info.FromPort,
+			ToPort:     info.ToPort,
+			CIDR:       info.CIDR,
+
+			Description: info.Description,
+			Priority:    info.Priority,
+			Action:      info.Action,
+
+			SecurityGroupIID: info.SecurityGroupIID,
+		}
+		reqRuleInfoList = append(reqRuleInfoList, ruleInfo)
+	}
+
+	dryRun, err := isDryRun(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	result, err := cmrt.SyncRules(req.ConnectionName, c.Param("SGName"), reqRuleInfoList, dryRun)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// auditSecurity godoc
+// @ID audit-securitygroup
+// @Summary Audit SecurityGroups
+// @Description Report risky rules of all managed Security Groups in a connection with severity levels. <br> Built-in checks: sensitive-port-open, all-protocol-inbound, broad-port-range, unused-sg, duplicate-rule.
+// @Tags [SecurityGroup Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection to audit the SecurityGroups"
+// @Param Checks query string false "Comma-separated names of the checks to run, default is all"
+// @Success 200 {object} cmrt.SGAuditReportInfo "Audit report of the SecurityGroups"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid query parameter"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /securitygroup/audit [get]
+func AuditSecurity(c echo.Context) error {
+	cblog.Info("call AuditSecurity()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	checkNameList := []string{}
+	if c.QueryParam("Checks") != "" {
+		checkNameList = strings.Split(c.QueryParam("Checks"), ",")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.AuditSecurity(req.ConnectionName, checkNameList)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
 // countAllSecurityGroups godoc
 // @ID count-all-securitygroup
 // @Summary Count All SecurityGroups
@@ -0,0 +1,66 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package restruntime
+
+import (
+	"fmt"
+
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//================ Topology Handler
+
+// getTopology godoc
+// @ID get-topology
+// @Summary Get Topology
+// @Description Get the graph of the deployed VPCs, Subnets, SecurityGroups, VMs, Disks, NLBs and Clusters of a connection. <br> Use format=dot to get the graph in the Graphviz DOT language.
+// @Tags [Topology Management]
+// @Accept  json
+// @Produce  json,plain
+// @Param ConnectionName query string true "The name of the Connection to get the topology"
+// @Param format query string false "Output format: json(default) or dot"
+// @Success 200 {object} cmrt.TopologyInfo "Nodes and edges of the topology"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid query parameter"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /topology [get]
+func GetTopology(c echo.Context) error {
+	cblog.Info("call GetTopology()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	format := c.QueryParam("format")
+	if format != "" && format != "json" && format != "dot" {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("%s is not a valid format, use json or dot!", format))
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.GetTopology(req.ConnectionName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	if format == "dot" {
+		return c.String(http.StatusOK, result.ToDOT())
+	}
+	return c.JSON(http.StatusOK, result)
+}
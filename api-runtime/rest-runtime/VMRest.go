@@ -147,28 +147,39 @@ func UnregisterVM(c echo.Context) error {
 
 // VMStartRequest represents the request body for starting a VM.
 type VMStartRequest struct {
-	ConnectionName  string `json:"ConnectionName" validate:"required" example:"aws-connection"`
-	IDTransformMode string `json:"IDTransformMode,omitempty" validate:"omitempty" example:"ON"` // ON: transform CSP ID, OFF: no-transform CSP ID
-	ReqInfo         struct {
-		Name       string `json:"Name" validate:"required" example:"vm-01"`
-		ImageType  string `json:"ImageType" validate:"required" example:"PublicImage"` // PublicImage or MyImage
-		ImageName  string `json:"ImageName" validate:"required" example:"ami-12345678"`
-		VMSpecName string `json:"VMSpecName" validate:"required" example:"t2.micro"`
+	ConnectionName  string         `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	IDTransformMode string         `json:"IDTransformMode,omitempty" validate:"omitempty" example:"ON"` // ON: transform CSP ID, OFF: no-transform CSP ID
+	ReqInfo         VMStartReqInfo `json:"ReqInfo" validate:"required"`
+}
 
-		VPCName            string   `json:"VPCName" validate:"required" example:"vpc-01"`
-		SubnetName         string   `json:"SubnetName" validate:"required" example:"subnet-01"`
-		SecurityGroupNames []string `json:"SecurityGroupNames" validate:"required" example:"sg-01,sg-02"`
-		KeyPairName        string   `json:"KeyPairName" validate:"required" example:"keypair-01"`
+// VMStartReqInfo represents the VM configurations of a VM start request.
+type VMStartReqInfo struct {
+	Name       string `json:"Name" validate:"required" example:"vm-01"`
+	ImageType  string `json:"ImageType" validate:"required" example:"PublicImage"` // PublicImage or MyImage
+	ImageName  string `json:"ImageName" validate:"required" example:"ami-12345678"`
+	VMSpecName string `json:"VMSpecName" validate:"required" example:"t2.micro"`
 
-		RootDiskType  string   `json:"RootDiskType,omitempty" validate:"omitempty" example:"gp2"`                         // gp2 or default, if not specified, default is used
-		RootDiskSize  string   `json:"RootDiskSize,omitempty" validate:"omitempty" example:"30"`                          // 100 or default, if not specified, default is used (unit is GB)
-		DataDiskNames []string `json:"DataDiskNames,omitempty" validate:"omitempty" example:"data-disk-01, data-disk-02"` // Data disks in the same zone as this VM
+	VPCName            string   `json:"VPCName" validate:"required" example:"vpc-01"`
+	SubnetName         string   `json:"SubnetName" validate:"required" example:"subnet-01"`
+	SecurityGroupNames []string `json:"SecurityGroupNames" validate:"required" example:"sg-01,sg-02"`
+	KeyPairName        string   `json:"KeyPairName" validate:"required" example:"keypair-01"`
 
-		VMUserId     string `json:"VMUserId,omitempty" validate:"omitempty" example:"Administrator"`    // Administrator, Windows Only
-		VMUserPasswd string `json:"VMUserPasswd,omitempty" validate:"omitempty" example:"password1234"` // Windows Only
+	RootDiskType  string   `json:"RootDiskType,omitempty" validate:"omitempty" example:"gp2"`                         // gp2 or default, if not specified, default is used
+	RootDiskSize  string   `json:"RootDiskSize,omitempty" validate:"omitempty" example:"30"`                          // 100 or default, if not specified, default is used (unit is GB)
+	DataDiskNames []string `json:"DataDiskNames,omitempty" validate:"omitempty" example:"data-disk-01, data-disk-02"` // Data disks in the same zone as this VM
 
-		TagList []cres.KeyValue `json:"TagList,omitempty" validate:"omitempty"`
-	} `json:"ReqInfo" validate:"required"`
+	VMUserId     string `json:"VMUserId,omitempty" validate:"omitempty" example:"Administrator"`    // Administrator, Windows Only
+	VMUserPasswd string `json:"VMUserPasswd,omitempty" validate:"omitempty" example:"password1234"` // Windows Only
+
+	UserData         string `json:"UserData,omitempty" validate:"omitempty" example:"#!/bin/bash"`   // cloud-init or shell script, size limit depends on CSP
+	UserDataEncoding string `json:"UserDataEncoding,omitempty" validate:"omitempty" example:"plain"` // plain(default) | base64
+
+	PurchaseOption string `json:"PurchaseOption,omitempty" validate:"omitempty" example:"Spot"` // OnDemand(default) | Spot | Preemptible
+	SpotMaxPrice   string `json:"SpotMaxPrice,omitempty" validate:"omitempty" example:"0.0125"` // USD per hour, only for Spot, if not specified, up to the OnDemand price
+
+	ReadinessProbe cres.ReadinessProbeInfo `json:"ReadinessProbe,omitempty" validate:"omitempty"` // if not specified, DEFAULT(PublicIP and SSHD check)
+
+	TagList []cres.KeyValue `json:"TagList,omitempty" validate:"omitempty"`
 }
 
 // startVM godoc
@@ -179,6 +190,7 @@ type VMStartRequest struct {
 // @Accept  json
 // @Produce  json
 // @Param VMStartRequest body restruntime.VMStartRequest true "Request body for starting a VM"
+// @Param dryRun query boolean false "Check the request without creating the resource, returns a DryRunReportInfo"
 // @Success 200 {object} cres.VMInfo "Details of the started VM"
 // @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
 // @Failure 404 {object} SimpleMsg "Resource Not Found"
@@ -193,51 +205,179 @@ func StartVM(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	// Rest RegInfo => Driver ReqInfo
+	reqInfo, err := toVMReqInfo(req.ReqInfo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	dryRun, err := isDryRun(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if dryRun {
+		report, err := cmrt.DryRunStartVM(req.ConnectionName, VM, reqInfo, req.IDTransformMode)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		return c.JSON(http.StatusOK, report)
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.StartVM(req.ConnectionName, VM, reqInfo, req.IDTransformMode)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// Rest RegInfo => Driver ReqInfo
+func toVMReqInfo(reqInfo VMStartReqInfo) (cres.VMReqInfo, error) {
 	// (1) create SecurityGroup IID List
 	sgIIDList := []cres.IID{}
-	for _, sgName := range req.ReqInfo.SecurityGroupNames {
+	for _, sgName := range reqInfo.SecurityGroupNames {
 		sgIID := cres.IID{sgName, ""}
 		sgIIDList = append(sgIIDList, sgIID)
 	}
 
 	// (2) create DataDisk IID List
 	diskIIDList := []cres.IID{}
-	for _, diskName := range req.ReqInfo.DataDiskNames {
+	for _, diskName := range reqInfo.DataDiskNames {
 		diskIID := cres.IID{diskName, ""}
 		diskIIDList = append(diskIIDList, diskIID)
 	}
 
-	// (3) create VMReqInfo with SecurityGroup & diskIID IID List
-	reqInfo := cres.VMReqInfo{
-		IId:               cres.IID{req.ReqInfo.Name, ""},
-		ImageType:         cres.ImageType(req.ReqInfo.ImageType),
-		ImageIID:          cres.IID{req.ReqInfo.ImageName, req.ReqInfo.ImageName},
-		VpcIID:            cres.IID{req.ReqInfo.VPCName, ""},
-		SubnetIID:         cres.IID{req.ReqInfo.SubnetName, ""},
+	// (3) decode UserData
+	userData, err := cmrt.DecodeUserData(reqInfo.UserData, reqInfo.UserDataEncoding)
+	if err != nil {
+		return cres.VMReqInfo{}, err
+	}
+
+	// (4) create VMReqInfo with SecurityGroup & diskIID IID List
+	vmReqInfo := cres.VMReqInfo{
+		IId:               cres.IID{reqInfo.Name, ""},
+		ImageType:         cres.ImageType(reqInfo.ImageType),
+		ImageIID:          cres.IID{reqInfo.ImageName, reqInfo.ImageName},
+		VpcIID:            cres.IID{reqInfo.VPCName, ""},
+		SubnetIID:         cres.IID{reqInfo.SubnetName, ""},
 		SecurityGroupIIDs: sgIIDList,
 
-		VMSpecName: req.ReqInfo.VMSpecName,
-		KeyPairIID: cres.IID{req.ReqInfo.KeyPairName, ""},
+		VMSpecName: reqInfo.VMSpecName,
+		KeyPairIID: cres.IID{reqInfo.KeyPairName, ""},
 
-		RootDiskType: req.ReqInfo.RootDiskType,
-		RootDiskSize: req.ReqInfo.RootDiskSize,
+		RootDiskType: reqInfo.RootDiskType,
+		RootDiskSize: reqInfo.RootDiskSize,
 
 		DataDiskIIDs: diskIIDList,
 
-		VMUserId:     req.ReqInfo.VMUserId,
-		VMUserPasswd: req.ReqInfo.VMUserPasswd,
+		VMUserId:     reqInfo.VMUserId,
+		VMUserPasswd: reqInfo.VMUserPasswd,
+
+		UserData: userData,
+
+		PurchaseOption: cres.PurchaseOptionInfo{
+			Type:     cres.PurchaseOptionType(reqInfo.PurchaseOption),
+			MaxPrice: reqInfo.SpotMaxPrice,
+		},
+
+		ReadinessProbe: reqInfo.ReadinessProbe,
+
+		TagList: reqInfo.TagList,
+	}
+
+	return vmReqInfo, nil
+}
+
+// VMGroupStartRequest represents the request body for starting a group of VMs with a template.
+type VMGroupStartRequest struct {
+	ConnectionName  string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	IDTransformMode string `json:"IDTransformMode,omitempty" validate:"omitempty" example:"ON"` // ON: transform CSP ID, OFF: no-transform CSP ID
+	ReqInfo         struct {
+		NamePattern       string         `json:"NamePattern,omitempty" validate:"omitempty" example:"worker-{index}"` // {index}: 1 ~ Count, default: {VMTemplate.Name}-{index}
+		Count             string         `json:"Count" validate:"required" example:"3"`                               // 1 ~ 100
+		MaxConcurrency    string         `json:"MaxConcurrency,omitempty" validate:"omitempty" example:"10"`          // 1 ~ 20, default: 10
+		RollbackOnFailure string         `json:"RollbackOnFailure,omitempty" validate:"omitempty" example:"false"`    // true: terminate all started VMs on a partial failure
+		VMTemplate        VMStartReqInfo `json:"VMTemplate" validate:"required"`                                      // DataDiskNames are not allowed
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// VMGroupStartResponse represents the response body structure for starting a group of VMs.
+type VMGroupStartResponse struct {
+	SucceededCount int                       `json:"SucceededCount" validate:"required" example:"3"`
+	FailedCount    int                       `json:"FailedCount" validate:"required" example:"0"`
+	Results        []*cmrt.VMGroupResultInfo `json:"Results" validate:"required"`
+}
+
+// startVMGroup godoc
+// @ID start-vm-group
+// @Summary Start VM Group
+// @Description Start a group of Virtual Machines (VMs) with a VM template, a count and a name pattern. <br> VMs are started concurrently, and the result of each VM is reported.
+// @Tags [VM Management]
+// @Accept  json
+// @Produce  json
+// @Param VMGroupStartRequest body restruntime.VMGroupStartRequest true "Request body for starting a VM Group"
+// @Success 200 {object} VMGroupStartResponse "Results of the started VMs"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /vmgroup [post]
+func StartVMGroup(c echo.Context) error {
+	cblog.Info("call StartVMGroup()")
+
+	req := VMGroupStartRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	count, err := strconv.Atoi(req.ReqInfo.Count)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Count should be a number: "+err.Error())
+	}
+
+	maxConcurrency := 0
+	if req.ReqInfo.MaxConcurrency != "" {
+		maxConcurrency, err = strconv.Atoi(req.ReqInfo.MaxConcurrency)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "MaxConcurrency should be a number: "+err.Error())
+		}
+	}
+
+	rollbackOnFailure := false
+	if req.ReqInfo.RollbackOnFailure != "" {
+		rollbackOnFailure, err = strconv.ParseBool(req.ReqInfo.RollbackOnFailure)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "RollbackOnFailure should be true or false: "+err.Error())
+		}
+	}
 
-		TagList: req.ReqInfo.TagList,
+	namePattern := req.ReqInfo.NamePattern
+	if namePattern == "" {
+		namePattern = req.ReqInfo.VMTemplate.Name
+	}
+
+	templateInfo, err := toVMReqInfo(req.ReqInfo.VMTemplate)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
 	// Call common-runtime API
-	result, err := cmrt.StartVM(req.ConnectionName, VM, reqInfo, req.IDTransformMode)
+	results, err := cmrt.StartVMGroup(req.ConnectionName, VM, templateInfo, namePattern, count, maxConcurrency,
+		rollbackOnFailure, req.IDTransformMode)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return c.JSON(http.StatusOK, result)
+	response := VMGroupStartResponse{Results: results}
+	for _, result := range results {
+		if result.Status == cmrt.VMGROUP_SUCCEEDED {
+			response.SucceededCount++
+		} else if result.Status == cmrt.VMGROUP_FAILED {
+			response.FailedCount++
+		}
+	}
+
+	return c.JSON(http.StatusOK, &response)
 }
 
 // VMListResponse represents the response body structure for listing VMs.
@@ -600,6 +740,98 @@ func ControlVM(c echo.Context) error {
 	return c.JSON(http.StatusOK, &resultInfo)
 }
 
+// VMSpecChangeRequest represents the request body for changing the VMSpec of a VM.
+type VMSpecChangeRequest struct {
+	ConnectionName string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	ReqInfo        struct {
+		VMSpecName string `json:"VMSpecName" validate:"required" example:"t3.large"`
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// changeVMSpec godoc
+// @ID change-vm-spec
+// @Summary Change VMSpec
+// @Description Change the VMSpec(instance type) of a Virtual Machine (VM). <br> If the CSP can not change the VMSpec of a running VM, the VM is suspended and resumed by Spider. <br> It is supported only by the drivers with the VM_SPEC_CHANGE capability.
+// @Tags [VM Management]
+// @Accept  json
+// @Produce  json
+// @Param VMSpecChangeRequest body restruntime.VMSpecChangeRequest true "Request body for changing the VMSpec of a VM"
+// @Param Name path string true "The name of the VM to change the VMSpec"
+// @Success 200 {object} cres.VMInfo "Details of the VM with the new VMSpec"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /vm/{Name}/spec [put]
+func ChangeVMSpec(c echo.Context) error {
+	cblog.Info("call ChangeVMSpec()")
+
+	var req VMSpecChangeRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ChangeVMSpec(req.ConnectionName, VM, c.Param("Name"), req.ReqInfo.VMSpecName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// VMConsoleOutputResponse represents the response body structure for getting the console output of a VM.
+type VMConsoleOutputResponse struct {
+	Output string `json:"Output" validate:"required" example:"[    0.000000] Linux version 5.15.0 ..."`
+}
+
+// getVMConsoleOutput godoc
+// @ID get-vm-console-output
+// @Summary Get VM Console Output
+// @Description Retrieve the serial console output(boot log) of a Virtual Machine (VM) to diagnose boot failures. <br> It is supported only by the drivers with the CONSOLE_OUTPUT capability.
+// @Tags [VM Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection to get a VM console output for"
+// @Param Name path string true "The name of the VM to get the console output"
+// @Param Tail query string false "The number of the last lines to retrieve, all lines if not specified"
+// @Success 200 {object} VMConsoleOutputResponse "Console output of the VM"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid query parameter"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /vm/{Name}/console [get]
+func GetVMConsoleOutput(c echo.Context) error {
+	cblog.Info("call GetVMConsoleOutput()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	tailLines := 0
+	if c.QueryParam("Tail") != "" {
+		var err error
+		tailLines, err = strconv.Atoi(c.QueryParam("Tail"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Tail should be a number: "+err.Error())
+		}
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.GetVMConsoleOutput(req.ConnectionName, VM, c.Param("Name"), tailLines)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, &VMConsoleOutputResponse{Output: result})
+}
+
 // countAllVMs godoc
 // @ID count-all-vm
 // @Summary Count All VMs
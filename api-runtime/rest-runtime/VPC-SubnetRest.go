@@ -199,11 +199,13 @@ type VPCCreateRequest struct {
 	IDTransformMode string `json:"IDTransformMode,omitempty" validate:"omitempty" example:"ON"` // ON: transform CSP ID, OFF: no-transform CSP ID
 	ReqInfo         struct {
 		Name           string `json:"Name" validate:"required" example:"vpc-01"`
-		IPv4_CIDR      string `json:"IPv4_CIDR" validate:"required" example:"10.0.0.0/16"` // Some CSPs unsupported VPC CIDR
+		IPv4_CIDR      string `json:"IPv4_CIDR,omitempty" validate:"omitempty" example:"10.0.0.0/16"`             // Some CSPs unsupported VPC CIDR, omitted: allocated from IPAM Pools
+		IPv6_CIDR      string `json:"IPv6_CIDR,omitempty" validate:"omitempty" example:"2001:db8:1234:1a00::/56"` // optional, dual-stack
 		SubnetInfoList []struct {
 			Name      string          `json:"Name" validate:"required" example:"subnet-01"`
-			Zone      string          `json:"Zone,omitempty" validate:"omitempty" example:"us-east-1b"` // target zone for the subnet, if not specified, it will be created in the same zone as the Connection.
-			IPv4_CIDR string          `json:"IPv4_CIDR" validate:"required" example:"10.0.8.0/22"`
+			Zone      string          `json:"Zone,omitempty" validate:"omitempty" example:"us-east-1b"`                   // target zone for the subnet, if not specified, it will be created in the same zone as the Connection.
+			IPv4_CIDR string          `json:"IPv4_CIDR,omitempty" validate:"omitempty" example:"10.0.8.0/22"`             // omitted: allocated in the VPC CIDR
+			IPv6_CIDR string          `json:"IPv6_CIDR,omitempty" validate:"omitempty" example:"2001:db8:1234:1a00::/64"` // optional, dual-stack
 			TagList   []cres.KeyValue `json:"TagList,omitempty" validate:"omitempty"`
 		} `json:"SubnetInfoList" validate:"required"`
 		TagList []cres.KeyValue `json:"TagList,omitempty" validate:"omitempty"`
@@ -218,6 +220,7 @@ type VPCCreateRequest struct {
 // @Accept  json
 // @Produce  json
 // @Param VPCCreateRequest body restruntime.VPCCreateRequest true "Request body for creating a VPC"
+// @Param dryRun query boolean false "Check the request without creating the resource, returns a DryRunReportInfo"
 // @Success 200 {object} cres.VPCInfo "Details of the created VPC"
 // @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
 // @Failure 404 {object} SimpleMsg "Resource Not Found"
@@ -236,17 +239,30 @@ func CreateVPC(c echo.Context) error {
 	// (1) create SubnetInfo List
 	subnetInfoList := []cres.SubnetInfo{}
 	for _, info := range req.ReqInfo.SubnetInfoList {
-		subnetInfo := cres.SubnetInfo{IId: cres.IID{info.Name, ""}, IPv4_CIDR: info.IPv4_CIDR, Zone: info.Zone, TagList: info.TagList}
+		subnetInfo := cres.SubnetInfo{IId: cres.IID{info.Name, ""}, IPv4_CIDR: info.IPv4_CIDR, IPv6_CIDR: info.IPv6_CIDR, Zone: info.Zone, TagList: info.TagList}
 		subnetInfoList = append(subnetInfoList, subnetInfo)
 	}
 	// (2) create VPCReqInfo with SubnetInfo List
 	reqInfo := cres.VPCReqInfo{
 		IId:            cres.IID{req.ReqInfo.Name, ""},
 		IPv4_CIDR:      req.ReqInfo.IPv4_CIDR,
+		IPv6_CIDR:      req.ReqInfo.IPv6_CIDR,
 		SubnetInfoList: subnetInfoList,
 		TagList:        req.ReqInfo.TagList,
 	}
 
+	dryRun, err := isDryRun(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if dryRun {
+		report, err := cmrt.DryRunCreateVPC(req.ConnectionName, VPC, reqInfo, req.IDTransformMode)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		return c.JSON(http.StatusOK, report)
+	}
+
 	// Call common-runtime API
 	result, err := cmrt.CreateVPC(req.ConnectionName, VPC, reqInfo, req.IDTransformMode)
 	if err != nil {
@@ -342,8 +358,9 @@ type SubnetAddRequest struct {
 	IDTransformMode string `json:"IDTransformMode,omitempty" validate:"omitempty" example:"ON"` // ON: transform CSP ID, OFF: no-transform CSP ID
 	ReqInfo         struct {
 		Name      string          `json:"Name" validate:"required" example:"subnet-01"`
-		Zone      string          `json:"Zone,omitempty" validate:"omitempty" example:"us-east-1b"` // target zone for the subnet, if not specified, it will be created in the same zone as the Connection.
-		IPv4_CIDR string          `json:"IPv4_CIDR" validate:"required" example:"10.0.12.0/22"`
+		Zone      string          `json:"Zone,omitempty" validate:"omitempty" example:"us-east-1b"`                   // target zone for the subnet, if not specified, it will be created in the same zone as the Connection.
+		IPv4_CIDR string          `json:"IPv4_CIDR,omitempty" validate:"omitempty" example:"10.0.12.0/22"`            // omitted: allocated in the VPC CIDR
+		IPv6_CIDR string          `json:"IPv6_CIDR,omitempty" validate:"omitempty" example:"2001:db8:1234:1a01::/64"` // optional, dual-stack
 		TagList   []cres.KeyValue `json:"TagList,omitempty" validate:"omitempty"`
 	} `json:"ReqInfo" validate:"required"`
 }
@@ -372,7 +389,7 @@ func AddSubnet(c echo.Context) error {
 	}
 
 	// Rest RegInfo => Driver ReqInfo
-	reqSubnetInfo := cres.SubnetInfo{IId: cres.IID{req.ReqInfo.Name, ""}, IPv4_CIDR: req.ReqInfo.IPv4_CIDR, Zone: req.ReqInfo.Zone, TagList: req.ReqInfo.TagList}
+	reqSubnetInfo := cres.SubnetInfo{IId: cres.IID{req.ReqInfo.Name, ""}, IPv4_CIDR: req.ReqInfo.IPv4_CIDR, IPv6_CIDR: req.ReqInfo.IPv6_CIDR, Zone: req.ReqInfo.Zone, TagList: req.ReqInfo.TagList}
 
 	// Call common-runtime API
 	result, err := cmrt.AddSubnet(req.ConnectionName, SUBNET, c.Param("VPCName"), reqSubnetInfo, req.IDTransformMode)
@@ -433,6 +450,99 @@ func GetSubnet(c echo.Context) error {
 	return c.JSON(http.StatusOK, result)
 }
 
+// SubnetUpdateRequest represents the request body for updating a Subnet.
+type SubnetUpdateRequest struct {
+	ConnectionName string `json:"ConnectionName" validate:"required" example:"aws-connection"`
+	ReqInfo        struct {
+		AutoAssignPublicIP string `json:"AutoAssignPublicIP,omitempty" validate:"omitempty" example:"true"`            // true or false, omitted: not changed
+		RouteTableId       string `json:"RouteTableId,omitempty" validate:"omitempty" example:"rtb-0a1b2c3d4e5f67890"` // CSP ID of the Route Table, omitted: not changed
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// updateSubnet godoc
+// @ID update-subnet
+// @Summary Update Subnet
+// @Description Update the auto-assign public IP option and the Route Table association of a Subnet. <br> Omitted fields are not changed.
+// @Tags [VPC Management]
+// @Accept  json
+// @Produce  json
+// @Param VPCName path string true "The name of the VPC"
+// @Param Name path string true "The name of the Subnet to update"
+// @Param SubnetUpdateRequest body restruntime.SubnetUpdateRequest true "Request body for updating a Subnet"
+// @Success 200 {object} cres.SubnetInfo "Details of the updated Subnet"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /vpc/{VPCName}/subnet/{Name} [put]
+func UpdateSubnet(c echo.Context) error {
+	cblog.Info("call UpdateSubnet()")
+
+	var req SubnetUpdateRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Rest RegInfo => Driver UpdateInfo
+	updateInfo := cres.SubnetUpdateInfo{}
+	if req.ReqInfo.AutoAssignPublicIP != "" {
+		autoAssign, err := strconv.ParseBool(req.ReqInfo.AutoAssignPublicIP)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		updateInfo.AutoAssignPublicIP = &autoAssign
+	}
+	if req.ReqInfo.RouteTableId != "" {
+		updateInfo.RouteTableIID = &cres.IID{NameId: req.ReqInfo.RouteTableId, SystemId: req.ReqInfo.RouteTableId}
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.UpdateSubnet(req.ConnectionName, c.Param("VPCName"), c.Param("Name"), updateInfo)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// getSubnetUsage godoc
+// @ID get-subnet-usage
+// @Summary Get Subnet Usage
+// @Description Retrieve the IP address usage(total, reserved, used and available IPs) of a Subnet.
+// @Tags [VPC Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection to get a Subnet usage for"
+// @Param VPCName path string true "The name of the VPC"
+// @Param Name path string true "The name of the Subnet"
+// @Success 200 {object} cres.SubnetUsageInfo "IP address usage of the Subnet"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid parameters"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /vpc/{VPCName}/subnet/{Name}/usage [get]
+func GetSubnetUsage(c echo.Context) error {
+	cblog.Info("call GetSubnetUsage()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.GetSubnetUsage(req.ConnectionName, c.Param("VPCName"), c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
 // removeSubnet godoc
 // @ID remove-subnet
 // @Summary Remove Subnet
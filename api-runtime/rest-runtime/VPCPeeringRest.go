@@ -0,0 +1,299 @@
+// Cloud Control Manager's Rest Runtime of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package restruntime
+
+import (
+	cmrt "github.com/cloud-barista/cb-spider/api-runtime/common-runtime"
+	cres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	// REST API (echo)
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"strconv"
+)
+
+//================ VPC Peering Handler
+
+// VPCPeeringRequestRequest represents the request body for requesting a VPC Peering.
+type VPCPeeringRequestRequest struct {
+	ConnectionName  string `json:"ConnectionName" validate:"required" example:"aws-seoul-connection"`
+	IDTransformMode string `json:"IDTransformMode,omitempty" validate:"omitempty" example:"ON"` // ON: transform CSP ID, OFF: no-transform CSP ID
+	ReqInfo         struct {
+		Name                      string          `json:"Name" validate:"required" example:"peering-01"`
+		VPCName                   string          `json:"VPCName" validate:"required" example:"app-vpc"`                                    // Requester VPC
+		PeerConnectionName        string          `json:"PeerConnectionName,omitempty" validate:"omitempty" example:"aws-tokyo-connection"` // default: ConnectionName
+		PeerVPCName               string          `json:"PeerVPCName" validate:"required" example:"shared-vpc"`                             // Accepter VPC
+		RequesterRoutePropagation string          `json:"RequesterRoutePropagation,omitempty" validate:"omitempty" example:"true"`          // default: false
+		AccepterRoutePropagation  string          `json:"AccepterRoutePropagation,omitempty" validate:"omitempty" example:"true"`           // default: false
+		TagList                   []cres.KeyValue `json:"TagList,omitempty" validate:"omitempty"`
+	} `json:"ReqInfo" validate:"required"`
+}
+
+// VPCPeeringListResponse represents the response body for listing VPC Peerings.
+type VPCPeeringListResponse struct {
+	Result []*cres.VPCPeeringInfo `json:"vpcpeering" validate:"required" description:"A list of VPC Peering information"`
+}
+
+// requestVPCPeering godoc
+// @ID request-vpcpeering
+// @Summary Request VPC Peering
+// @Description Request a new VPC Peering between two VPCs registered in CB-Spider. <br> The peer VPC can be in another connection of the same provider.
+// @Tags [VPC Peering Management]
+// @Accept  json
+// @Produce  json
+// @Param VPCPeeringRequestRequest body restruntime.VPCPeeringRequestRequest true "Request body for requesting a VPC Peering"
+// @Success 200 {object} cres.VPCPeeringInfo "Details of the requested VPC Peering"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /vpcpeering [post]
+func RequestVPCPeering(c echo.Context) error {
+	cblog.Info("call RequestVPCPeering()")
+
+	req := VPCPeeringRequestRequest{}
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	requesterRoutePropagation, err := parseBoolOption(req.ReqInfo.RequesterRoutePropagation)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	accepterRoutePropagation, err := parseBoolOption(req.ReqInfo.AccepterRoutePropagation)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	// Rest RegInfo => Driver ReqInfo
+	reqInfo := cres.VPCPeeringReqInfo{
+		IId:                       cres.IID{NameId: req.ReqInfo.Name, SystemId: ""},
+		RequesterVPCIID:           cres.IID{NameId: req.ReqInfo.VPCName, SystemId: ""},
+		AccepterVPCIID:            cres.IID{NameId: req.ReqInfo.PeerVPCName, SystemId: ""},
+		RequesterRoutePropagation: requesterRoutePropagation,
+		AccepterRoutePropagation:  accepterRoutePropagation,
+		TagList:                   req.ReqInfo.TagList,
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.RequestVPCPeering(req.ConnectionName, VPCPEERING, reqInfo, req.ReqInfo.PeerConnectionName, req.IDTransformMode)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// parse an optional boolean string option, empty means false
+func parseBoolOption(option string) (bool, error) {
+	if option == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(option)
+}
+
+// acceptVPCPeering godoc
+// @ID accept-vpcpeering
+// @Summary Accept VPC Peering
+// @Description Accept a requested VPC Peering with the connection of the peer VPC.
+// @Tags [VPC Peering Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionRequest body restruntime.ConnectionRequest true "Request body for accepting a VPC Peering, ConnectionName of the requester"
+// @Param Name path string true "The name of the VPC Peering to accept"
+// @Success 200 {object} cres.VPCPeeringInfo "Details of the accepted VPC Peering"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /vpcpeering/{Name}/accept [put]
+func AcceptVPCPeering(c echo.Context) error {
+	cblog.Info("call AcceptVPCPeering()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.AcceptVPCPeering(req.ConnectionName, VPCPEERING, c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// listVPCPeering godoc
+// @ID list-vpcpeering
+// @Summary List VPC Peerings
+// @Description Retrieve a list of VPC Peerings requested in a specific connection.
+// @Tags [VPC Peering Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection to list VPC Peerings for"
+// @Success 200 {object} VPCPeeringListResponse "List of VPC Peerings"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid query parameter"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /vpcpeering [get]
+func ListVPCPeering(c echo.Context) error {
+	cblog.Info("call ListVPCPeering()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.ListVPCPeering(req.ConnectionName, VPCPEERING)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	jsonResult := VPCPeeringListResponse{
+		Result: result,
+	}
+	return c.JSON(http.StatusOK, &jsonResult)
+}
+
+// getVPCPeering godoc
+// @ID get-vpcpeering
+// @Summary Get VPC Peering
+// @Description Retrieve details of a specific VPC Peering.
+// @Tags [VPC Peering Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionName query string true "The name of the Connection to get a VPC Peering for"
+// @Param Name path string true "The name of the VPC Peering to retrieve"
+// @Success 200 {object} cres.VPCPeeringInfo "Details of the VPC Peering"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /vpcpeering/{Name} [get]
+func GetVPCPeering(c echo.Context) error {
+	cblog.Info("call GetVPCPeering()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// To support for Get-Query Param Type API
+	if req.ConnectionName == "" {
+		req.ConnectionName = c.QueryParam("ConnectionName")
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.GetVPCPeering(req.ConnectionName, VPCPEERING, c.Param("Name"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// deleteVPCPeering godoc
+// @ID delete-vpcpeering
+// @Summary Delete VPC Peering
+// @Description Delete a specified VPC Peering.
+// @Tags [VPC Peering Management]
+// @Accept  json
+// @Produce  json
+// @Param ConnectionRequest body restruntime.ConnectionRequest true "Request body for deleting a VPC Peering"
+// @Param Name path string true "The name of the VPC Peering to delete"
+// @Param force query string false "Force delete the VPC Peering. ex) true or false(default: false)"
+// @Success 200 {object} BooleanInfo "Result of the delete operation"
+// @Failure 400 {object} SimpleMsg "Bad Request, possibly due to invalid JSON structure or missing fields"
+// @Failure 404 {object} SimpleMsg "Resource Not Found"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /vpcpeering/{Name} [delete]
+func DeleteVPCPeering(c echo.Context) error {
+	cblog.Info("call DeleteVPCPeering()")
+
+	var req ConnectionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Call common-runtime API
+	result, err := cmrt.DeleteVPCPeering(req.ConnectionName, VPCPEERING, c.Param("Name"), c.QueryParam("force"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	resultInfo := BooleanInfo{
+		Result: strconv.FormatBool(result),
+	}
+
+	return c.JSON(http.StatusOK, &resultInfo)
+}
+
+// countAllVPCPeerings godoc
+// @ID count-all-vpcpeering
+// @Summary Count All VPC Peerings
+// @Description Get the total number of VPC Peerings across all connections.
+// @Tags [VPC Peering Management]
+// @Produce  json
+// @Success 200 {object} CountResponse "Total count of VPC Peerings"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /countvpcpeering [get]
+func CountAllVPCPeerings(c echo.Context) error {
+	// Call common-runtime API to get count of VPC Peerings
+	count, err := cmrt.CountAllVPCPeerings()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Prepare JSON result
+	var jsonResult struct {
+		Count int `json:"count"`
+	}
+	jsonResult.Count = int(count)
+
+	// Return JSON response
+	return c.JSON(http.StatusOK, jsonResult)
+}
+
+// countVPCPeeringsByConnection godoc
+// @ID count-vpcpeering-by-connection
+// @Summary Count VPC Peerings by Connection
+// @Description Get the total number of VPC Peerings for a specific connection.
+// @Tags [VPC Peering Management]
+// @Produce  json
+// @Param ConnectionName path string true "The name of the Connection"
+// @Success 200 {object} CountResponse "Total count of VPC Peerings for the connection"
+// @Failure 500 {object} SimpleMsg "Internal Server Error"
+// @Router /countvpcpeering/{ConnectionName} [get]
+func CountVPCPeeringsByConnection(c echo.Context) error {
+	// Call common-runtime API to get count of VPC Peerings
+	count, err := cmrt.CountVPCPeeringsByConnection(c.Param("ConnectionName"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+
+	// Prepare JSON result
+	var jsonResult struct {
+		Count int `json:"count"`
+	}
+	jsonResult.Count = int(count)
+
+	// Return JSON response
+	return c.JSON(http.StatusOK, jsonResult)
+}
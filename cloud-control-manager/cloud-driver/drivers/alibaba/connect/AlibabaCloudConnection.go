@@ -178,3 +178,27 @@ func (cloudConn *AlibabaCloudConnection) CreateTagHandler() (irs.TagHandler, err
 	handler := alirs.AlibabaTagHandler{cloudConn.Region, cloudConn.VMClient, cloudConn.Cs2015Client, cloudConn.VpcClient, cloudConn.NLBClient}
 	return &handler, nil
 }
+
+func (cloudConn *AlibabaCloudConnection) CreateVPCPeeringHandler() (irs.VPCPeeringHandler, error) {
+	return nil, errors.New("Alibaba Driver: not implemented")
+}
+
+func (cloudConn *AlibabaCloudConnection) CreateRouteTableHandler() (irs.RouteTableHandler, error) {
+	return nil, errors.New("Alibaba Driver: not implemented")
+}
+
+func (cloudConn *AlibabaCloudConnection) CreateNATGatewayHandler() (irs.NATGatewayHandler, error) {
+	return nil, errors.New("Alibaba Driver: not implemented")
+}
+
+func (cloudConn *AlibabaCloudConnection) CreateVPNGatewayHandler() (irs.VPNGatewayHandler, error) {
+	return nil, errors.New("Alibaba Driver: not implemented")
+}
+
+func (cloudConn *AlibabaCloudConnection) CreateDNSHandler() (irs.DNSHandler, error) {
+	return nil, errors.New("Alibaba Driver: not implemented")
+}
+
+func (cloudConn *AlibabaCloudConnection) CreateALBHandler() (irs.ALBHandler, error) {
+	return nil, errors.New("Alibaba Driver: not implemented")
+}
@@ -592,3 +592,11 @@ func (vpcHandler *AlibabaVPCHandler) ListIID() ([]*irs.IID, error) {
 	cblogger.Info("Cloud driver: called ListIID()!!")
 	return nil, errors.New("Does not support ListIID() yet!!")
 }
+
+func (VPCHandler *AlibabaVPCHandler) GetSubnetUsage(vpcIID irs.IID, subnetIID irs.IID) (irs.SubnetUsageInfo, error) {
+	return irs.SubnetUsageInfo{}, errors.New("Does not support GetSubnetUsage() yet!!")
+}
+
+func (VPCHandler *AlibabaVPCHandler) UpdateSubnet(vpcIID irs.IID, subnetIID irs.IID, updateInfo irs.SubnetUpdateInfo) (irs.SubnetInfo, error) {
+	return irs.SubnetInfo{}, errors.New("Does not support UpdateSubnet() yet!!")
+}
@@ -11,6 +11,8 @@
 package connect
 
 import (
+	"errors"
+
 	cblog "github.com/cloud-barista/cb-log"
 	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
 
@@ -202,3 +204,27 @@ func (cloudConn *AwsCloudConnection) CreatePriceInfoHandler() (irs.PriceInfoHand
 	handler := ars.AwsPriceInfoHandler{Region: cloudConn.Region, Client: cloudConn.PriceInfoClient}
 	return &handler, nil
 }
+
+func (cloudConn *AwsCloudConnection) CreateVPCPeeringHandler() (irs.VPCPeeringHandler, error) {
+	return nil, errors.New("AWS Driver: not implemented")
+}
+
+func (cloudConn *AwsCloudConnection) CreateRouteTableHandler() (irs.RouteTableHandler, error) {
+	return nil, errors.New("AWS Driver: not implemented")
+}
+
+func (cloudConn *AwsCloudConnection) CreateNATGatewayHandler() (irs.NATGatewayHandler, error) {
+	return nil, errors.New("AWS Driver: not implemented")
+}
+
+func (cloudConn *AwsCloudConnection) CreateVPNGatewayHandler() (irs.VPNGatewayHandler, error) {
+	return nil, errors.New("AWS Driver: not implemented")
+}
+
+func (cloudConn *AwsCloudConnection) CreateDNSHandler() (irs.DNSHandler, error) {
+	return nil, errors.New("AWS Driver: not implemented")
+}
+
+func (cloudConn *AwsCloudConnection) CreateALBHandler() (irs.ALBHandler, error) {
+	return nil, errors.New("AWS Driver: not implemented")
+}
@@ -155,11 +155,7 @@ func (securityHandler *AwsSecurityHandler) CreateSecurity(securityReqInfo irs.Se
 				//ipPermission.SetToPort(0)
 			}
 
-			ipPermission.SetIpRanges([]*ec2.IpRange{
-				(&ec2.IpRange{}).
-					SetCidrIp(ip.CIDR),
-				//SetCidrIp("0.0.0.0/0"),
-			})
+			setIpPermissionSource(ipPermission, ip)
 			// cblogger.Debug("===>변환완료")
 			// cblogger.Debug(ipPermission)
 
@@ -222,11 +218,7 @@ func (securityHandler *AwsSecurityHandler) CreateSecurity(securityReqInfo irs.Se
 				//ipPermission.SetToPort(0)
 			}
 
-			ipPermission.SetIpRanges([]*ec2.IpRange{
-				(&ec2.IpRange{}).
-					SetCidrIp(ip.CIDR),
-				//SetCidrIp("0.0.0.0/0"),
-			})
+			setIpPermissionSource(ipPermission, ip)
 			//ipPermissions = append(ipPermissions, ipPermission)
 			ipPermissionsEgress = append(ipPermissionsEgress, ipPermission)
 		}
@@ -485,6 +477,21 @@ func ExtractIpPermissionCommon(ip *ec2.IpPermission, securityRuleInfo *irs.Secur
 	}
 }
 
+// set the source(inbound) or destination(outbound) of a rule: a referenced security group or a CIDR
+func setIpPermissionSource(ipPermission *ec2.IpPermission, ip irs.SecurityRuleInfo) {
+	if ip.SecurityGroupIID != nil {
+		ipPermission.SetUserIdGroupPairs([]*ec2.UserIdGroupPair{
+			(&ec2.UserIdGroupPair{}).
+				SetGroupId(ip.SecurityGroupIID.SystemId),
+		})
+		return
+	}
+	ipPermission.SetIpRanges([]*ec2.IpRange{
+		(&ec2.IpRange{}).
+			SetCidrIp(ip.CIDR),
+	})
+}
+
 func ExtractIpPermissions(ipPermissions []*ec2.IpPermission, direction string) []irs.SecurityRuleInfo {
 	var results []irs.SecurityRuleInfo
 
@@ -518,8 +525,8 @@ func ExtractIpPermissions(ipPermissions []*ec2.IpPermission, direction string) [
 		//ELB나 보안그룹 참조 방식 처리
 		for _, userIdGroup := range ip.UserIdGroupPairs {
 			securityRuleInfo := irs.SecurityRuleInfo{
-				Direction: direction, // "inbound | outbound"
-				CIDR:      *userIdGroup.GroupId,
+				Direction:        direction, // "inbound | outbound"
+				SecurityGroupIID: &irs.IID{SystemId: *userIdGroup.GroupId},
 			}
 			cblogger.Debug(*userIdGroup.UserId)
 
@@ -689,11 +696,7 @@ func (securityHandler *AwsSecurityHandler) ProcessAddRules(newGroupId *string, s
 			//ipPermission.SetToPort(0)
 		}
 
-		ipPermission.SetIpRanges([]*ec2.IpRange{
-			(&ec2.IpRange{}).
-				SetCidrIp(ip.CIDR),
-			//SetCidrIp("0.0.0.0/0"),
-		})
+		setIpPermissionSource(ipPermission, ip)
 		// cblogger.Debug("===>변환완료")
 		// cblogger.Debug(ipPermission)
 
@@ -756,11 +759,7 @@ func (securityHandler *AwsSecurityHandler) ProcessAddRules(newGroupId *string, s
 			//ipPermission.SetToPort(0)
 		}
 
-		ipPermission.SetIpRanges([]*ec2.IpRange{
-			(&ec2.IpRange{}).
-				SetCidrIp(ip.CIDR),
-			//SetCidrIp("0.0.0.0/0"),
-		})
+		setIpPermissionSource(ipPermission, ip)
 		//ipPermissions = append(ipPermissions, ipPermission)
 		ipPermissionsEgress = append(ipPermissionsEgress, ipPermission)
 	}
@@ -848,11 +847,7 @@ func (securityHandler *AwsSecurityHandler) ProcessRemoveRules(newGroupId *string
 			//ipPermission.SetToPort(0)
 		}
 
-		ipPermission.SetIpRanges([]*ec2.IpRange{
-			(&ec2.IpRange{}).
-				SetCidrIp(ip.CIDR),
-			//SetCidrIp("0.0.0.0/0"),
-		})
+		setIpPermissionSource(ipPermission, ip)
 		// cblogger.Debug("===>변환완료")
 		// cblogger.Debug(ipPermission)
 
@@ -915,11 +910,7 @@ func (securityHandler *AwsSecurityHandler) ProcessRemoveRules(newGroupId *string
 			//ipPermission.SetToPort(0)
 		}
 
-		ipPermission.SetIpRanges([]*ec2.IpRange{
-			(&ec2.IpRange{}).
-				SetCidrIp(ip.CIDR),
-			//SetCidrIp("0.0.0.0/0"),
-		})
+		setIpPermissionSource(ipPermission, ip)
 		//ipPermissions = append(ipPermissions, ipPermission)
 		ipPermissionsEgress = append(ipPermissionsEgress, ipPermission)
 	}
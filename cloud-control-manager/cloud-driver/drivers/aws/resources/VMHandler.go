@@ -1710,3 +1710,11 @@ func (vmHandler *AwsVMHandler) ListIID() ([]*irs.IID, error) {
 	cblogger.Info("Cloud driver: called ListIID()!!")
 	return nil, errors.New("Does not support ListIID() yet!!")
 }
+
+func (vmHandler *AwsVMHandler) ChangeVMSpec(vmIID irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	return irs.VMInfo{}, errors.New("Does not support ChangeVMSpec() yet!!")
+}
+
+func (vmHandler *AwsVMHandler) GetConsoleOutput(vmIID irs.IID) (string, error) {
+	return "", errors.New("Does not support GetConsoleOutput() yet!!")
+}
@@ -1154,3 +1154,11 @@ func (vpcHandler *AwsVPCHandler) ListIID() ([]*irs.IID, error) {
 	cblogger.Info("Cloud driver: called ListIID()!!")
 	return nil, errors.New("Does not support ListIID() yet!!")
 }
+
+func (VPCHandler *AwsVPCHandler) GetSubnetUsage(vpcIID irs.IID, subnetIID irs.IID) (irs.SubnetUsageInfo, error) {
+	return irs.SubnetUsageInfo{}, errors.New("Does not support GetSubnetUsage() yet!!")
+}
+
+func (VPCHandler *AwsVPCHandler) UpdateSubnet(vpcIID irs.IID, subnetIID irs.IID, updateInfo irs.SubnetUpdateInfo) (irs.SubnetInfo, error) {
+	return irs.SubnetInfo{}, errors.New("Does not support UpdateSubnet() yet!!")
+}
@@ -244,3 +244,27 @@ func (cloudConn *AzureCloudConnection) CreateTagHandler() (irs.TagHandler, error
 	return &tagHandler, nil
 	// return nil, errors.New("Azure Driver: not implemented")
 }
+
+func (cloudConn *AzureCloudConnection) CreateVPCPeeringHandler() (irs.VPCPeeringHandler, error) {
+	return nil, errors.New("Azure Driver: not implemented")
+}
+
+func (cloudConn *AzureCloudConnection) CreateRouteTableHandler() (irs.RouteTableHandler, error) {
+	return nil, errors.New("Azure Driver: not implemented")
+}
+
+func (cloudConn *AzureCloudConnection) CreateNATGatewayHandler() (irs.NATGatewayHandler, error) {
+	return nil, errors.New("Azure Driver: not implemented")
+}
+
+func (cloudConn *AzureCloudConnection) CreateVPNGatewayHandler() (irs.VPNGatewayHandler, error) {
+	return nil, errors.New("Azure Driver: not implemented")
+}
+
+func (cloudConn *AzureCloudConnection) CreateDNSHandler() (irs.DNSHandler, error) {
+	return nil, errors.New("Azure Driver: not implemented")
+}
+
+func (cloudConn *AzureCloudConnection) CreateALBHandler() (irs.ALBHandler, error) {
+	return nil, errors.New("Azure Driver: not implemented")
+}
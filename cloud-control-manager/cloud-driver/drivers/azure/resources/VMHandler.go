@@ -2099,3 +2099,11 @@ func (vmHandler *AzureVMHandler) ListIID() ([]*irs.IID, error) {
 
 	return iidList, nil
 }
+
+func (vmHandler *AzureVMHandler) ChangeVMSpec(vmIID irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	return irs.VMInfo{}, errors.New("Does not support ChangeVMSpec() yet!!")
+}
+
+func (vmHandler *AzureVMHandler) GetConsoleOutput(vmIID irs.IID) (string, error) {
+	return "", errors.New("Does not support GetConsoleOutput() yet!!")
+}
@@ -374,3 +374,11 @@ func (vpcHandler *AzureVPCHandler) ListIID() ([]*irs.IID, error) {
 
 	return iidList, nil
 }
+
+func (vpcHandler *AzureVPCHandler) GetSubnetUsage(vpcIID irs.IID, subnetIID irs.IID) (irs.SubnetUsageInfo, error) {
+	return irs.SubnetUsageInfo{}, errors.New("Does not support GetSubnetUsage() yet!!")
+}
+
+func (vpcHandler *AzureVPCHandler) UpdateSubnet(vpcIID irs.IID, subnetIID irs.IID, updateInfo irs.SubnetUpdateInfo) (irs.SubnetInfo, error) {
+	return irs.SubnetInfo{}, errors.New("Does not support UpdateSubnet() yet!!")
+}
@@ -126,3 +126,27 @@ func (*ClouditCloudConnection) CreatePriceInfoHandler() (irs.PriceInfoHandler, e
 func (cloudConn *ClouditCloudConnection) CreateTagHandler() (irs.TagHandler, error) {
 	return nil, errors.New("Cloudit Driver: not implemented")
 }
+
+func (cloudConn *ClouditCloudConnection) CreateVPCPeeringHandler() (irs.VPCPeeringHandler, error) {
+	return nil, errors.New("Cloudit Driver: not implemented")
+}
+
+func (cloudConn *ClouditCloudConnection) CreateRouteTableHandler() (irs.RouteTableHandler, error) {
+	return nil, errors.New("Cloudit Driver: not implemented")
+}
+
+func (cloudConn *ClouditCloudConnection) CreateNATGatewayHandler() (irs.NATGatewayHandler, error) {
+	return nil, errors.New("Cloudit Driver: not implemented")
+}
+
+func (cloudConn *ClouditCloudConnection) CreateVPNGatewayHandler() (irs.VPNGatewayHandler, error) {
+	return nil, errors.New("Cloudit Driver: not implemented")
+}
+
+func (cloudConn *ClouditCloudConnection) CreateDNSHandler() (irs.DNSHandler, error) {
+	return nil, errors.New("Cloudit Driver: not implemented")
+}
+
+func (cloudConn *ClouditCloudConnection) CreateALBHandler() (irs.ALBHandler, error) {
+	return nil, errors.New("Cloudit Driver: not implemented")
+}
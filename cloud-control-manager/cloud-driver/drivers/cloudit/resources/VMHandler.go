@@ -1274,3 +1274,11 @@ func (vmHandler *ClouditVMHandler) ListIID() ([]*irs.IID, error) {
 	cblogger.Info("Cloud driver: called ListIID()!!")
 	return nil, errors.New("Does not support ListIID() yet!!")
 }
+
+func (vmHandler *ClouditVMHandler) ChangeVMSpec(vmIID irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	return irs.VMInfo{}, errors.New("Does not support ChangeVMSpec() yet!!")
+}
+
+func (vmHandler *ClouditVMHandler) GetConsoleOutput(vmIID irs.IID) (string, error) {
+	return "", errors.New("Does not support GetConsoleOutput() yet!!")
+}
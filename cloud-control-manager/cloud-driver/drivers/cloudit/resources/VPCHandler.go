@@ -724,3 +724,11 @@ func (VPCHandler *ClouditVPCHandler) ListIID() ([]*irs.IID, error) {
 	cblogger.Info("Cloud driver: called ListIID()!!")
 	return nil, errors.New("Does not support ListIID() yet!!")
 }
+
+func (vpcHandler *ClouditVPCHandler) GetSubnetUsage(vpcIID irs.IID, subnetIID irs.IID) (irs.SubnetUsageInfo, error) {
+	return irs.SubnetUsageInfo{}, errors.New("Does not support GetSubnetUsage() yet!!")
+}
+
+func (vpcHandler *ClouditVPCHandler) UpdateSubnet(vpcIID irs.IID, subnetIID irs.IID, updateInfo irs.SubnetUpdateInfo) (irs.SubnetInfo, error) {
+	return irs.SubnetInfo{}, errors.New("Does not support UpdateSubnet() yet!!")
+}
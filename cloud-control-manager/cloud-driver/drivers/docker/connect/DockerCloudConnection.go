@@ -122,3 +122,27 @@ func (*DockerCloudConnection) CreatePriceInfoHandler() (irs.PriceInfoHandler, er
 func (cloudConn *DockerCloudConnection) CreateTagHandler() (irs.TagHandler, error) {
 	return nil, errors.New("Docker Driver: not implemented")
 }
+
+func (cloudConn *DockerCloudConnection) CreateVPCPeeringHandler() (irs.VPCPeeringHandler, error) {
+	return nil, errors.New("Docker Driver: not implemented")
+}
+
+func (cloudConn *DockerCloudConnection) CreateRouteTableHandler() (irs.RouteTableHandler, error) {
+	return nil, errors.New("Docker Driver: not implemented")
+}
+
+func (cloudConn *DockerCloudConnection) CreateNATGatewayHandler() (irs.NATGatewayHandler, error) {
+	return nil, errors.New("Docker Driver: not implemented")
+}
+
+func (cloudConn *DockerCloudConnection) CreateVPNGatewayHandler() (irs.VPNGatewayHandler, error) {
+	return nil, errors.New("Docker Driver: not implemented")
+}
+
+func (cloudConn *DockerCloudConnection) CreateDNSHandler() (irs.DNSHandler, error) {
+	return nil, errors.New("Docker Driver: not implemented")
+}
+
+func (cloudConn *DockerCloudConnection) CreateALBHandler() (irs.ALBHandler, error) {
+	return nil, errors.New("Docker Driver: not implemented")
+}
@@ -181,3 +181,27 @@ func (cloudConn *GCPCloudConnection) CreateTagHandler() (irs.TagHandler, error)
 
 	return &tagHandler, nil
 }
+
+func (cloudConn *GCPCloudConnection) CreateVPCPeeringHandler() (irs.VPCPeeringHandler, error) {
+	return nil, errors.New("GCP Cloud Driver: not implemented")
+}
+
+func (cloudConn *GCPCloudConnection) CreateRouteTableHandler() (irs.RouteTableHandler, error) {
+	return nil, errors.New("GCP Cloud Driver: not implemented")
+}
+
+func (cloudConn *GCPCloudConnection) CreateNATGatewayHandler() (irs.NATGatewayHandler, error) {
+	return nil, errors.New("GCP Cloud Driver: not implemented")
+}
+
+func (cloudConn *GCPCloudConnection) CreateVPNGatewayHandler() (irs.VPNGatewayHandler, error) {
+	return nil, errors.New("GCP Cloud Driver: not implemented")
+}
+
+func (cloudConn *GCPCloudConnection) CreateDNSHandler() (irs.DNSHandler, error) {
+	return nil, errors.New("GCP Cloud Driver: not implemented")
+}
+
+func (cloudConn *GCPCloudConnection) CreateALBHandler() (irs.ALBHandler, error) {
+	return nil, errors.New("GCP Cloud Driver: not implemented")
+}
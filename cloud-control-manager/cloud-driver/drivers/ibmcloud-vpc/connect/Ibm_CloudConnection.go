@@ -204,3 +204,27 @@ func (cloudConn *IbmCloudConnection) CreateTagHandler() (irs.TagHandler, error)
 	}
 	return &TagHandler, nil
 }
+
+func (cloudConn *IbmCloudConnection) CreateVPCPeeringHandler() (irs.VPCPeeringHandler, error) {
+	return nil, errors.New("Ibm Driver: not implemented")
+}
+
+func (cloudConn *IbmCloudConnection) CreateRouteTableHandler() (irs.RouteTableHandler, error) {
+	return nil, errors.New("Ibm Driver: not implemented")
+}
+
+func (cloudConn *IbmCloudConnection) CreateNATGatewayHandler() (irs.NATGatewayHandler, error) {
+	return nil, errors.New("Ibm Driver: not implemented")
+}
+
+func (cloudConn *IbmCloudConnection) CreateVPNGatewayHandler() (irs.VPNGatewayHandler, error) {
+	return nil, errors.New("Ibm Driver: not implemented")
+}
+
+func (cloudConn *IbmCloudConnection) CreateDNSHandler() (irs.DNSHandler, error) {
+	return nil, errors.New("Ibm Driver: not implemented")
+}
+
+func (cloudConn *IbmCloudConnection) CreateALBHandler() (irs.ALBHandler, error) {
+	return nil, errors.New("Ibm Driver: not implemented")
+}
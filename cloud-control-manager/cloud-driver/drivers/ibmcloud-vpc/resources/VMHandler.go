@@ -1521,3 +1521,11 @@ func (vmHandler *IbmVMHandler) ListIID() ([]*irs.IID, error) {
 
 	return iidList, nil
 }
+
+func (vmHandler *IbmVMHandler) ChangeVMSpec(vmIID irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	return irs.VMInfo{}, errors.New("Does not support ChangeVMSpec() yet!!")
+}
+
+func (vmHandler *IbmVMHandler) GetConsoleOutput(vmIID irs.IID) (string, error) {
+	return "", errors.New("Does not support GetConsoleOutput() yet!!")
+}
@@ -141,3 +141,33 @@ func (cloudConn *KtCloudConnection) Close() error {
 	cblogger.Info("KT Cloud Driver: called Close()!")
 	return nil
 }
+
+func (cloudConn *KtCloudConnection) CreateVPCPeeringHandler() (irs.VPCPeeringHandler, error) {
+	cblogger.Info("KT Cloud Driver: called CreateVPCPeeringHandler()!")
+	return nil, fmt.Errorf("KT Cloud Driver does not support CreateVPCPeeringHandler yet.")
+}
+
+func (cloudConn *KtCloudConnection) CreateRouteTableHandler() (irs.RouteTableHandler, error) {
+	cblogger.Info("KT Cloud Driver: called CreateRouteTableHandler()!")
+	return nil, fmt.Errorf("KT Cloud Driver does not support CreateRouteTableHandler yet.")
+}
+
+func (cloudConn *KtCloudConnection) CreateNATGatewayHandler() (irs.NATGatewayHandler, error) {
+	cblogger.Info("KT Cloud Driver: called CreateNATGatewayHandler()!")
+	return nil, fmt.Errorf("KT Cloud Driver does not support CreateNATGatewayHandler yet.")
+}
+
+func (cloudConn *KtCloudConnection) CreateVPNGatewayHandler() (irs.VPNGatewayHandler, error) {
+	cblogger.Info("KT Cloud Driver: called CreateVPNGatewayHandler()!")
+	return nil, fmt.Errorf("KT Cloud Driver does not support CreateVPNGatewayHandler yet.")
+}
+
+func (cloudConn *KtCloudConnection) CreateDNSHandler() (irs.DNSHandler, error) {
+	cblogger.Info("KT Cloud Driver: called CreateDNSHandler()!")
+	return nil, fmt.Errorf("KT Cloud Driver does not support CreateDNSHandler yet.")
+}
+
+func (cloudConn *KtCloudConnection) CreateALBHandler() (irs.ALBHandler, error) {
+	cblogger.Info("KT Cloud Driver: called CreateALBHandler()!")
+	return nil, fmt.Errorf("KT Cloud Driver does not support CreateALBHandler yet.")
+}
@@ -131,3 +131,27 @@ func (cloudConn *KTCloudVpcConnection) Close() error {
 func (cloudConn *KTCloudVpcConnection) CreateTagHandler() (irs.TagHandler, error) {
 	return nil, fmt.Errorf("KT Cloud VPC Driver: not implemented")
 }
+
+func (cloudConn *KTCloudVpcConnection) CreateVPCPeeringHandler() (irs.VPCPeeringHandler, error) {
+	return nil, fmt.Errorf("KT Cloud VPC Driver: not implemented")
+}
+
+func (cloudConn *KTCloudVpcConnection) CreateRouteTableHandler() (irs.RouteTableHandler, error) {
+	return nil, fmt.Errorf("KT Cloud VPC Driver: not implemented")
+}
+
+func (cloudConn *KTCloudVpcConnection) CreateNATGatewayHandler() (irs.NATGatewayHandler, error) {
+	return nil, fmt.Errorf("KT Cloud VPC Driver: not implemented")
+}
+
+func (cloudConn *KTCloudVpcConnection) CreateVPNGatewayHandler() (irs.VPNGatewayHandler, error) {
+	return nil, fmt.Errorf("KT Cloud VPC Driver: not implemented")
+}
+
+func (cloudConn *KTCloudVpcConnection) CreateDNSHandler() (irs.DNSHandler, error) {
+	return nil, fmt.Errorf("KT Cloud VPC Driver: not implemented")
+}
+
+func (cloudConn *KTCloudVpcConnection) CreateALBHandler() (irs.ALBHandler, error) {
+	return nil, fmt.Errorf("KT Cloud VPC Driver: not implemented")
+}
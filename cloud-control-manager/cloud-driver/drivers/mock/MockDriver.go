@@ -46,6 +46,21 @@ func (MockDriver) GetDriverCapability() idrv.DriverCapabilityInfo {
 	drvCapabilityInfo.VMHandler = true
 	drvCapabilityInfo.VMSpecHandler = true
 	drvCapabilityInfo.TagHandler = true
+	drvCapabilityInfo.VPCPeeringHandler = true
+	drvCapabilityInfo.RouteTableHandler = true
+	drvCapabilityInfo.NATGatewayHandler = true
+	drvCapabilityInfo.DNSHandler = true
+	drvCapabilityInfo.ALBHandler = true
+	drvCapabilityInfo.VPNGatewayHandler = true
+	drvCapabilityInfo.USER_DATA = true
+	drvCapabilityInfo.SPOT_VM = true
+	drvCapabilityInfo.PREEMPTIBLE_VM = true
+	drvCapabilityInfo.CONSOLE_OUTPUT = true
+	drvCapabilityInfo.VM_SPEC_CHANGE = true
+	drvCapabilityInfo.SG_REFERENCE_RULE = true
+	drvCapabilityInfo.SG_RULE_PRIORITY = true
+	drvCapabilityInfo.SG_DENY_RULE = true
+	drvCapabilityInfo.IPV6 = true
 	drvCapabilityInfo.TagSupportResourceType = []ires.RSType{ires.ALL, ires.VPC, ires.SUBNET, ires.SG, ires.KEY, ires.VM, ires.NLB, ires.DISK, ires.MYIMAGE, ires.CLUSTER}
 
 	return drvCapabilityInfo
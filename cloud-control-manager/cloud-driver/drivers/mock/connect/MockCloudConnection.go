@@ -56,12 +56,48 @@ func (cloudConn *MockConnection) CreateVMSpecHandler() (irs.VMSpecHandler, error
 	return &handler, nil
 }
 
+func (cloudConn *MockConnection) CreateVPCPeeringHandler() (irs.VPCPeeringHandler, error) {
+	cblogger.Info("Mock Driver: called CreateVPCPeeringHandler()!")
+	handler := mkrs.MockVPCPeeringHandler{MockName: cloudConn.MockName}
+	return &handler, nil
+}
+
+func (cloudConn *MockConnection) CreateRouteTableHandler() (irs.RouteTableHandler, error) {
+	cblogger.Info("Mock Driver: called CreateRouteTableHandler()!")
+	handler := mkrs.MockRouteTableHandler{MockName: cloudConn.MockName}
+	return &handler, nil
+}
+
+func (cloudConn *MockConnection) CreateNATGatewayHandler() (irs.NATGatewayHandler, error) {
+	cblogger.Info("Mock Driver: called CreateNATGatewayHandler()!")
+	handler := mkrs.MockNATGatewayHandler{MockName: cloudConn.MockName}
+	return &handler, nil
+}
+
+func (cloudConn *MockConnection) CreateVPNGatewayHandler() (irs.VPNGatewayHandler, error) {
+	cblogger.Info("Mock Driver: called CreateVPNGatewayHandler()!")
+	handler := mkrs.MockVPNGatewayHandler{MockName: cloudConn.MockName}
+	return &handler, nil
+}
+
+func (cloudConn *MockConnection) CreateDNSHandler() (irs.DNSHandler, error) {
+	cblogger.Info("Mock Driver: called CreateDNSHandler()!")
+	handler := mkrs.MockDNSHandler{MockName: cloudConn.MockName}
+	return &handler, nil
+}
+
 func (cloudConn *MockConnection) CreateNLBHandler() (irs.NLBHandler, error) {
 	cblogger.Info("Mock Driver: called CreateNLBHandler()!")
 	handler := mkrs.MockNLBHandler{cloudConn.MockName}
 	return &handler, nil
 }
 
+func (cloudConn *MockConnection) CreateALBHandler() (irs.ALBHandler, error) {
+	cblogger.Info("Mock Driver: called CreateALBHandler()!")
+	handler := mkrs.MockALBHandler{MockName: cloudConn.MockName}
+	return &handler, nil
+}
+
 func (cloudConn *MockConnection) IsConnected() (bool, error) {
 	cblogger.Info("Mock Driver: called IsConnected()!")
 	if cloudConn == nil {
@@ -0,0 +1,331 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Mock Driver.
+//
+// by CB-Spider Team, 2024.10.
+
+package resources
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/xid"
+
+	cblog "github.com/cloud-barista/cb-log"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+// key: MockName
+var albInfoMap map[string][]*irs.ALBInfo
+
+type MockALBHandler struct {
+	MockName string
+}
+
+func init() {
+	// cblog is a global variable.
+	albInfoMap = make(map[string][]*irs.ALBInfo)
+}
+
+var albMapLock = new(sync.RWMutex)
+
+func (albHandler *MockALBHandler) CreateALB(albReqInfo irs.ALBInfo) (irs.ALBInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called CreateALB()!")
+
+	mockName := albHandler.MockName
+
+	if _, err := findMockVPCInfo(mockName, albReqInfo.VpcIID); err != nil {
+		return irs.ALBInfo{}, err
+	}
+
+	// check the references of Listeners to Target Groups
+	tgNames := map[string]bool{}
+	for _, tg := range albReqInfo.TargetGroups {
+		tgNames[tg.Name] = true
+	}
+	for _, listener := range albReqInfo.Listeners {
+		if strings.ToUpper(listener.Protocol) == "HTTPS" && listener.CertificateRef == "" {
+			return irs.ALBInfo{}, fmt.Errorf("%s:%s Listener requires a Certificate!!", listener.Protocol, listener.Port)
+		}
+		if !tgNames[listener.DefaultTargetGroup] {
+			return irs.ALBInfo{}, fmt.Errorf("%s Target Group does not exist!!", listener.DefaultTargetGroup)
+		}
+		for _, rule := range listener.Rules {
+			if !tgNames[rule.TargetGroup] {
+				return irs.ALBInfo{}, fmt.Errorf("%s Target Group does not exist!!", rule.TargetGroup)
+			}
+		}
+	}
+
+	albMapLock.Lock()
+	defer albMapLock.Unlock()
+
+	for _, info := range albInfoMap[mockName] {
+		if info.IId.NameId == albReqInfo.IId.NameId {
+			return irs.ALBInfo{}, fmt.Errorf("%s ALB already exists!!", albReqInfo.IId.NameId)
+		}
+	}
+
+	albInfo := CloneALBInfo(albReqInfo)
+	albInfo.IId.SystemId = albInfo.IId.NameId
+	albInfo.VpcIID.SystemId = albInfo.VpcIID.NameId
+	albInfo.IP = "1.2.3.6"
+	albInfo.DNSName = albInfo.IId.NameId + ".alb.mock.local"
+	albInfo.CreatedTime = time.Now()
+	for idx := range albInfo.Listeners {
+		albInfo.Listeners[idx].CspID = albInfo.IId.NameId + "-Listener-" + xid.New().String()
+		for ridx := range albInfo.Listeners[idx].Rules {
+			albInfo.Listeners[idx].Rules[ridx].CspID = albInfo.IId.NameId + "-Rule-" + xid.New().String()
+		}
+	}
+	for idx := range albInfo.TargetGroups {
+		albInfo.TargetGroups[idx].CspID = albInfo.IId.NameId + "-TargetGroup-" + xid.New().String()
+	}
+
+	// insert ALBInfo into global Map
+	albInfoMap[mockName] = append(albInfoMap[mockName], &albInfo)
+
+	return CloneALBInfo(albInfo), nil
+}
+
+func CloneALBInfoList(srcInfoList []*irs.ALBInfo) []*irs.ALBInfo {
+	clonedInfoList := []*irs.ALBInfo{}
+	for _, srcInfo := range srcInfoList {
+		clonedInfo := CloneALBInfo(*srcInfo)
+		clonedInfoList = append(clonedInfoList, &clonedInfo)
+	}
+	return clonedInfoList
+}
+
+func CloneALBInfo(srcInfo irs.ALBInfo) irs.ALBInfo {
+	clonedInfo := srcInfo
+
+	clonedInfo.Listeners = []irs.ALBListenerInfo{}
+	for _, listener := range srcInfo.Listeners {
+		clonedListener := listener
+		clonedListener.Rules = append([]irs.ALBRuleInfo(nil), listener.Rules...)
+		clonedListener.KeyValueList = append([]irs.KeyValue(nil), listener.KeyValueList...)
+		clonedInfo.Listeners = append(clonedInfo.Listeners, clonedListener)
+	}
+
+	clonedInfo.TargetGroups = []irs.TargetGroupInfo{}
+	for _, tg := range srcInfo.TargetGroups {
+		clonedInfo.TargetGroups = append(clonedInfo.TargetGroups, CloneTargetGroupInfo(tg))
+	}
+
+	clonedInfo.TagList = append([]irs.KeyValue(nil), srcInfo.TagList...)
+	clonedInfo.KeyValueList = append([]irs.KeyValue(nil), srcInfo.KeyValueList...)
+	return clonedInfo
+}
+
+func CloneTargetGroupInfo(srcInfo irs.TargetGroupInfo) irs.TargetGroupInfo {
+	clonedInfo := srcInfo
+	if srcInfo.VMs != nil {
+		clonedInfo.VMs = CloneVMs(srcInfo.VMs)
+	} else {
+		clonedInfo.VMs = &[]irs.IID{}
+	}
+	clonedInfo.HealthChecker = CloneHealthCheckerInfo(srcInfo.HealthChecker)
+	clonedInfo.KeyValueList = append([]irs.KeyValue(nil), srcInfo.KeyValueList...)
+	return clonedInfo
+}
+
+func (albHandler *MockALBHandler) ListALB() ([]*irs.ALBInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListALB()!")
+
+	mockName := albHandler.MockName
+	albMapLock.RLock()
+	defer albMapLock.RUnlock()
+
+	infoList, ok := albInfoMap[mockName]
+	if !ok {
+		return []*irs.ALBInfo{}, nil
+	}
+
+	return CloneALBInfoList(infoList), nil
+}
+
+func (albHandler *MockALBHandler) GetALB(albIID irs.IID) (irs.ALBInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetALB()!")
+
+	albMapLock.RLock()
+	defer albMapLock.RUnlock()
+
+	info := findMockALBInfo(albHandler.MockName, albIID)
+	if info == nil {
+		return irs.ALBInfo{}, fmt.Errorf("%s ALB does not exist!!", albIID.NameId)
+	}
+
+	return CloneALBInfo(*info), nil
+}
+
+// caller should hold albMapLock
+func findMockALBInfo(mockName string, albIID irs.IID) *irs.ALBInfo {
+	for _, info := range albInfoMap[mockName] {
+		if info.IId.SystemId == albIID.SystemId {
+			return info
+		}
+	}
+	return nil
+}
+
+// caller should hold albMapLock
+func findMockTargetGroupInfo(albInfo *irs.ALBInfo, targetGroupName string) *irs.TargetGroupInfo {
+	for idx, tg := range albInfo.TargetGroups {
+		if tg.Name == targetGroupName {
+			return &albInfo.TargetGroups[idx]
+		}
+	}
+	return nil
+}
+
+func (albHandler *MockALBHandler) DeleteALB(albIID irs.IID) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called DeleteALB()!")
+
+	albMapLock.Lock()
+	defer albMapLock.Unlock()
+
+	mockName := albHandler.MockName
+	infoList, ok := albInfoMap[mockName]
+	if !ok {
+		return false, fmt.Errorf("%s ALB does not exist!!", albIID.NameId)
+	}
+
+	for idx, info := range infoList {
+		if info.IId.SystemId == albIID.SystemId {
+			albInfoMap[mockName] = append(infoList[:idx], infoList[idx+1:]...)
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("%s ALB does not exist!!", albIID.NameId)
+}
+
+func (albHandler *MockALBHandler) AddTargetVMs(albIID irs.IID, targetGroupName string, vmIIDs *[]irs.IID) (irs.TargetGroupInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called AddTargetVMs()!")
+
+	albMapLock.Lock()
+	defer albMapLock.Unlock()
+
+	albInfo := findMockALBInfo(albHandler.MockName, albIID)
+	if albInfo == nil {
+		return irs.TargetGroupInfo{}, fmt.Errorf("%s ALB does not exist!!", albIID.NameId)
+	}
+	tgInfo := findMockTargetGroupInfo(albInfo, targetGroupName)
+	if tgInfo == nil {
+		return irs.TargetGroupInfo{}, fmt.Errorf("%s Target Group does not exist!!", targetGroupName)
+	}
+	if tgInfo.VMs == nil {
+		tgInfo.VMs = &[]irs.IID{}
+	}
+
+	// check if all input VMs are new
+	for _, vmIID := range *vmIIDs {
+		for _, vm := range *tgInfo.VMs {
+			if vm.SystemId == vmIID.SystemId {
+				return irs.TargetGroupInfo{}, fmt.Errorf("%s Target Group already has this VM: %v!!", targetGroupName, vmIID)
+			}
+		}
+	}
+
+	*tgInfo.VMs = append(*tgInfo.VMs, *vmIIDs...)
+	return CloneTargetGroupInfo(*tgInfo), nil
+}
+
+func (albHandler *MockALBHandler) RemoveTargetVMs(albIID irs.IID, targetGroupName string, vmIIDs *[]irs.IID) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called RemoveTargetVMs()!")
+
+	albMapLock.Lock()
+	defer albMapLock.Unlock()
+
+	albInfo := findMockALBInfo(albHandler.MockName, albIID)
+	if albInfo == nil {
+		return false, fmt.Errorf("%s ALB does not exist!!", albIID.NameId)
+	}
+	tgInfo := findMockTargetGroupInfo(albInfo, targetGroupName)
+	if tgInfo == nil || tgInfo.VMs == nil {
+		return false, fmt.Errorf("%s Target Group does not exist!!", targetGroupName)
+	}
+
+	// check if all input VMs exist
+	for _, vmIID := range *vmIIDs {
+		existFlag := false
+		for _, vm := range *tgInfo.VMs {
+			if vm.SystemId == vmIID.SystemId {
+				existFlag = true
+				break
+			}
+		}
+		if !existFlag {
+			return false, fmt.Errorf("%s Target Group does not have this VM: %v!!", targetGroupName, vmIID)
+		}
+	}
+
+	for _, vmIID := range *vmIIDs {
+		for idx, vm := range *tgInfo.VMs {
+			if vm.SystemId == vmIID.SystemId {
+				*tgInfo.VMs = removeVM(tgInfo.VMs, idx)
+				break
+			}
+		}
+	}
+	return true, nil
+}
+
+func (albHandler *MockALBHandler) GetTargetGroupHealthInfo(albIID irs.IID, targetGroupName string) (irs.HealthInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetTargetGroupHealthInfo()!")
+
+	albMapLock.RLock()
+	defer albMapLock.RUnlock()
+
+	albInfo := findMockALBInfo(albHandler.MockName, albIID)
+	if albInfo == nil {
+		return irs.HealthInfo{}, fmt.Errorf("%s ALB does not exist!!", albIID.NameId)
+	}
+	tgInfo := findMockTargetGroupInfo(albInfo, targetGroupName)
+	if tgInfo == nil {
+		return irs.HealthInfo{}, fmt.Errorf("%s Target Group does not exist!!", targetGroupName)
+	}
+
+	// all Target VMs are healthy in the Mock Driver
+	healthInfo := irs.HealthInfo{AllVMs: &[]irs.IID{}, HealthyVMs: &[]irs.IID{}, UnHealthyVMs: &[]irs.IID{}}
+	if tgInfo.VMs != nil {
+		*healthInfo.AllVMs = append(*healthInfo.AllVMs, *tgInfo.VMs...)
+		*healthInfo.HealthyVMs = append(*healthInfo.HealthyVMs, *tgInfo.VMs...)
+	}
+	return healthInfo, nil
+}
+
+func (albHandler *MockALBHandler) ListIID() ([]*irs.IID, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListIID()!")
+
+	mockName := albHandler.MockName
+	albMapLock.RLock()
+	defer albMapLock.RUnlock()
+
+	infoList, ok := albInfoMap[mockName]
+	if !ok {
+		return []*irs.IID{}, nil
+	}
+
+	iidList := make([]*irs.IID, len(infoList))
+	for i, info := range infoList {
+		iid := info.IId
+		iidList[i] = &iid
+	}
+	return iidList, nil
+}
@@ -0,0 +1,267 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Mock Driver.
+//
+// by CB-Spider Team, 2024.10.
+
+package resources
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	cblog "github.com/cloud-barista/cb-log"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+// key: MockName
+var dnsZoneInfoMap map[string][]*irs.DNSZoneInfo
+
+// key: MockName + ":" + Zone SystemId
+var dnsRecordInfoMap map[string][]*irs.DNSRecordInfo
+
+type MockDNSHandler struct {
+	MockName string
+}
+
+func init() {
+	// cblog is a global variable.
+	dnsZoneInfoMap = make(map[string][]*irs.DNSZoneInfo)
+	dnsRecordInfoMap = make(map[string][]*irs.DNSRecordInfo)
+}
+
+var dnsMapLock = new(sync.RWMutex)
+
+func getMockDNSRecordKey(mockName string, zoneIID irs.IID) string {
+	return mockName + ":" + zoneIID.SystemId
+}
+
+// The caller must hold the dnsMapLock.
+func findMockDNSZone(mockName string, zoneIID irs.IID) (*irs.DNSZoneInfo, error) {
+	for _, info := range dnsZoneInfoMap[mockName] {
+		if info.IId.SystemId == zoneIID.SystemId {
+			return info, nil
+		}
+	}
+	return nil, fmt.Errorf("%s DNS Zone does not exist!!", zoneIID.NameId)
+}
+
+func (dnsHandler *MockDNSHandler) CreateZone(zoneReqInfo irs.DNSZoneReqInfo) (irs.DNSZoneInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called CreateZone()!")
+
+	mockName := dnsHandler.MockName
+
+	dnsMapLock.Lock()
+	defer dnsMapLock.Unlock()
+
+	for _, info := range dnsZoneInfoMap[mockName] {
+		if info.DomainName == zoneReqInfo.DomainName {
+			return irs.DNSZoneInfo{}, fmt.Errorf("%s DNS Zone already exists!!", zoneReqInfo.DomainName)
+		}
+	}
+
+	zoneInfo := irs.DNSZoneInfo{
+		IId:         irs.IID{NameId: zoneReqInfo.IId.NameId, SystemId: zoneReqInfo.IId.NameId},
+		DomainName:  zoneReqInfo.DomainName,
+		NameServers: []string{"ns-1.mock-dns.com", "ns-2.mock-dns.com"},
+		CreatedTime: time.Now(),
+		TagList:     zoneReqInfo.TagList,
+	}
+
+	// insert DNSZoneInfo into global Map
+	dnsZoneInfoMap[mockName] = append(dnsZoneInfoMap[mockName], &zoneInfo)
+	dnsRecordInfoMap[getMockDNSRecordKey(mockName, zoneInfo.IId)] = []*irs.DNSRecordInfo{}
+
+	return CloneDNSZoneInfo(zoneInfo), nil
+}
+
+func CloneDNSZoneInfoList(srcInfoList []*irs.DNSZoneInfo) []*irs.DNSZoneInfo {
+	clonedInfoList := []*irs.DNSZoneInfo{}
+	for _, srcInfo := range srcInfoList {
+		clonedInfo := CloneDNSZoneInfo(*srcInfo)
+		clonedInfoList = append(clonedInfoList, &clonedInfo)
+	}
+	return clonedInfoList
+}
+
+func CloneDNSZoneInfo(srcInfo irs.DNSZoneInfo) irs.DNSZoneInfo {
+	clonedInfo := srcInfo
+	clonedInfo.NameServers = append([]string(nil), srcInfo.NameServers...)
+	clonedInfo.TagList = append([]irs.KeyValue(nil), srcInfo.TagList...)
+	clonedInfo.KeyValueList = append([]irs.KeyValue(nil), srcInfo.KeyValueList...)
+	return clonedInfo
+}
+
+func CloneDNSRecordInfo(srcInfo irs.DNSRecordInfo) irs.DNSRecordInfo {
+	clonedInfo := srcInfo
+	clonedInfo.Values = append([]string(nil), srcInfo.Values...)
+	clonedInfo.KeyValueList = append([]irs.KeyValue(nil), srcInfo.KeyValueList...)
+	return clonedInfo
+}
+
+func (dnsHandler *MockDNSHandler) ListZone() ([]*irs.DNSZoneInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListZone()!")
+
+	mockName := dnsHandler.MockName
+	dnsMapLock.RLock()
+	defer dnsMapLock.RUnlock()
+
+	infoList := CloneDNSZoneInfoList(dnsZoneInfoMap[mockName])
+	for _, info := range infoList {
+		info.RecordCount = len(dnsRecordInfoMap[getMockDNSRecordKey(mockName, info.IId)])
+	}
+	return infoList, nil
+}
+
+func (dnsHandler *MockDNSHandler) GetZone(zoneIID irs.IID) (irs.DNSZoneInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetZone()!")
+
+	mockName := dnsHandler.MockName
+	dnsMapLock.RLock()
+	defer dnsMapLock.RUnlock()
+
+	info, err := findMockDNSZone(mockName, zoneIID)
+	if err != nil {
+		return irs.DNSZoneInfo{}, err
+	}
+
+	zoneInfo := CloneDNSZoneInfo(*info)
+	zoneInfo.RecordCount = len(dnsRecordInfoMap[getMockDNSRecordKey(mockName, zoneIID)])
+	return zoneInfo, nil
+}
+
+func (dnsHandler *MockDNSHandler) DeleteZone(zoneIID irs.IID) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called DeleteZone()!")
+
+	mockName := dnsHandler.MockName
+	dnsMapLock.Lock()
+	defer dnsMapLock.Unlock()
+
+	infoList := dnsZoneInfoMap[mockName]
+	for idx, info := range infoList {
+		if info.IId.SystemId == zoneIID.SystemId {
+			dnsZoneInfoMap[mockName] = append(infoList[:idx], infoList[idx+1:]...)
+			delete(dnsRecordInfoMap, getMockDNSRecordKey(mockName, zoneIID))
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("%s DNS Zone does not exist!!", zoneIID.NameId)
+}
+
+func (dnsHandler *MockDNSHandler) ListRecord(zoneIID irs.IID) ([]*irs.DNSRecordInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListRecord()!")
+
+	mockName := dnsHandler.MockName
+	dnsMapLock.RLock()
+	defer dnsMapLock.RUnlock()
+
+	if _, err := findMockDNSZone(mockName, zoneIID); err != nil {
+		return nil, err
+	}
+
+	infoList := []*irs.DNSRecordInfo{}
+	for _, info := range dnsRecordInfoMap[getMockDNSRecordKey(mockName, zoneIID)] {
+		clonedInfo := CloneDNSRecordInfo(*info)
+		infoList = append(infoList, &clonedInfo)
+	}
+	return infoList, nil
+}
+
+func (dnsHandler *MockDNSHandler) GetRecord(zoneIID irs.IID, name string, recordType irs.DNSRecordType) (irs.DNSRecordInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetRecord()!")
+
+	mockName := dnsHandler.MockName
+	dnsMapLock.RLock()
+	defer dnsMapLock.RUnlock()
+
+	if _, err := findMockDNSZone(mockName, zoneIID); err != nil {
+		return irs.DNSRecordInfo{}, err
+	}
+
+	for _, info := range dnsRecordInfoMap[getMockDNSRecordKey(mockName, zoneIID)] {
+		if info.Name == name && info.Type == recordType {
+			return CloneDNSRecordInfo(*info), nil
+		}
+	}
+	return irs.DNSRecordInfo{}, fmt.Errorf("%s %s Record does not exist!!", name, recordType)
+}
+
+func (dnsHandler *MockDNSHandler) UpsertRecord(zoneIID irs.IID, record irs.DNSRecordInfo) (irs.DNSRecordInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called UpsertRecord()!")
+
+	mockName := dnsHandler.MockName
+	dnsMapLock.Lock()
+	defer dnsMapLock.Unlock()
+
+	if _, err := findMockDNSZone(mockName, zoneIID); err != nil {
+		return irs.DNSRecordInfo{}, err
+	}
+
+	key := getMockDNSRecordKey(mockName, zoneIID)
+	newRecord := CloneDNSRecordInfo(record)
+	for idx, info := range dnsRecordInfoMap[key] {
+		if info.Name == record.Name && info.Type == record.Type {
+			dnsRecordInfoMap[key][idx] = &newRecord
+			return CloneDNSRecordInfo(newRecord), nil
+		}
+		// CNAME can not coexist with other records of the same name
+		if info.Name == record.Name && (info.Type == irs.DNSRecordCNAME || record.Type == irs.DNSRecordCNAME) {
+			return irs.DNSRecordInfo{}, fmt.Errorf("%s CNAME Record can not coexist with other Records!!", record.Name)
+		}
+	}
+
+	dnsRecordInfoMap[key] = append(dnsRecordInfoMap[key], &newRecord)
+	return CloneDNSRecordInfo(newRecord), nil
+}
+
+func (dnsHandler *MockDNSHandler) DeleteRecord(zoneIID irs.IID, name string, recordType irs.DNSRecordType) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called DeleteRecord()!")
+
+	mockName := dnsHandler.MockName
+	dnsMapLock.Lock()
+	defer dnsMapLock.Unlock()
+
+	if _, err := findMockDNSZone(mockName, zoneIID); err != nil {
+		return false, err
+	}
+
+	key := getMockDNSRecordKey(mockName, zoneIID)
+	infoList := dnsRecordInfoMap[key]
+	for idx, info := range infoList {
+		if info.Name == name && info.Type == recordType {
+			dnsRecordInfoMap[key] = append(infoList[:idx], infoList[idx+1:]...)
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("%s %s Record does not exist!!", name, recordType)
+}
+
+func (dnsHandler *MockDNSHandler) ListIID() ([]*irs.IID, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListIID()!")
+
+	mockName := dnsHandler.MockName
+	dnsMapLock.RLock()
+	defer dnsMapLock.RUnlock()
+
+	infoList := dnsZoneInfoMap[mockName]
+	iidList := make([]*irs.IID, len(infoList))
+	for i, info := range infoList {
+		iid := info.IId
+		iidList[i] = &iid
+	}
+	return iidList, nil
+}
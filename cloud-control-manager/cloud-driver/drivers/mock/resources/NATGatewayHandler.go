@@ -0,0 +1,180 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Mock Driver.
+//
+// by CB-Spider Team, 2024.10.
+
+package resources
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	cblog "github.com/cloud-barista/cb-log"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+// key: MockName
+var natGatewayInfoMap map[string][]*irs.NATGatewayInfo
+
+// sequence number for allocating mock public IPs
+var natPublicIPSeq int
+
+type MockNATGatewayHandler struct {
+	MockName string
+}
+
+func init() {
+	// cblog is a global variable.
+	natGatewayInfoMap = make(map[string][]*irs.NATGatewayInfo)
+}
+
+var natGatewayMapLock = new(sync.RWMutex)
+
+func (natHandler *MockNATGatewayHandler) CreateNATGateway(natReqInfo irs.NATGatewayReqInfo) (irs.NATGatewayInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called CreateNATGateway()!")
+
+	mockName := natHandler.MockName
+
+	vpcInfo, err := findMockVPCInfo(mockName, natReqInfo.VpcIID)
+	if err != nil {
+		return irs.NATGatewayInfo{}, err
+	}
+
+	var subnetInfo *irs.SubnetInfo
+	for idx, info := range vpcInfo.SubnetInfoList {
+		if info.IId.SystemId == natReqInfo.SubnetIID.SystemId {
+			subnetInfo = &vpcInfo.SubnetInfoList[idx]
+			break
+		}
+	}
+	if subnetInfo == nil {
+		return irs.NATGatewayInfo{}, fmt.Errorf("%s Subnet does not exist!!", natReqInfo.SubnetIID.NameId)
+	}
+
+	natGatewayMapLock.Lock()
+	defer natGatewayMapLock.Unlock()
+
+	for _, info := range natGatewayInfoMap[mockName] {
+		if info.IId.NameId == natReqInfo.IId.NameId {
+			return irs.NATGatewayInfo{}, fmt.Errorf("%s NAT Gateway already exists!!", natReqInfo.IId.NameId)
+		}
+	}
+
+	// allocate a public IP
+	natPublicIPSeq++
+	natInfo := irs.NATGatewayInfo{
+		IId:          irs.IID{NameId: natReqInfo.IId.NameId, SystemId: natReqInfo.IId.NameId},
+		VpcIID:       vpcInfo.IId,
+		SubnetIID:    subnetInfo.IId,
+		PublicIP:     fmt.Sprintf("5.6.%d.%d", natPublicIPSeq/250, natPublicIPSeq%250+1),
+		PrivateIP:    "1.2.3.5",
+		Status:       irs.NATGatewayAvailable,
+		CreatedTime:  time.Now(),
+		TagList:      natReqInfo.TagList,
+		KeyValueList: []irs.KeyValue{{Key: "SubnetCIDR", Value: subnetInfo.IPv4_CIDR}},
+	}
+
+	// insert NATGatewayInfo into global Map
+	natGatewayInfoMap[mockName] = append(natGatewayInfoMap[mockName], &natInfo)
+
+	return CloneNATGatewayInfo(natInfo), nil
+}
+
+func CloneNATGatewayInfoList(srcInfoList []*irs.NATGatewayInfo) []*irs.NATGatewayInfo {
+	clonedInfoList := []*irs.NATGatewayInfo{}
+	for _, srcInfo := range srcInfoList {
+		clonedInfo := CloneNATGatewayInfo(*srcInfo)
+		clonedInfoList = append(clonedInfoList, &clonedInfo)
+	}
+	return clonedInfoList
+}
+
+func CloneNATGatewayInfo(srcInfo irs.NATGatewayInfo) irs.NATGatewayInfo {
+	clonedInfo := srcInfo
+	clonedInfo.TagList = append([]irs.KeyValue(nil), srcInfo.TagList...)
+	clonedInfo.KeyValueList = append([]irs.KeyValue(nil), srcInfo.KeyValueList...)
+	return clonedInfo
+}
+
+func (natHandler *MockNATGatewayHandler) ListNATGateway() ([]*irs.NATGatewayInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListNATGateway()!")
+
+	mockName := natHandler.MockName
+	natGatewayMapLock.RLock()
+	defer natGatewayMapLock.RUnlock()
+
+	infoList, ok := natGatewayInfoMap[mockName]
+	if !ok {
+		return []*irs.NATGatewayInfo{}, nil
+	}
+
+	return CloneNATGatewayInfoList(infoList), nil
+}
+
+func (natHandler *MockNATGatewayHandler) GetNATGateway(natIID irs.IID) (irs.NATGatewayInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetNATGateway()!")
+
+	natGatewayMapLock.RLock()
+	defer natGatewayMapLock.RUnlock()
+
+	mockName := natHandler.MockName
+	for _, info := range natGatewayInfoMap[mockName] {
+		if info.IId.SystemId == natIID.SystemId {
+			return CloneNATGatewayInfo(*info), nil
+		}
+	}
+
+	return irs.NATGatewayInfo{}, fmt.Errorf("%s NAT Gateway does not exist!!", natIID.NameId)
+}
+
+func (natHandler *MockNATGatewayHandler) DeleteNATGateway(natIID irs.IID) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called DeleteNATGateway()!")
+
+	natGatewayMapLock.Lock()
+	defer natGatewayMapLock.Unlock()
+
+	mockName := natHandler.MockName
+	infoList, ok := natGatewayInfoMap[mockName]
+	if !ok {
+		return false, fmt.Errorf("%s NAT Gateway does not exist!!", natIID.NameId)
+	}
+
+	for idx, info := range infoList {
+		if info.IId.SystemId == natIID.SystemId {
+			natGatewayInfoMap[mockName] = append(infoList[:idx], infoList[idx+1:]...)
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("%s NAT Gateway does not exist!!", natIID.NameId)
+}
+
+func (natHandler *MockNATGatewayHandler) ListIID() ([]*irs.IID, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListIID()!")
+
+	mockName := natHandler.MockName
+	natGatewayMapLock.RLock()
+	defer natGatewayMapLock.RUnlock()
+
+	infoList, ok := natGatewayInfoMap[mockName]
+	if !ok {
+		return []*irs.IID{}, nil
+	}
+
+	iidList := make([]*irs.IID, len(infoList))
+	for i, info := range infoList {
+		iid := info.IId
+		iidList[i] = &iid
+	}
+	return iidList, nil
+}
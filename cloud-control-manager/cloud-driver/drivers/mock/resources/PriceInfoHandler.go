@@ -87,6 +87,7 @@ type NLBInfo struct {
 // ------- common struct for price info
 type PricingList struct {
 	PayAsYouGo PayAsYouGo   `json:"payAsYouGo"`
+	Spot       *PayAsYouGo  `json:"spot,omitempty"` // only for Compute Instance
 	SavingPlan []SavingPlan `json:"savingPlan"`
 }
 type PayAsYouGo struct {
@@ -397,6 +398,19 @@ func transformToPriceInfo(productFamily string, jsonData *interface{}, filterLis
 		priceInfo.PricingPolicies = append(priceInfo.PricingPolicies, paygPolicy)
 	}
 
+	// Transform Spot to PricingPolicies
+	if priceList.Spot != nil && priceList.Spot.PricingId != "" {
+		spotPolicy := irs.PricingPolicies{
+			PricingId:     priceList.Spot.PricingId,
+			PricingPolicy: "Spot",
+			Unit:          priceList.Spot.Unit,
+			Currency:      priceList.Spot.Currency,
+			Price:         priceList.Spot.Price,
+			Description:   "Spot pricing policy",
+		}
+		priceInfo.PricingPolicies = append(priceInfo.PricingPolicies, spotPolicy)
+	}
+
 	// Transform SavingPlan to PricingPolicies
 	for _, plan := range priceList.SavingPlan {
 		savingPolicy := irs.PricingPolicies{
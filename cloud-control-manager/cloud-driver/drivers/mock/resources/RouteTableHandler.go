@@ -0,0 +1,215 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Mock Driver.
+//
+// by CB-Spider Team, 2024.10.
+
+package resources
+
+import (
+	"fmt"
+	"sync"
+
+	cblog "github.com/cloud-barista/cb-log"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+// key: MockName
+var routeTableInfoMap map[string][]*irs.RouteTableInfo
+
+type MockRouteTableHandler struct {
+	MockName string
+}
+
+func init() {
+	// cblog is a global variable.
+	routeTableInfoMap = make(map[string][]*irs.RouteTableInfo)
+}
+
+var routeTableMapLock = new(sync.RWMutex)
+
+func CloneRouteTableInfo(srcInfo irs.RouteTableInfo) irs.RouteTableInfo {
+	clonedInfo := srcInfo
+	clonedInfo.SubnetIIDs = append([]irs.IID(nil), srcInfo.SubnetIIDs...)
+	clonedInfo.Routes = append([]irs.RouteInfo(nil), srcInfo.Routes...)
+	clonedInfo.KeyValueList = append([]irs.KeyValue(nil), srcInfo.KeyValueList...)
+	return clonedInfo
+}
+
+// The main Route Table is created with the local route when it is used at first.
+// The caller must hold the routeTableMapLock.
+func getMockMainRouteTable(mockName string, vpcInfo irs.VPCInfo) *irs.RouteTableInfo {
+	for _, info := range routeTableInfoMap[mockName] {
+		if info.VpcIID.SystemId == vpcInfo.IId.SystemId && info.IsMain {
+			return info
+		}
+	}
+
+	mainTable := irs.RouteTableInfo{
+		IId:    irs.IID{NameId: "rtb-" + vpcInfo.IId.SystemId, SystemId: "rtb-" + vpcInfo.IId.SystemId},
+		VpcIID: vpcInfo.IId,
+		IsMain: true,
+		Routes: []irs.RouteInfo{
+			{DestinationCIDR: vpcInfo.IPv4_CIDR, TargetType: irs.RouteTargetLocal},
+		},
+	}
+	routeTableInfoMap[mockName] = append(routeTableInfoMap[mockName], &mainTable)
+	return &mainTable
+}
+
+// get the Route Table applied to the subnet, the main Route Table if the subnet has no explicit association.
+// The caller must hold the routeTableMapLock.
+func getMockSubnetRouteTable(mockName string, vpcInfo irs.VPCInfo, subnetIID irs.IID) (*irs.RouteTableInfo, error) {
+	if subnetIID.SystemId == "" {
+		return getMockMainRouteTable(mockName, vpcInfo), nil
+	}
+
+	exist := false
+	for _, subnetInfo := range vpcInfo.SubnetInfoList {
+		if subnetInfo.IId.SystemId == subnetIID.SystemId {
+			exist = true
+			break
+		}
+	}
+	if !exist {
+		return nil, fmt.Errorf("%s Subnet does not exist!!", subnetIID.NameId)
+	}
+
+	for _, info := range routeTableInfoMap[mockName] {
+		if info.VpcIID.SystemId != vpcInfo.IId.SystemId {
+			continue
+		}
+		for _, iid := range info.SubnetIIDs {
+			if iid.SystemId == subnetIID.SystemId {
+				return info, nil
+			}
+		}
+	}
+	return getMockMainRouteTable(mockName, vpcInfo), nil
+}
+
+func (routeTableHandler *MockRouteTableHandler) ListRouteTable(vpcIID irs.IID) ([]*irs.RouteTableInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListRouteTable()!")
+
+	mockName := routeTableHandler.MockName
+	vpcInfo, err := findMockVPCInfo(mockName, vpcIID)
+	if err != nil {
+		return nil, err
+	}
+
+	routeTableMapLock.Lock()
+	defer routeTableMapLock.Unlock()
+
+	getMockMainRouteTable(mockName, vpcInfo)
+
+	infoList := []*irs.RouteTableInfo{}
+	for _, info := range routeTableInfoMap[mockName] {
+		if info.VpcIID.SystemId == vpcInfo.IId.SystemId {
+			clonedInfo := CloneRouteTableInfo(*info)
+			infoList = append(infoList, &clonedInfo)
+		}
+	}
+	return infoList, nil
+}
+
+func (routeTableHandler *MockRouteTableHandler) GetRouteTable(vpcIID irs.IID, subnetIID irs.IID) (irs.RouteTableInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetRouteTable()!")
+
+	mockName := routeTableHandler.MockName
+	vpcInfo, err := findMockVPCInfo(mockName, vpcIID)
+	if err != nil {
+		return irs.RouteTableInfo{}, err
+	}
+
+	routeTableMapLock.Lock()
+	defer routeTableMapLock.Unlock()
+
+	info, err := getMockSubnetRouteTable(mockName, vpcInfo, subnetIID)
+	if err != nil {
+		return irs.RouteTableInfo{}, err
+	}
+	return CloneRouteTableInfo(*info), nil
+}
+
+// If a route is added for a subnet using the main Route Table,
+// a new Route Table copied from the main one is associated with the subnet.
+func (routeTableHandler *MockRouteTableHandler) AddRoute(vpcIID irs.IID, subnetIID irs.IID, route irs.RouteInfo) (irs.RouteTableInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called AddRoute()!")
+
+	if route.TargetType == irs.RouteTargetLocal {
+		return irs.RouteTableInfo{}, fmt.Errorf("The %s route can not be added!!", irs.RouteTargetLocal)
+	}
+
+	mockName := routeTableHandler.MockName
+	vpcInfo, err := findMockVPCInfo(mockName, vpcIID)
+	if err != nil {
+		return irs.RouteTableInfo{}, err
+	}
+
+	routeTableMapLock.Lock()
+	defer routeTableMapLock.Unlock()
+
+	info, err := getMockSubnetRouteTable(mockName, vpcInfo, subnetIID)
+	if err != nil {
+		return irs.RouteTableInfo{}, err
+	}
+
+	for _, oneRoute := range info.Routes {
+		if oneRoute.DestinationCIDR == route.DestinationCIDR {
+			return irs.RouteTableInfo{}, fmt.Errorf("The route to %s already exists!!", route.DestinationCIDR)
+		}
+	}
+
+	if info.IsMain && subnetIID.SystemId != "" {
+		subnetTable := CloneRouteTableInfo(*info)
+		subnetTable.IId = irs.IID{NameId: info.IId.SystemId + "-" + subnetIID.SystemId, SystemId: info.IId.SystemId + "-" + subnetIID.SystemId}
+		subnetTable.IsMain = false
+		subnetTable.SubnetIIDs = []irs.IID{subnetIID}
+		routeTableInfoMap[mockName] = append(routeTableInfoMap[mockName], &subnetTable)
+		info = &subnetTable
+	}
+
+	route.KeyValueList = nil
+	info.Routes = append(info.Routes, route)
+
+	return CloneRouteTableInfo(*info), nil
+}
+
+func (routeTableHandler *MockRouteTableHandler) RemoveRoute(vpcIID irs.IID, subnetIID irs.IID, route irs.RouteInfo) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called RemoveRoute()!")
+
+	if route.TargetType == irs.RouteTargetLocal {
+		return false, fmt.Errorf("The %s route can not be removed!!", irs.RouteTargetLocal)
+	}
+
+	mockName := routeTableHandler.MockName
+	vpcInfo, err := findMockVPCInfo(mockName, vpcIID)
+	if err != nil {
+		return false, err
+	}
+
+	routeTableMapLock.Lock()
+	defer routeTableMapLock.Unlock()
+
+	info, err := getMockSubnetRouteTable(mockName, vpcInfo, subnetIID)
+	if err != nil {
+		return false, err
+	}
+
+	for idx, oneRoute := range info.Routes {
+		if oneRoute.DestinationCIDR == route.DestinationCIDR && oneRoute.TargetType == route.TargetType &&
+			oneRoute.TargetIID.SystemId == route.TargetIID.SystemId {
+			info.Routes = append(info.Routes[:idx], info.Routes[idx+1:]...)
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("The route to %s does not exist!!", route.DestinationCIDR)
+}
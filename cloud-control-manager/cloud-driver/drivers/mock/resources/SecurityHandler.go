@@ -12,6 +12,7 @@ package resources
 
 import (
 	"fmt"
+	"strconv"
 	"sync"
 
 	cblog "github.com/cloud-barista/cb-log"
@@ -37,6 +38,12 @@ func (securityHandler *MockSecurityHandler) CreateSecurity(securityReqInfo irs.S
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called CreateSecurity()!")
 
+	if securityReqInfo.SecurityRules != nil {
+		if err := validateRulePorts(securityReqInfo.SecurityRules); err != nil {
+			return irs.SecurityInfo{}, err
+		}
+	}
+
 	mockName := securityHandler.MockName
 	securityReqInfo.IId.SystemId = securityReqInfo.IId.NameId
 	securityReqInfo.VpcIID.SystemId = securityReqInfo.VpcIID.NameId
@@ -156,6 +163,10 @@ func (securityHandler *MockSecurityHandler) AddRules(sgIID irs.IID, securityRule
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called AddRules()!")
 
+	if err := validateRulePorts(securityRules); err != nil {
+		return irs.SecurityInfo{}, err
+	}
+
 	sgMapLock.Lock()
 	defer sgMapLock.Unlock()
 
@@ -247,6 +258,10 @@ func isEqualRule(a *irs.SecurityRuleInfo, b *irs.SecurityRuleInfo) bool {
 		FromPort   string
 		ToPort     string
 		CIDR       string
+		Description string
+		Priority    string
+		Action      string
+		SecurityGroupIID *IID
 	}
 	-------------------------------*/
 
@@ -265,10 +280,47 @@ func isEqualRule(a *irs.SecurityRuleInfo, b *irs.SecurityRuleInfo) bool {
 	if a.CIDR != b.CIDR {
 		return false
 	}
+	if a.Description != b.Description {
+		return false
+	}
+	if a.Priority != b.Priority {
+		return false
+	}
+	if a.Action != b.Action {
+		return false
+	}
+	if (a.SecurityGroupIID == nil) != (b.SecurityGroupIID == nil) {
+		return false
+	}
+	if a.SecurityGroupIID != nil && a.SecurityGroupIID.SystemId != b.SecurityGroupIID.SystemId {
+		return false
+	}
 
 	return true
 }
 
+// rejects an invalid port range like CSPs, ex) "80" ~ "70"
+// The port can be empty or -1 for all ports.
+func validateRulePorts(securityRules *[]irs.SecurityRuleInfo) error {
+	for _, rule := range *securityRules {
+		if rule.FromPort == "" || rule.ToPort == "" {
+			continue
+		}
+		fromPort, err := strconv.Atoi(rule.FromPort)
+		if err != nil || fromPort < -1 || fromPort > 65535 {
+			return fmt.Errorf("%s is not a valid port!!", rule.FromPort)
+		}
+		toPort, err := strconv.Atoi(rule.ToPort)
+		if err != nil || toPort < -1 || toPort > 65535 {
+			return fmt.Errorf("%s is not a valid port!!", rule.ToPort)
+		}
+		if fromPort > toPort {
+			return fmt.Errorf("%s ~ %s is not a valid port range!!", rule.FromPort, rule.ToPort)
+		}
+	}
+	return nil
+}
+
 func removeRule(list *[]irs.SecurityRuleInfo, idx int) []irs.SecurityRuleInfo {
 	return append((*list)[:idx], (*list)[idx+1:]...)
 }
@@ -12,6 +12,8 @@ package resources
 
 import (
 	"fmt"
+	"net"
+	"strings"
 	"sync"
 	"time"
 
@@ -42,6 +44,18 @@ func (vmHandler *MockVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, er
 	mockName := vmHandler.MockName
 	vmReqInfo.IId.SystemId = vmReqInfo.IId.NameId
 
+	// the name of a VM is unique like the CSPs
+	vmMapLock.RLock()
+	for _, info := range vmInfoMap[mockName] {
+		if info.IId.NameId == vmReqInfo.IId.NameId {
+			vmMapLock.RUnlock()
+			errMSG := vmReqInfo.IId.NameId + " vm already exists!!"
+			cblogger.Error(errMSG)
+			return irs.VMInfo{}, fmt.Errorf(errMSG)
+		}
+	}
+	vmMapLock.RUnlock()
+
 	validatedImageIID := irs.IID{}
 	// public image validation
 	if vmReqInfo.ImageType == irs.PublicImage {
@@ -150,6 +164,23 @@ func (vmHandler *MockVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, er
 		return irs.VMInfo{}, err
 	}
 
+	// user data validation
+	var keyValueList []irs.KeyValue
+	if vmReqInfo.UserData != "" {
+		userDataFormat, err := getMockUserDataFormat(vmReqInfo.UserData)
+		if err != nil {
+			cblogger.Error(err)
+			return irs.VMInfo{}, err
+		}
+		keyValueList = append(keyValueList, irs.KeyValue{Key: "UserDataFormat", Value: userDataFormat})
+	}
+
+	// purchase option
+	purchaseOption := vmReqInfo.PurchaseOption
+	if purchaseOption.Type == "" {
+		purchaseOption.Type = irs.OnDemand
+	}
+
 	// vm creation
 	vmInfo := irs.VMInfo{
 		IId:       vmReqInfo.IId,
@@ -172,6 +203,7 @@ func (vmHandler *MockVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, er
 		PublicDNS:        vmReqInfo.IId.NameId + ".spider.barista.com",
 		PrivateIP:        "1.2.3.4",
 		PrivateDNS:       vmReqInfo.IId.NameId + ".spider.barista.com",
+		IPv6Address:      getMockIPv6Address(validatedSubnetInfo.IPv6_CIDR),
 
 		VMBootDisk:  "/dev/sda1",
 		VMBlockDisk: "/dev/sda1",
@@ -182,8 +214,12 @@ func (vmHandler *MockVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, er
 
 		DataDiskIIDs: validatedDiskIIDs,
 
+		UserDataHash: irs.GetUserDataHash(vmReqInfo.UserData),
+
+		PurchaseOption: purchaseOption,
+
 		TagList:      vmReqInfo.TagList,
-		KeyValueList: nil,
+		KeyValueList: keyValueList,
 	}
 
 	// attach disks
@@ -212,6 +248,58 @@ func (vmHandler *MockVMHandler) StartVM(vmReqInfo irs.VMReqInfo) (irs.VMInfo, er
 	return vmInfo, nil
 }
 
+// returns the 4th address of the subnet IPv6 CIDR, empty for an IPv4 only subnet
+func getMockIPv6Address(ipv6CIDR string) string {
+	if ipv6CIDR == "" {
+		return ""
+	}
+	_, ipNet, err := net.ParseCIDR(ipv6CIDR)
+	if err != nil {
+		return ""
+	}
+	ip := make(net.IP, len(ipNet.IP))
+	copy(ip, ipNet.IP)
+	ip[len(ip)-1] += 4
+	return ip.String()
+}
+
+// The Mock Driver accepts the UserData formats supported by cloud-init.
+func getMockUserDataFormat(userData string) (string, error) {
+	switch {
+	case strings.HasPrefix(userData, "#cloud-config"):
+		return "cloud-init", nil
+	case strings.HasPrefix(userData, "#!"):
+		return "shell", nil
+	case strings.HasPrefix(userData, "#include"):
+		return "include", nil
+	case strings.HasPrefix(userData, "Content-Type: multipart/mixed"):
+		return "mime-multipart", nil
+	default:
+		return "", fmt.Errorf("UserData should start with '#cloud-config', '#!', '#include' or 'Content-Type: multipart/mixed'!!")
+	}
+}
+
+// PreemptVM simulates an interruption of a Spot or Preemptible VM by the CSP.
+func PreemptVM(mockName string, iid irs.IID) error {
+	vmMapLock.Lock()
+	defer vmMapLock.Unlock()
+
+	for _, info := range vmInfoMap[mockName] {
+		if (*info).IId.NameId == iid.NameId {
+			if info.PurchaseOption.Type != irs.Spot && info.PurchaseOption.Type != irs.Preemptible {
+				return fmt.Errorf("%s vm is not a Spot or Preemptible VM!!", iid.NameId)
+			}
+			for _, statusInfo := range vmStatusInfoMap[mockName] {
+				if (*statusInfo).IId.NameId == iid.NameId {
+					statusInfo.VmStatus = irs.Preempted
+					return nil
+				}
+			}
+		}
+	}
+	return fmt.Errorf("%s vm iid does not exist!!", iid.NameId)
+}
+
 func (vmHandler *MockVMHandler) SuspendVM(iid irs.IID) (irs.VMStatus, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called SuspendVM()!")
@@ -524,9 +612,14 @@ func CloneVMInfo(srcInfo irs.VMInfo) irs.VMInfo {
 		PublicDNS:        srcInfo.PublicDNS,
 		PrivateIP:        srcInfo.PrivateIP,
 		PrivateDNS:       srcInfo.PrivateDNS,
+		IPv6Address:      srcInfo.IPv6Address,
 
 		SSHAccessPoint: srcInfo.SSHAccessPoint,
 
+		UserDataHash: srcInfo.UserDataHash,
+
+		PurchaseOption: srcInfo.PurchaseOption,
+
 		TagList:      srcInfo.TagList,      // clone TagList
 		KeyValueList: srcInfo.KeyValueList, // now, do not need cloning
 	}
@@ -571,6 +664,76 @@ func (vmHandler *MockVMHandler) GetVM(iid irs.IID) (irs.VMInfo, error) {
 	return irs.VMInfo{}, fmt.Errorf(errMSG)
 }
 
+// Mock VMs should be Suspended to change the VMSpec like most CSPs(vmspecchange: STOP).
+func (vmHandler *MockVMHandler) ChangeVMSpec(iid irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ChangeVMSpec()!")
+
+	mockName := vmHandler.MockName
+
+	// spec validation
+	vmSpecHandler := MockVMSpecHandler{mockName}
+	validatedSpecInfo, err := vmSpecHandler.GetVMSpec(vmSpecName)
+	if err != nil {
+		cblogger.Error(err)
+		return irs.VMInfo{}, err
+	}
+
+	vmMapLock.Lock()
+	defer vmMapLock.Unlock()
+
+	var validatedStatusInfo *irs.VMStatusInfo = nil
+	for _, info := range vmStatusInfoMap[mockName] {
+		if (*info).IId.NameId == iid.NameId {
+			validatedStatusInfo = info
+		}
+	}
+	if validatedStatusInfo == nil {
+		errMSG := iid.NameId + " vm status iid does not exist!!"
+		cblogger.Error(errMSG)
+		return irs.VMInfo{}, fmt.Errorf(errMSG)
+	}
+	if validatedStatusInfo.VmStatus != irs.Suspended {
+		errMSG := iid.NameId + " vm should be Suspended to change the VMSpec!!"
+		cblogger.Error(errMSG)
+		return irs.VMInfo{}, fmt.Errorf(errMSG)
+	}
+
+	for _, info := range vmInfoMap[mockName] {
+		if (*info).IId.NameId == iid.NameId {
+			info.VMSpecName = validatedSpecInfo.Name
+			return CloneVMInfo(*info), nil
+		}
+	}
+
+	errMSG := iid.NameId + " vm iid does not exist!!"
+	cblogger.Error(errMSG)
+	return irs.VMInfo{}, fmt.Errorf(errMSG)
+}
+
+// The Mock Driver returns a fake boot log made from the VM info.
+func (vmHandler *MockVMHandler) GetConsoleOutput(iid irs.IID) (string, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetConsoleOutput()!")
+
+	vmInfo, err := vmHandler.GetVM(iid)
+	if err != nil {
+		cblogger.Error(err)
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("[    0.000000] Linux version 5.15.0-mock (mock@cb-spider)\n")
+	sb.WriteString(fmt.Sprintf("[    0.000000] Command line: root=%s ro console=ttyS0\n", vmInfo.RootDeviceName))
+	sb.WriteString(fmt.Sprintf("[    2.100000] cloud-init: running 'init' with the VMSpec %s\n", vmInfo.VMSpecName))
+	if vmInfo.UserDataHash != "" {
+		sb.WriteString(fmt.Sprintf("[    3.200000] cloud-init: UserData(%s) processed\n", vmInfo.UserDataHash))
+	}
+	sb.WriteString(fmt.Sprintf("[    4.300000] cloud-init: network up, PrivateIP %s\n", vmInfo.PrivateIP))
+	sb.WriteString(fmt.Sprintf("\n%s login: ", vmInfo.IId.NameId))
+	return sb.String(), nil
+}
+
 func diskAttach(mockName string, iid irs.IID, diskIID irs.IID) (bool, error) {
 	cblogger := cblog.GetLogger("CB-SPIDER")
 	cblogger.Info("Mock Driver: called diskAttach()!")
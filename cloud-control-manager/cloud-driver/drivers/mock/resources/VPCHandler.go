@@ -2,6 +2,8 @@ package resources
 
 import (
 	"fmt"
+	"net"
+	"strconv"
 	"sync"
 
 	cblog "github.com/cloud-barista/cb-log"
@@ -38,6 +40,7 @@ func (vpcHandler *MockVPCHandler) CreateVPC(vpcReqInfo irs.VPCReqInfo) (irs.VPCI
 	vpcInfo := irs.VPCInfo{
 		IId:            vpcReqInfo.IId,
 		IPv4_CIDR:      vpcReqInfo.IPv4_CIDR,
+		IPv6_CIDR:      vpcReqInfo.IPv6_CIDR,
 		SubnetInfoList: vpcReqInfo.SubnetInfoList,
 		TagList:        vpcReqInfo.TagList,
 		KeyValueList:   nil,
@@ -79,6 +82,7 @@ func CloneVPCInfo(srcInfo irs.VPCInfo) irs.VPCInfo {
 	clonedInfo := irs.VPCInfo{
 		IId:            irs.IID{srcInfo.IId.NameId, srcInfo.IId.SystemId},
 		IPv4_CIDR:      srcInfo.IPv4_CIDR,
+		IPv6_CIDR:      srcInfo.IPv6_CIDR,
 		SubnetInfoList: CloneSubnetInfoList(srcInfo.SubnetInfoList),
 		TagList:        srcInfo.TagList, // 필요시 깊은 복사 추가 가능
 		KeyValueList:   srcInfo.KeyValueList,
@@ -100,6 +104,7 @@ func CloneSubnetInfo(srcInfo irs.SubnetInfo) irs.SubnetInfo {
 		IId:          irs.IID{srcInfo.IId.NameId, srcInfo.IId.SystemId},
 		Zone:         srcInfo.Zone,
 		IPv4_CIDR:    srcInfo.IPv4_CIDR,
+		IPv6_CIDR:    srcInfo.IPv6_CIDR,
 		TagList:      srcInfo.TagList, // 필요시 깊은 복사 추가 가능
 		KeyValueList: srcInfo.KeyValueList,
 	}
@@ -219,3 +224,159 @@ func (vpcHandler *MockVPCHandler) ListIID() ([]*irs.IID, error) {
 	}
 	return iidList, nil
 }
+
+// the mock reserves the first four and the last addresses of a Subnet like AWS.
+const mockSubnetReservedIPCount = 5
+
+func (vpcHandler *MockVPCHandler) GetSubnetUsage(vpcIID irs.IID, subnetIID irs.IID) (irs.SubnetUsageInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetSubnetUsage()!")
+
+	mockName := vpcHandler.MockName
+	subnetInfo, err := findMockSubnetInfo(mockName, vpcIID, subnetIID)
+	if err != nil {
+		return irs.SubnetUsageInfo{}, err
+	}
+
+	_, ipNet, err := net.ParseCIDR(subnetInfo.IPv4_CIDR)
+	if err != nil {
+		return irs.SubnetUsageInfo{}, err
+	}
+	ones, bits := ipNet.Mask.Size()
+	total := 1 << (bits - ones)
+	reserved := mockSubnetReservedIPCount
+	if reserved > total {
+		reserved = total
+	}
+
+	// addresses used by VMs and NAT Gateways in the Subnet
+	used := 0
+	vmMapLock.RLock()
+	for _, vmInfo := range vmInfoMap[mockName] {
+		if vmInfo.SubnetIID.SystemId == subnetInfo.IId.SystemId {
+			used++
+		}
+	}
+	vmMapLock.RUnlock()
+	natGatewayMapLock.RLock()
+	for _, natInfo := range natGatewayInfoMap[mockName] {
+		if natInfo.SubnetIID.SystemId == subnetInfo.IId.SystemId {
+			used++
+		}
+	}
+	natGatewayMapLock.RUnlock()
+
+	available := total - reserved - used
+	if available < 0 {
+		available = 0
+	}
+
+	return irs.SubnetUsageInfo{
+		SubnetIID:        subnetInfo.IId,
+		Zone:             subnetInfo.Zone,
+		IPv4_CIDR:        subnetInfo.IPv4_CIDR,
+		TotalIPCount:     total,
+		ReservedIPCount:  reserved,
+		UsedIPCount:      used,
+		AvailableIPCount: available,
+	}, nil
+}
+
+// The auto-assign public IP option and the Route Table are kept in the KeyValueList of the Subnet.
+func (vpcHandler *MockVPCHandler) UpdateSubnet(vpcIID irs.IID, subnetIID irs.IID, updateInfo irs.SubnetUpdateInfo) (irs.SubnetInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called UpdateSubnet()!")
+
+	mockName := vpcHandler.MockName
+	vpcInfo, err := findMockVPCInfo(mockName, vpcIID)
+	if err != nil {
+		return irs.SubnetInfo{}, err
+	}
+	if _, err := findMockSubnetInfo(mockName, vpcIID, subnetIID); err != nil {
+		return irs.SubnetInfo{}, err
+	}
+
+	// (1) associate the Subnet with the Route Table
+	routeTableId := ""
+	if updateInfo.RouteTableIID != nil {
+		routeTableMapLock.Lock()
+		var target *irs.RouteTableInfo
+		mainTable := getMockMainRouteTable(mockName, vpcInfo)
+		for _, info := range routeTableInfoMap[mockName] {
+			if info.VpcIID.SystemId == vpcInfo.IId.SystemId && info.IId.SystemId == updateInfo.RouteTableIID.SystemId {
+				target = info
+				break
+			}
+		}
+		if target == nil {
+			routeTableMapLock.Unlock()
+			return irs.SubnetInfo{}, fmt.Errorf("%s Route Table does not exist in the VPC %s!!", updateInfo.RouteTableIID.NameId, vpcIID.NameId)
+		}
+		for _, info := range routeTableInfoMap[mockName] {
+			if info.VpcIID.SystemId != vpcInfo.IId.SystemId {
+				continue
+			}
+			for idx, iid := range info.SubnetIIDs {
+				if iid.SystemId == subnetIID.SystemId {
+					info.SubnetIIDs = append(info.SubnetIIDs[:idx], info.SubnetIIDs[idx+1:]...)
+					break
+				}
+			}
+		}
+		// the main Route Table is applied implicitly
+		if target != mainTable {
+			target.SubnetIIDs = append(target.SubnetIIDs, subnetIID)
+		}
+		routeTableId = target.IId.SystemId
+		routeTableMapLock.Unlock()
+	}
+
+	// (2) update the Subnet's attributes
+	vpcMapLock.Lock()
+	defer vpcMapLock.Unlock()
+
+	for _, info := range vpcInfoMap[mockName] {
+		if info.IId.SystemId != vpcInfo.IId.SystemId {
+			continue
+		}
+		for idx := range info.SubnetInfoList {
+			subnetInfo := &info.SubnetInfoList[idx]
+			if subnetInfo.IId.SystemId != subnetIID.SystemId {
+				continue
+			}
+			if updateInfo.AutoAssignPublicIP != nil {
+				subnetInfo.KeyValueList = setMockKeyValue(subnetInfo.KeyValueList, "AutoAssignPublicIP", strconv.FormatBool(*updateInfo.AutoAssignPublicIP))
+			}
+			if routeTableId != "" {
+				subnetInfo.KeyValueList = setMockKeyValue(subnetInfo.KeyValueList, "RouteTableId", routeTableId)
+			}
+			return CloneSubnetInfo(*subnetInfo), nil
+		}
+	}
+	return irs.SubnetInfo{}, fmt.Errorf("%s Subnet does not exist!!", subnetIID.NameId)
+}
+
+func findMockSubnetInfo(mockName string, vpcIID irs.IID, subnetIID irs.IID) (irs.SubnetInfo, error) {
+	vpcInfo, err := findMockVPCInfo(mockName, vpcIID)
+	if err != nil {
+		return irs.SubnetInfo{}, err
+	}
+	for _, subnetInfo := range vpcInfo.SubnetInfoList {
+		if subnetInfo.IId.SystemId == subnetIID.SystemId {
+			return subnetInfo, nil
+		}
+	}
+	return irs.SubnetInfo{}, fmt.Errorf("%s Subnet does not exist!!", subnetIID.NameId)
+}
+
+// replace the value of the key or append the key, the returned list is a new one.
+func setMockKeyValue(kvList []irs.KeyValue, key string, value string) []irs.KeyValue {
+	newList := append([]irs.KeyValue(nil), kvList...)
+	for idx := range newList {
+		if newList[idx].Key == key {
+			newList[idx].Value = value
+			return newList
+		}
+	}
+	return append(newList, irs.KeyValue{Key: key, Value: value})
+}
@@ -0,0 +1,210 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Mock Driver.
+//
+// by CB-Spider Team, 2024.10.
+
+package resources
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	cblog "github.com/cloud-barista/cb-log"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+// key: MockName of Requester
+var vpcPeeringInfoMap map[string][]*irs.VPCPeeringInfo
+
+type MockVPCPeeringHandler struct {
+	MockName string
+}
+
+func init() {
+	// cblog is a global variable.
+	vpcPeeringInfoMap = make(map[string][]*irs.VPCPeeringInfo)
+}
+
+var vpcPeeringMapLock = new(sync.RWMutex)
+
+// find a VPC with SystemId in all Mock connections(for Accepter in another connection)
+func findMockVPCInfo(mockName string, vpcIID irs.IID) (irs.VPCInfo, error) {
+	vpcMapLock.RLock()
+	defer vpcMapLock.RUnlock()
+
+	if mockName != "" {
+		for _, info := range vpcInfoMap[mockName] {
+			if info.IId.SystemId == vpcIID.SystemId {
+				return CloneVPCInfo(*info), nil
+			}
+		}
+		return irs.VPCInfo{}, fmt.Errorf("%s VPC does not exist!!", vpcIID.NameId)
+	}
+
+	for _, infoList := range vpcInfoMap {
+		for _, info := range infoList {
+			if info.IId.SystemId == vpcIID.SystemId {
+				return CloneVPCInfo(*info), nil
+			}
+		}
+	}
+	return irs.VPCInfo{}, fmt.Errorf("%s VPC does not exist!!", vpcIID.NameId)
+}
+
+func (peeringHandler *MockVPCPeeringHandler) RequestVPCPeering(peeringReqInfo irs.VPCPeeringReqInfo) (irs.VPCPeeringInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called RequestVPCPeering()!")
+
+	mockName := peeringHandler.MockName
+
+	requesterVPC, err := findMockVPCInfo(mockName, peeringReqInfo.RequesterVPCIID)
+	if err != nil {
+		return irs.VPCPeeringInfo{}, err
+	}
+	accepterVPC, err := findMockVPCInfo("", peeringReqInfo.AccepterVPCIID)
+	if err != nil {
+		return irs.VPCPeeringInfo{}, err
+	}
+
+	peeringInfo := irs.VPCPeeringInfo{
+		IId:                       irs.IID{NameId: peeringReqInfo.IId.NameId, SystemId: peeringReqInfo.IId.NameId},
+		RequesterVPCIID:           requesterVPC.IId,
+		AccepterVPCIID:            accepterVPC.IId,
+		RequesterCIDR:             requesterVPC.IPv4_CIDR,
+		AccepterCIDR:              accepterVPC.IPv4_CIDR,
+		AccepterRegion:            peeringReqInfo.AccepterRegion,
+		RequesterRoutePropagation: peeringReqInfo.RequesterRoutePropagation,
+		AccepterRoutePropagation:  peeringReqInfo.AccepterRoutePropagation,
+		Status:                    irs.PeeringPendingAcceptance,
+		CreatedTime:               time.Now(),
+		TagList:                   peeringReqInfo.TagList,
+	}
+
+	// insert VPCPeeringInfo into global Map
+	vpcPeeringMapLock.Lock()
+	defer vpcPeeringMapLock.Unlock()
+	vpcPeeringInfoMap[mockName] = append(vpcPeeringInfoMap[mockName], &peeringInfo)
+
+	return CloneVPCPeeringInfo(peeringInfo), nil
+}
+
+func CloneVPCPeeringInfoList(srcInfoList []*irs.VPCPeeringInfo) []*irs.VPCPeeringInfo {
+	clonedInfoList := []*irs.VPCPeeringInfo{}
+	for _, srcInfo := range srcInfoList {
+		clonedInfo := CloneVPCPeeringInfo(*srcInfo)
+		clonedInfoList = append(clonedInfoList, &clonedInfo)
+	}
+	return clonedInfoList
+}
+
+func CloneVPCPeeringInfo(srcInfo irs.VPCPeeringInfo) irs.VPCPeeringInfo {
+	clonedInfo := srcInfo
+	clonedInfo.TagList = append([]irs.KeyValue(nil), srcInfo.TagList...)
+	clonedInfo.KeyValueList = append([]irs.KeyValue(nil), srcInfo.KeyValueList...)
+	return clonedInfo
+}
+
+// The Accepter can call with its own connection, so find the Peering in all Mock connections.
+func (peeringHandler *MockVPCPeeringHandler) AcceptVPCPeering(peeringIID irs.IID) (irs.VPCPeeringInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called AcceptVPCPeering()!")
+
+	vpcPeeringMapLock.Lock()
+	defer vpcPeeringMapLock.Unlock()
+
+	for _, infoList := range vpcPeeringInfoMap {
+		for _, info := range infoList {
+			if info.IId.SystemId == peeringIID.SystemId {
+				if info.Status != irs.PeeringPendingAcceptance && info.Status != irs.PeeringActive {
+					return irs.VPCPeeringInfo{}, fmt.Errorf("%s VPC Peering can not be accepted in %s status!!", peeringIID.NameId, info.Status)
+				}
+				info.Status = irs.PeeringActive
+				return CloneVPCPeeringInfo(*info), nil
+			}
+		}
+	}
+
+	return irs.VPCPeeringInfo{}, fmt.Errorf("%s VPC Peering does not exist!!", peeringIID.NameId)
+}
+
+func (peeringHandler *MockVPCPeeringHandler) ListVPCPeering() ([]*irs.VPCPeeringInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListVPCPeering()!")
+
+	mockName := peeringHandler.MockName
+	vpcPeeringMapLock.RLock()
+	defer vpcPeeringMapLock.RUnlock()
+
+	infoList, ok := vpcPeeringInfoMap[mockName]
+	if !ok {
+		return []*irs.VPCPeeringInfo{}, nil
+	}
+
+	return CloneVPCPeeringInfoList(infoList), nil
+}
+
+func (peeringHandler *MockVPCPeeringHandler) GetVPCPeering(peeringIID irs.IID) (irs.VPCPeeringInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetVPCPeering()!")
+
+	vpcPeeringMapLock.RLock()
+	defer vpcPeeringMapLock.RUnlock()
+
+	mockName := peeringHandler.MockName
+	for _, info := range vpcPeeringInfoMap[mockName] {
+		if info.IId.SystemId == peeringIID.SystemId {
+			return CloneVPCPeeringInfo(*info), nil
+		}
+	}
+
+	return irs.VPCPeeringInfo{}, fmt.Errorf("%s VPC Peering does not exist!!", peeringIID.NameId)
+}
+
+func (peeringHandler *MockVPCPeeringHandler) DeleteVPCPeering(peeringIID irs.IID) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called DeleteVPCPeering()!")
+
+	vpcPeeringMapLock.Lock()
+	defer vpcPeeringMapLock.Unlock()
+
+	mockName := peeringHandler.MockName
+	infoList, ok := vpcPeeringInfoMap[mockName]
+	if !ok {
+		return false, fmt.Errorf("%s VPC Peering does not exist!!", peeringIID.NameId)
+	}
+
+	for idx, info := range infoList {
+		if info.IId.SystemId == peeringIID.SystemId {
+			vpcPeeringInfoMap[mockName] = append(infoList[:idx], infoList[idx+1:]...)
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("%s VPC Peering does not exist!!", peeringIID.NameId)
+}
+
+func (peeringHandler *MockVPCPeeringHandler) ListIID() ([]*irs.IID, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListIID()!")
+
+	mockName := peeringHandler.MockName
+	vpcPeeringMapLock.RLock()
+	defer vpcPeeringMapLock.RUnlock()
+
+	infoList, ok := vpcPeeringInfoMap[mockName]
+	if !ok {
+		return []*irs.IID{}, nil
+	}
+
+	iidList := make([]*irs.IID, len(infoList))
+	for i, info := range infoList {
+		iid := info.IId
+		iidList[i] = &iid
+	}
+	return iidList, nil
+}
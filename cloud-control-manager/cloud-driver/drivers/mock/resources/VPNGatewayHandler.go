@@ -0,0 +1,323 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Mock Driver.
+//
+// by CB-Spider Team, 2024.10.
+
+package resources
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	cblog "github.com/cloud-barista/cb-log"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+)
+
+// mockVPNTunnel keeps the write-only pre-shared key beside the Tunnel info.
+type mockVPNTunnel struct {
+	info            irs.VPNTunnelInfo
+	gatewayPublicIP string
+	preSharedKey    string
+}
+
+// key: MockName
+var vpnGatewayInfoMap map[string][]*irs.VPNGatewayInfo
+var vpnTunnelMap map[string][]*mockVPNTunnel
+
+// sequence number for allocating mock public IPs
+var vpnPublicIPSeq int
+
+type MockVPNGatewayHandler struct {
+	MockName string
+}
+
+func init() {
+	// cblog is a global variable.
+	vpnGatewayInfoMap = make(map[string][]*irs.VPNGatewayInfo)
+	vpnTunnelMap = make(map[string][]*mockVPNTunnel)
+}
+
+// vpnGatewayMapLock guards both vpnGatewayInfoMap and vpnTunnelMap.
+var vpnGatewayMapLock = new(sync.RWMutex)
+
+func (vpnHandler *MockVPNGatewayHandler) CreateVPNGateway(vpnReqInfo irs.VPNGatewayReqInfo) (irs.VPNGatewayInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called CreateVPNGateway()!")
+
+	mockName := vpnHandler.MockName
+
+	vpcInfo, err := findMockVPCInfo(mockName, vpnReqInfo.VpcIID)
+	if err != nil {
+		return irs.VPNGatewayInfo{}, err
+	}
+
+	vpnGatewayMapLock.Lock()
+	defer vpnGatewayMapLock.Unlock()
+
+	for _, info := range vpnGatewayInfoMap[mockName] {
+		if info.IId.NameId == vpnReqInfo.IId.NameId {
+			return irs.VPNGatewayInfo{}, fmt.Errorf("%s VPN Gateway already exists!!", vpnReqInfo.IId.NameId)
+		}
+		if info.VpcIID.SystemId == vpcInfo.IId.SystemId {
+			return irs.VPNGatewayInfo{}, fmt.Errorf("%s VPC already has a VPN Gateway(%s)!!", vpcInfo.IId.NameId, info.IId.NameId)
+		}
+	}
+
+	// allocate a public IP
+	vpnPublicIPSeq++
+	vpnInfo := irs.VPNGatewayInfo{
+		IId:          irs.IID{NameId: vpnReqInfo.IId.NameId, SystemId: vpnReqInfo.IId.NameId},
+		VpcIID:       vpcInfo.IId,
+		PublicIP:     fmt.Sprintf("7.8.%d.%d", vpnPublicIPSeq/250, vpnPublicIPSeq%250+1),
+		Status:       irs.VPNGatewayAvailable,
+		CreatedTime:  time.Now(),
+		TagList:      vpnReqInfo.TagList,
+		KeyValueList: []irs.KeyValue{{Key: "VPCCIDR", Value: vpcInfo.IPv4_CIDR}},
+	}
+
+	// insert VPNGatewayInfo into global Map
+	vpnGatewayInfoMap[mockName] = append(vpnGatewayInfoMap[mockName], &vpnInfo)
+
+	return CloneVPNGatewayInfo(vpnInfo), nil
+}
+
+func CloneVPNGatewayInfoList(srcInfoList []*irs.VPNGatewayInfo) []*irs.VPNGatewayInfo {
+	clonedInfoList := []*irs.VPNGatewayInfo{}
+	for _, srcInfo := range srcInfoList {
+		clonedInfo := CloneVPNGatewayInfo(*srcInfo)
+		clonedInfoList = append(clonedInfoList, &clonedInfo)
+	}
+	return clonedInfoList
+}
+
+func CloneVPNGatewayInfo(srcInfo irs.VPNGatewayInfo) irs.VPNGatewayInfo {
+	clonedInfo := srcInfo
+	clonedInfo.TagList = append([]irs.KeyValue(nil), srcInfo.TagList...)
+	clonedInfo.KeyValueList = append([]irs.KeyValue(nil), srcInfo.KeyValueList...)
+	return clonedInfo
+}
+
+func CloneVPNTunnelInfo(srcInfo irs.VPNTunnelInfo) irs.VPNTunnelInfo {
+	clonedInfo := srcInfo
+	clonedInfo.PeerCIDRList = append([]string(nil), srcInfo.PeerCIDRList...)
+	clonedInfo.TagList = append([]irs.KeyValue(nil), srcInfo.TagList...)
+	clonedInfo.KeyValueList = append([]irs.KeyValue(nil), srcInfo.KeyValueList...)
+	return clonedInfo
+}
+
+func (vpnHandler *MockVPNGatewayHandler) ListVPNGateway() ([]*irs.VPNGatewayInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListVPNGateway()!")
+
+	mockName := vpnHandler.MockName
+	vpnGatewayMapLock.RLock()
+	defer vpnGatewayMapLock.RUnlock()
+
+	infoList, ok := vpnGatewayInfoMap[mockName]
+	if !ok {
+		return []*irs.VPNGatewayInfo{}, nil
+	}
+
+	return CloneVPNGatewayInfoList(infoList), nil
+}
+
+func (vpnHandler *MockVPNGatewayHandler) GetVPNGateway(vpnIID irs.IID) (irs.VPNGatewayInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetVPNGateway()!")
+
+	vpnGatewayMapLock.RLock()
+	defer vpnGatewayMapLock.RUnlock()
+
+	info := findMockVPNGateway(vpnHandler.MockName, vpnIID)
+	if info == nil {
+		return irs.VPNGatewayInfo{}, fmt.Errorf("%s VPN Gateway does not exist!!", vpnIID.NameId)
+	}
+	return CloneVPNGatewayInfo(*info), nil
+}
+
+func (vpnHandler *MockVPNGatewayHandler) DeleteVPNGateway(vpnIID irs.IID) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called DeleteVPNGateway()!")
+
+	vpnGatewayMapLock.Lock()
+	defer vpnGatewayMapLock.Unlock()
+
+	mockName := vpnHandler.MockName
+	for _, tunnel := range vpnTunnelMap[mockName] {
+		if tunnel.info.VPNGatewayIID.SystemId == vpnIID.SystemId {
+			return false, fmt.Errorf("%s VPN Gateway is in use by the Tunnel(%s)!!", vpnIID.NameId, tunnel.info.IId.NameId)
+		}
+	}
+
+	infoList := vpnGatewayInfoMap[mockName]
+	for idx, info := range infoList {
+		if info.IId.SystemId == vpnIID.SystemId {
+			vpnGatewayInfoMap[mockName] = append(infoList[:idx], infoList[idx+1:]...)
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("%s VPN Gateway does not exist!!", vpnIID.NameId)
+}
+
+func (vpnHandler *MockVPNGatewayHandler) CreateVPNTunnel(tunnelReqInfo irs.VPNTunnelReqInfo) (irs.VPNTunnelInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called CreateVPNTunnel()!")
+
+	if tunnelReqInfo.PeerPublicIP == "" {
+		return irs.VPNTunnelInfo{}, fmt.Errorf("PeerPublicIP is empty!!")
+	}
+	if len(tunnelReqInfo.PeerCIDRList) == 0 {
+		return irs.VPNTunnelInfo{}, fmt.Errorf("PeerCIDRList is empty!!")
+	}
+	if tunnelReqInfo.PreSharedKey == "" {
+		return irs.VPNTunnelInfo{}, fmt.Errorf("PreSharedKey is empty!!")
+	}
+
+	mockName := vpnHandler.MockName
+
+	vpnGatewayMapLock.Lock()
+	defer vpnGatewayMapLock.Unlock()
+
+	gwInfo := findMockVPNGateway(mockName, tunnelReqInfo.VPNGatewayIID)
+	if gwInfo == nil {
+		return irs.VPNTunnelInfo{}, fmt.Errorf("%s VPN Gateway does not exist!!", tunnelReqInfo.VPNGatewayIID.NameId)
+	}
+
+	for _, tunnel := range vpnTunnelMap[mockName] {
+		if tunnel.info.IId.NameId == tunnelReqInfo.IId.NameId {
+			return irs.VPNTunnelInfo{}, fmt.Errorf("%s VPN Tunnel already exists!!", tunnelReqInfo.IId.NameId)
+		}
+	}
+
+	tunnel := mockVPNTunnel{
+		info: irs.VPNTunnelInfo{
+			IId:           irs.IID{NameId: tunnelReqInfo.IId.NameId, SystemId: tunnelReqInfo.IId.NameId},
+			VPNGatewayIID: gwInfo.IId,
+			PeerPublicIP:  tunnelReqInfo.PeerPublicIP,
+			PeerCIDRList:  append([]string(nil), tunnelReqInfo.PeerCIDRList...),
+			CreatedTime:   time.Now(),
+			TagList:       tunnelReqInfo.TagList,
+		},
+		gatewayPublicIP: gwInfo.PublicIP,
+		preSharedKey:    tunnelReqInfo.PreSharedKey,
+	}
+
+	// insert the Tunnel into global Map
+	vpnTunnelMap[mockName] = append(vpnTunnelMap[mockName], &tunnel)
+
+	return tunnel.toInfo(), nil
+}
+
+func (vpnHandler *MockVPNGatewayHandler) ListVPNTunnel(vpnIID irs.IID) ([]*irs.VPNTunnelInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListVPNTunnel()!")
+
+	vpnGatewayMapLock.RLock()
+	defer vpnGatewayMapLock.RUnlock()
+
+	mockName := vpnHandler.MockName
+	if findMockVPNGateway(mockName, vpnIID) == nil {
+		return nil, fmt.Errorf("%s VPN Gateway does not exist!!", vpnIID.NameId)
+	}
+
+	infoList := []*irs.VPNTunnelInfo{}
+	for _, tunnel := range vpnTunnelMap[mockName] {
+		if tunnel.info.VPNGatewayIID.SystemId == vpnIID.SystemId {
+			info := tunnel.toInfo()
+			infoList = append(infoList, &info)
+		}
+	}
+	return infoList, nil
+}
+
+func (vpnHandler *MockVPNGatewayHandler) GetVPNTunnel(tunnelIID irs.IID) (irs.VPNTunnelInfo, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called GetVPNTunnel()!")
+
+	vpnGatewayMapLock.RLock()
+	defer vpnGatewayMapLock.RUnlock()
+
+	for _, tunnel := range vpnTunnelMap[vpnHandler.MockName] {
+		if tunnel.info.IId.SystemId == tunnelIID.SystemId {
+			return tunnel.toInfo(), nil
+		}
+	}
+	return irs.VPNTunnelInfo{}, fmt.Errorf("%s VPN Tunnel does not exist!!", tunnelIID.NameId)
+}
+
+func (vpnHandler *MockVPNGatewayHandler) DeleteVPNTunnel(tunnelIID irs.IID) (bool, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called DeleteVPNTunnel()!")
+
+	vpnGatewayMapLock.Lock()
+	defer vpnGatewayMapLock.Unlock()
+
+	mockName := vpnHandler.MockName
+	tunnelList := vpnTunnelMap[mockName]
+	for idx, tunnel := range tunnelList {
+		if tunnel.info.IId.SystemId == tunnelIID.SystemId {
+			vpnTunnelMap[mockName] = append(tunnelList[:idx], tunnelList[idx+1:]...)
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("%s VPN Tunnel does not exist!!", tunnelIID.NameId)
+}
+
+func (vpnHandler *MockVPNGatewayHandler) ListIID() ([]*irs.IID, error) {
+	cblogger := cblog.GetLogger("CB-SPIDER")
+	cblogger.Info("Mock Driver: called ListIID()!")
+
+	mockName := vpnHandler.MockName
+	vpnGatewayMapLock.RLock()
+	defer vpnGatewayMapLock.RUnlock()
+
+	infoList, ok := vpnGatewayInfoMap[mockName]
+	if !ok {
+		return []*irs.IID{}, nil
+	}
+
+	iidList := make([]*irs.IID, len(infoList))
+	for i, info := range infoList {
+		iid := info.IId
+		iidList[i] = &iid
+	}
+	return iidList, nil
+}
+
+// caller must hold vpnGatewayMapLock.
+func findMockVPNGateway(mockName string, vpnIID irs.IID) *irs.VPNGatewayInfo {
+	for _, info := range vpnGatewayInfoMap[mockName] {
+		if info.IId.SystemId == vpnIID.SystemId {
+			return info
+		}
+	}
+	return nil
+}
+
+// toInfo returns a copy of the Tunnel info with the Status evaluated against all mock connections:
+// Up when the peer gateway has a Tunnel back to this gateway with the same pre-shared key,
+// Down when the key does not match, and Pending while the peer Tunnel does not exist.
+// caller must hold vpnGatewayMapLock.
+func (tunnel *mockVPNTunnel) toInfo() irs.VPNTunnelInfo {
+	info := CloneVPNTunnelInfo(tunnel.info)
+	info.Status = irs.VPNTunnelPending
+	for _, tunnelList := range vpnTunnelMap {
+		for _, peer := range tunnelList {
+			if peer.gatewayPublicIP != tunnel.info.PeerPublicIP || peer.info.PeerPublicIP != tunnel.gatewayPublicIP {
+				continue
+			}
+			if peer.preSharedKey == tunnel.preSharedKey {
+				info.Status = irs.VPNTunnelUp
+				return info
+			}
+			info.Status = irs.VPNTunnelDown
+		}
+	}
+	return info
+}
@@ -0,0 +1,131 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package mocktest
+
+import (
+	mockdrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock"
+	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"testing"
+
+	cblog "github.com/cloud-barista/cb-log"
+)
+
+var albVPCHandler irs.VPCHandler
+var albHandler irs.ALBHandler
+
+func init() {
+	// make the log level lower to print clearly
+	cblog.SetLevel("error")
+
+	cloudConn, _ := (&mockdrv.MockDriver{}).ConnectCloud(idrv.ConnectionInfo{
+		CredentialInfo: idrv.CredentialInfo{MockName: "MockDriver-ALB-01"},
+	})
+	albVPCHandler, _ = cloudConn.CreateVPCHandler()
+	albHandler, _ = cloudConn.CreateALBHandler()
+}
+
+func TestALBCreateAndTargetVMs(t *testing.T) {
+	vpcInfo, err := albVPCHandler.CreateVPC(irs.VPCReqInfo{
+		IId:            irs.IID{NameId: "mock-alb-vpc"},
+		IPv4_CIDR:      "10.0.0.0/16",
+		SubnetInfoList: []irs.SubnetInfo{{IId: irs.IID{NameId: "mock-alb-subnet"}, IPv4_CIDR: "10.0.1.0/24"}},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	reqInfo := irs.ALBInfo{
+		IId:    irs.IID{NameId: "mock-alb-01"},
+		VpcIID: vpcInfo.IId,
+		Type:   "PUBLIC",
+		Listeners: []irs.ALBListenerInfo{
+			{Protocol: "HTTPS", Port: "443", CertificateRef: "mock-cert-01", DefaultTargetGroup: "web-tg",
+				Rules: []irs.ALBRuleInfo{{Priority: 10, PathPattern: "/api/*", TargetGroup: "api-tg"}}},
+		},
+		TargetGroups: []irs.TargetGroupInfo{
+			{Name: "web-tg", Protocol: "HTTP", Port: "80", VMs: &[]irs.IID{{NameId: "vm-01", SystemId: "vm-01"}},
+				StickySession: irs.StickySessionInfo{Enabled: true, Type: "LB_COOKIE", Duration: 3600}},
+			{Name: "api-tg", Protocol: "HTTP", Port: "8080", VMs: &[]irs.IID{}},
+		},
+	}
+
+	// HTTPS Listener without a Certificate
+	noCertReq := reqInfo
+	noCertReq.Listeners = []irs.ALBListenerInfo{{Protocol: "HTTPS", Port: "443", DefaultTargetGroup: "web-tg"}}
+	if _, err := albHandler.CreateALB(noCertReq); err == nil {
+		t.Error("Creating an HTTPS Listener without a Certificate should be failed!")
+	}
+
+	// Rule to an unknown Target Group
+	badRuleReq := reqInfo
+	badRuleReq.Listeners = []irs.ALBListenerInfo{{Protocol: "HTTP", Port: "80", DefaultTargetGroup: "web-tg",
+		Rules: []irs.ALBRuleInfo{{Priority: 1, Host: "api.example.com", TargetGroup: "unknown-tg"}}}}
+	if _, err := albHandler.CreateALB(badRuleReq); err == nil {
+		t.Error("Creating a Rule with an unknown Target Group should be failed!")
+	}
+
+	// create
+	albInfo, err := albHandler.CreateALB(reqInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if albInfo.DNSName == "" || albInfo.Listeners[0].CspID == "" {
+		t.Error("The ALB should have a DNSName and a Listener CspID!")
+	}
+	if !albInfo.TargetGroups[0].StickySession.Enabled {
+		t.Error("The Sticky Session of web-tg should be enabled!")
+	}
+
+	// add and remove Target VMs
+	vmIIDs := []irs.IID{{NameId: "vm-02", SystemId: "vm-02"}, {NameId: "vm-03", SystemId: "vm-03"}}
+	tgInfo, err := albHandler.AddTargetVMs(albInfo.IId, "api-tg", &vmIIDs)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(*tgInfo.VMs) != 2 {
+		t.Errorf("The number of api-tg VMs: expected 2, got %d", len(*tgInfo.VMs))
+	}
+	if _, err := albHandler.AddTargetVMs(albInfo.IId, "api-tg", &[]irs.IID{{NameId: "vm-02", SystemId: "vm-02"}}); err == nil {
+		t.Error("Adding a duplicated VM should be failed!")
+	}
+
+	healthInfo, err := albHandler.GetTargetGroupHealthInfo(albInfo.IId, "api-tg")
+	if err != nil {
+		t.Error(err.Error())
+	} else if len(*healthInfo.AllVMs) != 2 {
+		t.Errorf("The number of api-tg health VMs: expected 2, got %d", len(*healthInfo.AllVMs))
+	}
+
+	result, err := albHandler.RemoveTargetVMs(albInfo.IId, "api-tg", &[]irs.IID{{NameId: "vm-02", SystemId: "vm-02"}})
+	if err != nil || !result {
+		t.Errorf("failed to remove the VM: %v", err)
+	}
+	getInfo, err := albHandler.GetALB(albInfo.IId)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(*getInfo.TargetGroups[1].VMs) != 1 {
+		t.Errorf("The number of api-tg VMs: expected 1, got %d", len(*getInfo.TargetGroups[1].VMs))
+	}
+
+	// delete
+	result, err = albHandler.DeleteALB(albInfo.IId)
+	if err != nil || !result {
+		t.Errorf("failed to delete the ALB: %v", err)
+	}
+	albList, err := albHandler.ListALB()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(albList) != 0 {
+		t.Errorf("The number of ALBs: expected 0, got %d", len(albList))
+	}
+}
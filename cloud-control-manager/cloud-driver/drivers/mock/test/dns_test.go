@@ -0,0 +1,97 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package mocktest
+
+import (
+	mockdrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock"
+	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"testing"
+
+	cblog "github.com/cloud-barista/cb-log"
+)
+
+var dnsHandler irs.DNSHandler
+
+func init() {
+	// make the log level lower to print clearly
+	cblog.SetLevel("error")
+
+	cloudConn, _ := (&mockdrv.MockDriver{}).ConnectCloud(idrv.ConnectionInfo{
+		CredentialInfo: idrv.CredentialInfo{MockName: "MockDriver-DNS-01"},
+	})
+	dnsHandler, _ = cloudConn.CreateDNSHandler()
+}
+
+func TestDNSZoneAndRecord(t *testing.T) {
+	zoneInfo, err := dnsHandler.CreateZone(irs.DNSZoneReqInfo{IId: irs.IID{NameId: "mock-zone-01"}, DomainName: "example.com"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(zoneInfo.NameServers) == 0 {
+		t.Error("The Zone should have Name Servers!")
+	}
+	if _, err := dnsHandler.CreateZone(irs.DNSZoneReqInfo{IId: irs.IID{NameId: "mock-zone-02"}, DomainName: "example.com"}); err == nil {
+		t.Error("Creating a Zone with a duplicated domain should be failed!")
+	}
+
+	// upsert: create and replace
+	record := irs.DNSRecordInfo{Name: "www.example.com", Type: irs.DNSRecordA, TTL: 300, Values: []string{"4.3.2.1"}}
+	if _, err := dnsHandler.UpsertRecord(zoneInfo.IId, record); err != nil {
+		t.Fatal(err.Error())
+	}
+	record.Values = []string{"4.3.2.2", "4.3.2.3"}
+	if _, err := dnsHandler.UpsertRecord(zoneInfo.IId, record); err != nil {
+		t.Fatal(err.Error())
+	}
+	getInfo, err := dnsHandler.GetRecord(zoneInfo.IId, "www.example.com", irs.DNSRecordA)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(getInfo.Values) != 2 {
+		t.Errorf("The number of values: expected 2, got %d", len(getInfo.Values))
+	}
+
+	// CNAME can not coexist with the A record
+	cname := irs.DNSRecordInfo{Name: "www.example.com", Type: irs.DNSRecordCNAME, TTL: 300, Values: []string{"lb.example.com"}}
+	if _, err := dnsHandler.UpsertRecord(zoneInfo.IId, cname); err == nil {
+		t.Error("Upserting a CNAME Record with the name of an A Record should be failed!")
+	}
+
+	txt := irs.DNSRecordInfo{Name: "example.com", Type: irs.DNSRecordTXT, TTL: 60, Values: []string{"v=spf1 -all"}}
+	if _, err := dnsHandler.UpsertRecord(zoneInfo.IId, txt); err != nil {
+		t.Error(err.Error())
+	}
+
+	recordList, err := dnsHandler.ListRecord(zoneInfo.IId)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(recordList) != 2 {
+		t.Errorf("The number of Records: expected 2, got %d", len(recordList))
+	}
+	zoneInfo, _ = dnsHandler.GetZone(zoneInfo.IId)
+	if zoneInfo.RecordCount != 2 {
+		t.Errorf("RecordCount: expected 2, got %d", zoneInfo.RecordCount)
+	}
+
+	// delete
+	result, err := dnsHandler.DeleteRecord(zoneInfo.IId, "www.example.com", irs.DNSRecordA)
+	if err != nil || !result {
+		t.Errorf("failed to delete the Record: %v", err)
+	}
+	result, err = dnsHandler.DeleteZone(zoneInfo.IId)
+	if err != nil || !result {
+		t.Errorf("failed to delete the Zone: %v", err)
+	}
+	if _, err := dnsHandler.ListRecord(zoneInfo.IId); err == nil {
+		t.Error("Listing Records of a deleted Zone should be failed!")
+	}
+}
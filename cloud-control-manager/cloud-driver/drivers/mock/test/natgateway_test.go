@@ -0,0 +1,114 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package mocktest
+
+import (
+	mockdrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock"
+	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"testing"
+
+	cblog "github.com/cloud-barista/cb-log"
+)
+
+var natVPCHandler irs.VPCHandler
+var natGatewayHandler irs.NATGatewayHandler
+var natRouteTableHandler irs.RouteTableHandler
+
+func init() {
+	// make the log level lower to print clearly
+	cblog.SetLevel("error")
+
+	cloudConn, _ := (&mockdrv.MockDriver{}).ConnectCloud(idrv.ConnectionInfo{
+		CredentialInfo: idrv.CredentialInfo{MockName: "MockDriver-NATGateway-01"},
+	})
+	natVPCHandler, _ = cloudConn.CreateVPCHandler()
+	natGatewayHandler, _ = cloudConn.CreateNATGatewayHandler()
+	natRouteTableHandler, _ = cloudConn.CreateRouteTableHandler()
+}
+
+func TestNATGatewayCreateListDelete(t *testing.T) {
+	vpcInfo, err := natVPCHandler.CreateVPC(irs.VPCReqInfo{
+		IId:       irs.IID{NameId: "mock-nat-vpc"},
+		IPv4_CIDR: "10.0.0.0/16",
+		SubnetInfoList: []irs.SubnetInfo{
+			{IId: irs.IID{NameId: "mock-public-subnet"}, IPv4_CIDR: "10.0.1.0/24"},
+			{IId: irs.IID{NameId: "mock-private-subnet"}, IPv4_CIDR: "10.0.2.0/24"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	publicSubnetIID := vpcInfo.SubnetInfoList[0].IId
+	privateSubnetIID := vpcInfo.SubnetInfoList[1].IId
+
+	// create
+	natInfo, err := natGatewayHandler.CreateNATGateway(irs.NATGatewayReqInfo{
+		IId:       irs.IID{NameId: "mock-nat-01"},
+		VpcIID:    vpcInfo.IId,
+		SubnetIID: publicSubnetIID,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if natInfo.PublicIP == "" || natInfo.Status != irs.NATGatewayAvailable || natInfo.SubnetIID.SystemId != publicSubnetIID.SystemId {
+		t.Errorf("unexpected NAT Gateway info: %#v", natInfo)
+	}
+
+	// route the private subnet to the NAT Gateway
+	route := irs.RouteInfo{DestinationCIDR: "0.0.0.0/0", TargetType: irs.RouteTargetNATGateway, TargetIID: natInfo.IId}
+	if _, err := natRouteTableHandler.AddRoute(vpcInfo.IId, privateSubnetIID, route); err != nil {
+		t.Error(err.Error())
+	}
+
+	// get & list
+	if _, err := natGatewayHandler.GetNATGateway(natInfo.IId); err != nil {
+		t.Error(err.Error())
+	}
+	infoList, err := natGatewayHandler.ListNATGateway()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(infoList) != 1 {
+		t.Errorf("The number of NAT Gateways: expected 1, got %d", len(infoList))
+	}
+
+	// delete
+	result, err := natGatewayHandler.DeleteNATGateway(natInfo.IId)
+	if err != nil || !result {
+		t.Errorf("failed to delete the NAT Gateway: %v", err)
+	}
+	infoList, _ = natGatewayHandler.ListNATGateway()
+	if len(infoList) != 0 {
+		t.Errorf("The number of NAT Gateways: expected 0, got %d", len(infoList))
+	}
+}
+
+func TestNATGatewayCreateWithUnknownSubnet(t *testing.T) {
+	vpcInfo, err := natVPCHandler.CreateVPC(irs.VPCReqInfo{
+		IId:       irs.IID{NameId: "mock-nat-vpc-02"},
+		IPv4_CIDR: "10.1.0.0/16",
+		SubnetInfoList: []irs.SubnetInfo{
+			{IId: irs.IID{NameId: "mock-nat-subnet-02"}, IPv4_CIDR: "10.1.1.0/24"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	_, err = natGatewayHandler.CreateNATGateway(irs.NATGatewayReqInfo{
+		IId:       irs.IID{NameId: "mock-nat-02"},
+		VpcIID:    vpcInfo.IId,
+		SubnetIID: irs.IID{NameId: "no-subnet", SystemId: "no-subnet"},
+	})
+	if err == nil {
+		t.Error("Creating a NAT Gateway with an unknown Subnet should be failed!")
+	}
+}
@@ -60,6 +60,7 @@ func TestGetComputeInstancePriceInfo(t *testing.T) {
 		{"mercury", []irs.KeyValue{{Key: "noField", Value: "mock.enhnace1.mercury"}}, 0},
 		{"mercury", []irs.KeyValue{{Key: "vcpu", Value: "8"}}, 1},
 		{"mercury", []irs.KeyValue{{Key: "pricingPolicy", Value: "OnDemand"}}, 3},
+		{"mercury", []irs.KeyValue{{Key: "pricingPolicy", Value: "Spot"}}, 3},
 		{"mercury", []irs.KeyValue{{Key: "LeaseContractLength", Value: "1 Year"}}, 3},
 	}
 
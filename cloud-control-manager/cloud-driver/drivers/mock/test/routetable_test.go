@@ -0,0 +1,98 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package mocktest
+
+import (
+	mockdrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock"
+	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"testing"
+
+	cblog "github.com/cloud-barista/cb-log"
+)
+
+var routeVPCHandler irs.VPCHandler
+var routeTableHandler irs.RouteTableHandler
+
+func init() {
+	// make the log level lower to print clearly
+	cblog.SetLevel("error")
+
+	cloudConn, _ := (&mockdrv.MockDriver{}).ConnectCloud(idrv.ConnectionInfo{
+		CredentialInfo: idrv.CredentialInfo{MockName: "MockDriver-RouteTable-01"},
+	})
+	routeVPCHandler, _ = cloudConn.CreateVPCHandler()
+	routeTableHandler, _ = cloudConn.CreateRouteTableHandler()
+}
+
+func TestRouteTableAddRemove(t *testing.T) {
+	vpcInfo, err := routeVPCHandler.CreateVPC(irs.VPCReqInfo{
+		IId:       irs.IID{NameId: "mock-route-vpc"},
+		IPv4_CIDR: "10.0.0.0/16",
+		SubnetInfoList: []irs.SubnetInfo{
+			{IId: irs.IID{NameId: "mock-route-subnet-01"}, IPv4_CIDR: "10.0.1.0/24"},
+			{IId: irs.IID{NameId: "mock-route-subnet-02"}, IPv4_CIDR: "10.0.2.0/24"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	subnetIID := vpcInfo.SubnetInfoList[0].IId
+
+	// main Route Table with the local route
+	mainTable, err := routeTableHandler.GetRouteTable(vpcInfo.IId, irs.IID{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !mainTable.IsMain || len(mainTable.Routes) != 1 || mainTable.Routes[0].TargetType != irs.RouteTargetLocal {
+		t.Errorf("unexpected main Route Table: %#v", mainTable)
+	}
+
+	// add a default route to subnet-01
+	route := irs.RouteInfo{DestinationCIDR: "0.0.0.0/0", TargetType: irs.RouteTargetInternetGateway}
+	subnetTable, err := routeTableHandler.AddRoute(vpcInfo.IId, subnetIID, route)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if subnetTable.IsMain || len(subnetTable.Routes) != 2 {
+		t.Errorf("unexpected subnet Route Table: %#v", subnetTable)
+	}
+	if _, err := routeTableHandler.AddRoute(vpcInfo.IId, subnetIID, route); err == nil {
+		t.Error("Adding a duplicated route should be failed!")
+	}
+
+	// the main Route Table is not changed
+	mainTable, _ = routeTableHandler.GetRouteTable(vpcInfo.IId, irs.IID{})
+	if len(mainTable.Routes) != 1 {
+		t.Errorf("The number of main routes: expected 1, got %d", len(mainTable.Routes))
+	}
+	tableList, err := routeTableHandler.ListRouteTable(vpcInfo.IId)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(tableList) != 2 {
+		t.Errorf("The number of Route Tables: expected 2, got %d", len(tableList))
+	}
+
+	// remove
+	result, err := routeTableHandler.RemoveRoute(vpcInfo.IId, subnetIID, route)
+	if err != nil || !result {
+		t.Errorf("failed to remove the route: %v", err)
+	}
+	subnetTable, _ = routeTableHandler.GetRouteTable(vpcInfo.IId, subnetIID)
+	if len(subnetTable.Routes) != 1 {
+		t.Errorf("The number of subnet routes: expected 1, got %d", len(subnetTable.Routes))
+	}
+
+	// the local route can not be removed
+	if _, err := routeTableHandler.RemoveRoute(vpcInfo.IId, subnetIID, subnetTable.Routes[0]); err == nil {
+		t.Error("Removing the local route should be failed!")
+	}
+}
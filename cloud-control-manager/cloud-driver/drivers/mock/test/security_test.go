@@ -158,6 +158,15 @@ func TestSecurityAddRules(t *testing.T) {
 	// print 4 rules
 	// fmt.Printf("\n\t%#v\n", *info2.SecurityRules)
 
+	//---- Add an invalid port range => error
+	badRules := &[]irs.SecurityRuleInfo{
+		{Direction: "inbound", IPProtocol: "tcp", FromPort: "80", ToPort: "70"},
+	}
+	_, err = securityHandler.AddRules(infoList[0].IId, badRules)
+	if err == nil {
+		t.Error("The invalid port range should be failed!")
+	}
+
 	//---- Remove 3 Ruls => 1 Rule
 	SecurityRules2 := &[]irs.SecurityRuleInfo{
 		{Direction: "inbound", IPProtocol: "tcp", FromPort: "22", ToPort: "22"},
@@ -198,3 +207,82 @@ func TestSecurityAddRules(t *testing.T) {
 	// pritn 0 Rule
 	// fmt.Printf("\n\t%#v\n", *info4.SecurityRules)
 }
+
+func TestSecurityReferenceRules(t *testing.T) {
+	infoList, err := securityHandler.ListSecurity()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(infoList) < 2 {
+		t.Fatalf("The number of Infos is not at least %d. It is %d.", 2, len(infoList))
+	}
+
+	//---- Add a Rule referencing another SG and a Rule with the same ports and a CIDR => 2 Rules
+	SecurityRules := &[]irs.SecurityRuleInfo{
+		{Direction: "inbound", IPProtocol: "tcp", FromPort: "5432", ToPort: "5432", SecurityGroupIID: &infoList[1].IId},
+		{Direction: "inbound", IPProtocol: "tcp", FromPort: "5432", ToPort: "5432", CIDR: "10.0.0.0/16"},
+	}
+	info, err := securityHandler.AddRules(infoList[0].IId, SecurityRules)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(*info.SecurityRules) != 2 {
+		t.Errorf("The number of Infos is not %d. It is %d.", 2, len(*info.SecurityRules))
+	}
+	refRule := (*info.SecurityRules)[0]
+	if refRule.SecurityGroupIID == nil || refRule.SecurityGroupIID.SystemId != infoList[1].IId.SystemId {
+		t.Errorf("The referenced SG of the Rule is not %s: %v", infoList[1].IId.SystemId, refRule.SecurityGroupIID)
+	}
+
+	//---- Remove the Rule referencing another SG => 1 Rule
+	SecurityRules2 := &[]irs.SecurityRuleInfo{
+		{Direction: "inbound", IPProtocol: "tcp", FromPort: "5432", ToPort: "5432", SecurityGroupIID: &infoList[1].IId},
+	}
+	result, err := securityHandler.RemoveRules(infoList[0].IId, SecurityRules2)
+	if result != true {
+		t.Error(err.Error())
+	}
+	info2, err := securityHandler.GetSecurity(infoList[0].IId)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(*info2.SecurityRules) != 1 || (*info2.SecurityRules)[0].SecurityGroupIID != nil {
+		t.Errorf("Only the Rule with the CIDR should remain: %v", *info2.SecurityRules)
+	}
+}
+
+func TestSecurityRuleActionPriority(t *testing.T) {
+	infoList, err := securityHandler.ListSecurity()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(infoList) < 3 {
+		t.Fatalf("The number of Infos is not at least %d. It is %d.", 3, len(infoList))
+	}
+	sgIID := infoList[2].IId
+
+	//---- Add a deny Rule with a Priority and a Description
+	denyRule := irs.SecurityRuleInfo{Direction: "inbound", IPProtocol: "tcp", FromPort: "3389", ToPort: "3389", CIDR: "0.0.0.0/0",
+		Description: "deny rdp", Priority: "100", Action: irs.RuleDeny}
+	info, err := securityHandler.AddRules(sgIID, &[]irs.SecurityRuleInfo{denyRule})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	lastRule := (*info.SecurityRules)[len(*info.SecurityRules)-1]
+	if lastRule.Action != irs.RuleDeny || lastRule.Priority != "100" || lastRule.Description != "deny rdp" {
+		t.Errorf("The Rule is not same: %v", lastRule)
+	}
+
+	//---- The allow Rule with the same ports is not the deny Rule
+	allowRule := denyRule
+	allowRule.Action = irs.RuleAllow
+	if result, _ := securityHandler.RemoveRules(sgIID, &[]irs.SecurityRuleInfo{allowRule}); result {
+		t.Error("The allow Rule should not match the deny Rule!")
+	}
+
+	//---- Remove the deny Rule
+	result, err := securityHandler.RemoveRules(sgIID, &[]irs.SecurityRuleInfo{denyRule})
+	if result != true {
+		t.Error(err.Error())
+	}
+}
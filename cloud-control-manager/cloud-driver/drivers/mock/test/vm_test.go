@@ -10,9 +10,11 @@ package mocktest
 
 import (
 	mockdrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock"
+	mockres "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock/resources"
 	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
 	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
 
+	"strings"
 	"testing"
 
 	cblog "github.com/cloud-barista/cb-log"
@@ -354,3 +356,268 @@ func TestVMTerminateGet(t *testing.T) {
 	}
 
 }
+
+func TestStartVMUserData(t *testing.T) {
+
+	info := vmTestInfoList[0]
+	vmReqInfo := irs.VMReqInfo{
+		IId: irs.IID{NameId: "mock-vm-userdata"},
+
+		ImageIID:          irs.IID{NameId: info.ImageIID},
+		VpcIID:            irs.IID{NameId: info.VpcIID},
+		SubnetIID:         irs.IID{NameId: info.SubnetIID},
+		SecurityGroupIIDs: []irs.IID{{NameId: info.SecurityGroupIIDs[0]}},
+
+		VMSpecName: info.VMSpecName,
+		KeyPairIID: irs.IID{NameId: info.KeyPairIID},
+
+		UserData: "plain text without a header",
+	}
+
+	// unsupported format
+	if _, err := vmHandler.StartVM(vmReqInfo); err == nil {
+		t.Error("Starting a VM with an unsupported UserData format should be failed!")
+	}
+
+	// cloud-init
+	vmReqInfo.UserData = "#cloud-config\npackages:\n  - nginx\n"
+	vmInfo, err := vmHandler.StartVM(vmReqInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if vmInfo.UserDataHash != irs.GetUserDataHash(vmReqInfo.UserData) {
+		t.Errorf("UserDataHash %s is not same %s", vmInfo.UserDataHash, irs.GetUserDataHash(vmReqInfo.UserData))
+	}
+	if len(vmInfo.KeyValueList) == 0 || vmInfo.KeyValueList[0].Value != "cloud-init" {
+		t.Errorf("UserDataFormat is not cloud-init: %v", vmInfo.KeyValueList)
+	}
+
+	getInfo, err := vmHandler.GetVM(vmInfo.IId)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if getInfo.UserDataHash != vmInfo.UserDataHash {
+		t.Errorf("UserDataHash %s is not same %s", getInfo.UserDataHash, vmInfo.UserDataHash)
+	}
+
+	if _, err := vmHandler.TerminateVM(vmInfo.IId); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestChangeVMSpec(t *testing.T) {
+
+	info := vmTestInfoList[0]
+	vmReqInfo := irs.VMReqInfo{
+		IId: irs.IID{NameId: "mock-vm-changespec"},
+
+		ImageIID:          irs.IID{NameId: info.ImageIID},
+		VpcIID:            irs.IID{NameId: info.VpcIID},
+		SubnetIID:         irs.IID{NameId: info.SubnetIID},
+		SecurityGroupIIDs: []irs.IID{{NameId: info.SecurityGroupIIDs[0]}},
+
+		VMSpecName: "mock-vmspec-01",
+		KeyPairIID: irs.IID{NameId: info.KeyPairIID},
+	}
+	vmInfo, err := vmHandler.StartVM(vmReqInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// running VM
+	if _, err := vmHandler.ChangeVMSpec(vmInfo.IId, "mock-vmspec-03"); err == nil {
+		t.Error("Changing the VMSpec of a running VM should be failed!")
+	}
+
+	if _, err := vmHandler.SuspendVM(vmInfo.IId); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// unknown VMSpec
+	if _, err := vmHandler.ChangeVMSpec(vmInfo.IId, "mock-vmspec-unknown"); err == nil {
+		t.Error("Changing to an unknown VMSpec should be failed!")
+	}
+
+	changedInfo, err := vmHandler.ChangeVMSpec(vmInfo.IId, "mock-vmspec-03")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if changedInfo.VMSpecName != "mock-vmspec-03" {
+		t.Errorf("VMSpecName: expected mock-vmspec-03, got %s", changedInfo.VMSpecName)
+	}
+
+	getInfo, err := vmHandler.GetVM(vmInfo.IId)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if getInfo.VMSpecName != "mock-vmspec-03" {
+		t.Errorf("VMSpecName: expected mock-vmspec-03, got %s", getInfo.VMSpecName)
+	}
+
+	if _, err := vmHandler.TerminateVM(vmInfo.IId); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestStartVMSpot(t *testing.T) {
+
+	info := vmTestInfoList[0]
+	vmReqInfo := irs.VMReqInfo{
+		IId: irs.IID{NameId: "mock-vm-spot"},
+
+		ImageIID:          irs.IID{NameId: info.ImageIID},
+		VpcIID:            irs.IID{NameId: info.VpcIID},
+		SubnetIID:         irs.IID{NameId: info.SubnetIID},
+		SecurityGroupIIDs: []irs.IID{{NameId: info.SecurityGroupIIDs[0]}},
+
+		VMSpecName: info.VMSpecName,
+		KeyPairIID: irs.IID{NameId: info.KeyPairIID},
+
+		PurchaseOption: irs.PurchaseOptionInfo{Type: irs.Spot, MaxPrice: "0.1"},
+	}
+	vmInfo, err := vmHandler.StartVM(vmReqInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	getInfo, err := vmHandler.GetVM(vmInfo.IId)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if getInfo.PurchaseOption.Type != irs.Spot || getInfo.PurchaseOption.MaxPrice != "0.1" {
+		t.Errorf("PurchaseOption is not same: %v", getInfo.PurchaseOption)
+	}
+
+	// interruption by the CSP
+	if err := mockres.PreemptVM("MockDriver-77", vmInfo.IId); err != nil {
+		t.Fatal(err.Error())
+	}
+	status, err := vmHandler.GetVMStatus(vmInfo.IId)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if status != irs.Preempted {
+		t.Errorf("VM status: expected Preempted, got %s", status)
+	}
+
+	if _, err := vmHandler.TerminateVM(vmInfo.IId); err != nil {
+		t.Error(err.Error())
+	}
+
+	// OnDemand VM can not be preempted
+	vmReqInfo.IId = irs.IID{NameId: "mock-vm-ondemand"}
+	vmReqInfo.PurchaseOption = irs.PurchaseOptionInfo{}
+	vmInfo, err = vmHandler.StartVM(vmReqInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if vmInfo.PurchaseOption.Type != irs.OnDemand {
+		t.Errorf("PurchaseOption: expected OnDemand, got %s", vmInfo.PurchaseOption.Type)
+	}
+	if err := mockres.PreemptVM("MockDriver-77", vmInfo.IId); err == nil {
+		t.Error("Preempting an OnDemand VM should be failed!")
+	}
+
+	if _, err := vmHandler.TerminateVM(vmInfo.IId); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestGetConsoleOutput(t *testing.T) {
+
+	info := vmTestInfoList[0]
+	vmReqInfo := irs.VMReqInfo{
+		IId: irs.IID{NameId: "mock-vm-console"},
+
+		ImageIID:          irs.IID{NameId: info.ImageIID},
+		VpcIID:            irs.IID{NameId: info.VpcIID},
+		SubnetIID:         irs.IID{NameId: info.SubnetIID},
+		SecurityGroupIIDs: []irs.IID{{NameId: info.SecurityGroupIIDs[0]}},
+
+		VMSpecName: info.VMSpecName,
+		KeyPairIID: irs.IID{NameId: info.KeyPairIID},
+	}
+	vmInfo, err := vmHandler.StartVM(vmReqInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	output, err := vmHandler.GetConsoleOutput(vmInfo.IId)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !strings.Contains(output, vmInfo.IId.NameId+" login:") {
+		t.Errorf("The console output does not have the login prompt: %s", output)
+	}
+
+	if _, err := vmHandler.TerminateVM(vmInfo.IId); err != nil {
+		t.Error(err.Error())
+	}
+
+	if _, err := vmHandler.GetConsoleOutput(vmInfo.IId); err == nil {
+		t.Error("Getting the console output of a terminated VM should be failed!")
+	}
+}
+
+func TestStartVMIPv6(t *testing.T) {
+
+	// dual-stack vpc creation
+	vpcHandler := mockres.MockVPCHandler{MockName: "MockDriver-77"}
+	vpcReqInfo := irs.VPCReqInfo{
+		IId:       irs.IID{NameId: "mock-vpc-ipv6"},
+		IPv4_CIDR: "10.0.0.0/16",
+		IPv6_CIDR: "2001:db8:1234:1a00::/56",
+		SubnetInfoList: []irs.SubnetInfo{
+			{IId: irs.IID{NameId: "mock-subnet-ipv6"}, IPv4_CIDR: "10.0.1.0/24", IPv6_CIDR: "2001:db8:1234:1a01::/64"},
+			{IId: irs.IID{NameId: "mock-subnet-ipv4"}, IPv4_CIDR: "10.0.2.0/24"},
+		},
+	}
+	vpcInfo, err := vpcHandler.CreateVPC(vpcReqInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if vpcInfo.IPv6_CIDR != vpcReqInfo.IPv6_CIDR || vpcInfo.SubnetInfoList[0].IPv6_CIDR != vpcReqInfo.SubnetInfoList[0].IPv6_CIDR {
+		t.Errorf("IPv6_CIDR is not same: %v", vpcInfo)
+	}
+
+	info := vmTestInfoList[0]
+	vmReqInfo := irs.VMReqInfo{
+		IId: irs.IID{NameId: "mock-vm-ipv6"},
+
+		ImageIID:          irs.IID{NameId: info.ImageIID},
+		VpcIID:            irs.IID{NameId: "mock-vpc-ipv6"},
+		SubnetIID:         irs.IID{NameId: "mock-subnet-ipv6"},
+		SecurityGroupIIDs: []irs.IID{{NameId: info.SecurityGroupIIDs[0]}},
+
+		VMSpecName: info.VMSpecName,
+		KeyPairIID: irs.IID{NameId: info.KeyPairIID},
+	}
+	vmInfo, err := vmHandler.StartVM(vmReqInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if vmInfo.IPv6Address != "2001:db8:1234:1a01::4" {
+		t.Errorf("IPv6Address: expected 2001:db8:1234:1a01::4, got %s", vmInfo.IPv6Address)
+	}
+	if _, err := vmHandler.TerminateVM(vmInfo.IId); err != nil {
+		t.Error(err.Error())
+	}
+
+	// IPv4 only subnet has no IPv6Address
+	vmReqInfo.IId = irs.IID{NameId: "mock-vm-ipv4"}
+	vmReqInfo.SubnetIID = irs.IID{NameId: "mock-subnet-ipv4"}
+	vmInfo, err = vmHandler.StartVM(vmReqInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if vmInfo.IPv6Address != "" {
+		t.Errorf("IPv6Address: expected empty, got %s", vmInfo.IPv6Address)
+	}
+	if _, err := vmHandler.TerminateVM(vmInfo.IId); err != nil {
+		t.Error(err.Error())
+	}
+
+	if _, err := vpcHandler.DeleteVPC(vpcInfo.IId); err != nil {
+		t.Error(err.Error())
+	}
+}
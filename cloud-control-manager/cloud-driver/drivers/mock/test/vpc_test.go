@@ -182,3 +182,105 @@ func TestVPCDeleteGet(t *testing.T) {
 		t.Errorf("The number of Infos is not %d. It is %d.", 0, len(infoList))
 	}
 }
+
+func TestSubnetUsageUpdate(t *testing.T) {
+	cloudConn, _ := (&mockdrv.MockDriver{}).ConnectCloud(idrv.ConnectionInfo{
+		CredentialInfo: idrv.CredentialInfo{MockName: "MockDriver-SubnetUpdate-01"},
+	})
+	subnetVPCHandler, _ := cloudConn.CreateVPCHandler()
+	subnetNATHandler, _ := cloudConn.CreateNATGatewayHandler()
+	subnetRouteTableHandler, _ := cloudConn.CreateRouteTableHandler()
+
+	vpcInfo, err := subnetVPCHandler.CreateVPC(irs.VPCReqInfo{
+		IId:       irs.IID{NameId: "mock-subnet-update-vpc"},
+		IPv4_CIDR: "10.0.0.0/16",
+		SubnetInfoList: []irs.SubnetInfo{
+			{IId: irs.IID{NameId: "mock-subnet-a"}, IPv4_CIDR: "10.0.1.0/24"},
+			{IId: irs.IID{NameId: "mock-subnet-b"}, IPv4_CIDR: "10.0.2.0/28"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	subnetA, subnetB := vpcInfo.SubnetInfoList[0].IId, vpcInfo.SubnetInfoList[1].IId
+
+	// usage
+	if _, err := subnetNATHandler.CreateNATGateway(irs.NATGatewayReqInfo{IId: irs.IID{NameId: "mock-subnet-nat"}, VpcIID: vpcInfo.IId, SubnetIID: subnetA}); err != nil {
+		t.Fatal(err.Error())
+	}
+	usage, err := subnetVPCHandler.GetSubnetUsage(vpcInfo.IId, subnetA)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if usage.TotalIPCount != 256 || usage.ReservedIPCount != 5 || usage.UsedIPCount != 1 || usage.AvailableIPCount != 250 {
+		t.Errorf("unexpected usage: %#v", usage)
+	}
+	usage, err = subnetVPCHandler.GetSubnetUsage(vpcInfo.IId, subnetB)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if usage.TotalIPCount != 16 || usage.UsedIPCount != 0 || usage.AvailableIPCount != 11 {
+		t.Errorf("unexpected usage: %#v", usage)
+	}
+	if _, err := subnetVPCHandler.GetSubnetUsage(vpcInfo.IId, irs.IID{NameId: "no-subnet", SystemId: "no-subnet"}); err == nil {
+		t.Error("expected an error for a not existing Subnet")
+	}
+
+	// auto-assign public IP
+	autoAssign := true
+	subnetInfo, err := subnetVPCHandler.UpdateSubnet(vpcInfo.IId, subnetA, irs.SubnetUpdateInfo{AutoAssignPublicIP: &autoAssign})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if getKeyValue(subnetInfo.KeyValueList, "AutoAssignPublicIP") != "true" {
+		t.Errorf("AutoAssignPublicIP is not updated: %#v", subnetInfo.KeyValueList)
+	}
+
+	// move subnet B to the Route Table of subnet A, and back to the main one
+	routeTableA, err := subnetRouteTableHandler.AddRoute(vpcInfo.IId, subnetA, irs.RouteInfo{DestinationCIDR: "0.0.0.0/0", TargetType: irs.RouteTargetInternetGateway})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := subnetVPCHandler.UpdateSubnet(vpcInfo.IId, subnetB, irs.SubnetUpdateInfo{RouteTableIID: &routeTableA.IId}); err != nil {
+		t.Fatal(err.Error())
+	}
+	routeTableB, err := subnetRouteTableHandler.GetRouteTable(vpcInfo.IId, subnetB)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if routeTableB.IId.SystemId != routeTableA.IId.SystemId {
+		t.Errorf("Route Table: expected %s, got %s", routeTableA.IId.SystemId, routeTableB.IId.SystemId)
+	}
+
+	mainTable, err := subnetRouteTableHandler.GetRouteTable(vpcInfo.IId, irs.IID{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	subnetInfo, err = subnetVPCHandler.UpdateSubnet(vpcInfo.IId, subnetB, irs.SubnetUpdateInfo{RouteTableIID: &mainTable.IId})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if getKeyValue(subnetInfo.KeyValueList, "RouteTableId") != mainTable.IId.SystemId || getKeyValue(subnetInfo.KeyValueList, "AutoAssignPublicIP") != "" {
+		t.Errorf("unexpected KeyValueList: %#v", subnetInfo.KeyValueList)
+	}
+	routeTableB, err = subnetRouteTableHandler.GetRouteTable(vpcInfo.IId, subnetB)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !routeTableB.IsMain {
+		t.Errorf("Route Table: expected the main one, got %s", routeTableB.IId.SystemId)
+	}
+
+	if _, err := subnetVPCHandler.UpdateSubnet(vpcInfo.IId, subnetB, irs.SubnetUpdateInfo{RouteTableIID: &irs.IID{NameId: "rtb-none", SystemId: "rtb-none"}}); err == nil {
+		t.Error("expected an error for a not existing Route Table")
+	}
+}
+
+func getKeyValue(kvList []irs.KeyValue, key string) string {
+	for _, kv := range kvList {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,119 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package mocktest
+
+import (
+	mockdrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock"
+	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"testing"
+
+	cblog "github.com/cloud-barista/cb-log"
+)
+
+var peeringVPCHandler irs.VPCHandler
+var peerVPCHandler irs.VPCHandler
+var vpcPeeringHandler irs.VPCPeeringHandler
+var peerVPCPeeringHandler irs.VPCPeeringHandler
+
+func init() {
+	// make the log level lower to print clearly
+	cblog.SetLevel("error")
+
+	// requester connection
+	cloudConn, _ := (&mockdrv.MockDriver{}).ConnectCloud(idrv.ConnectionInfo{
+		CredentialInfo: idrv.CredentialInfo{MockName: "MockDriver-Peering-01"},
+	})
+	peeringVPCHandler, _ = cloudConn.CreateVPCHandler()
+	vpcPeeringHandler, _ = cloudConn.CreateVPCPeeringHandler()
+
+	// accepter connection
+	peerConn, _ := (&mockdrv.MockDriver{}).ConnectCloud(idrv.ConnectionInfo{
+		CredentialInfo: idrv.CredentialInfo{MockName: "MockDriver-Peering-02"},
+	})
+	peerVPCHandler, _ = peerConn.CreateVPCHandler()
+	peerVPCPeeringHandler, _ = peerConn.CreateVPCPeeringHandler()
+}
+
+func TestVPCPeeringRequestAcceptDelete(t *testing.T) {
+	appVPC, err := peeringVPCHandler.CreateVPC(irs.VPCReqInfo{IId: irs.IID{NameId: "mock-app-vpc"}, IPv4_CIDR: "10.0.0.0/16"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	sharedVPC, err := peerVPCHandler.CreateVPC(irs.VPCReqInfo{IId: irs.IID{NameId: "mock-shared-vpc"}, IPv4_CIDR: "10.1.0.0/16"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// request
+	reqInfo := irs.VPCPeeringReqInfo{
+		IId:                       irs.IID{NameId: "mock-peering-01"},
+		RequesterVPCIID:           appVPC.IId,
+		AccepterVPCIID:            sharedVPC.IId,
+		RequesterRoutePropagation: true,
+	}
+	info, err := vpcPeeringHandler.RequestVPCPeering(reqInfo)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if info.Status != irs.PeeringPendingAcceptance {
+		t.Errorf("Status: expected %s, got %s", irs.PeeringPendingAcceptance, info.Status)
+	}
+	if info.AccepterCIDR != "10.1.0.0/16" || !info.RequesterRoutePropagation || info.AccepterRoutePropagation {
+		t.Errorf("unexpected peering info: %#v", info)
+	}
+
+	// accept with the accepter connection
+	info, err = peerVPCPeeringHandler.AcceptVPCPeering(info.IId)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if info.Status != irs.PeeringActive {
+		t.Errorf("Status: expected %s, got %s", irs.PeeringActive, info.Status)
+	}
+
+	// get & list
+	info, err = vpcPeeringHandler.GetVPCPeering(info.IId)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if info.Status != irs.PeeringActive {
+		t.Errorf("Status: expected %s, got %s", irs.PeeringActive, info.Status)
+	}
+	infoList, err := vpcPeeringHandler.ListVPCPeering()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if len(infoList) != 1 {
+		t.Errorf("The number of Peerings: expected 1, got %d", len(infoList))
+	}
+
+	// delete
+	result, err := vpcPeeringHandler.DeleteVPCPeering(info.IId)
+	if err != nil || !result {
+		t.Errorf("failed to delete the Peering: %v", err)
+	}
+	infoList, _ = vpcPeeringHandler.ListVPCPeering()
+	if len(infoList) != 0 {
+		t.Errorf("The number of Peerings: expected 0, got %d", len(infoList))
+	}
+}
+
+func TestVPCPeeringRequestWithUnknownVPC(t *testing.T) {
+	reqInfo := irs.VPCPeeringReqInfo{
+		IId:             irs.IID{NameId: "mock-peering-02"},
+		RequesterVPCIID: irs.IID{NameId: "no-vpc", SystemId: "no-vpc"},
+		AccepterVPCIID:  irs.IID{NameId: "no-peer-vpc", SystemId: "no-peer-vpc"},
+	}
+	_, err := vpcPeeringHandler.RequestVPCPeering(reqInfo)
+	if err == nil {
+		t.Error("Requesting a Peering with unknown VPCs should be failed!")
+	}
+}
@@ -0,0 +1,180 @@
+// Mock Driver Test of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// by CB-Spider Team, 2024.10.
+
+package mocktest
+
+import (
+	mockdrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/drivers/mock"
+	idrv "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces"
+	irs "github.com/cloud-barista/cb-spider/cloud-control-manager/cloud-driver/interfaces/resources"
+
+	"testing"
+
+	cblog "github.com/cloud-barista/cb-log"
+)
+
+var vpnVPCHandler irs.VPCHandler
+var vpnPeerVPCHandler irs.VPCHandler
+var vpnGatewayHandler irs.VPNGatewayHandler
+var vpnPeerGatewayHandler irs.VPNGatewayHandler
+
+func init() {
+	// make the log level lower to print clearly
+	cblog.SetLevel("error")
+
+	cloudConn, _ := (&mockdrv.MockDriver{}).ConnectCloud(idrv.ConnectionInfo{
+		CredentialInfo: idrv.CredentialInfo{MockName: "MockDriver-VPN-01"},
+	})
+	vpnVPCHandler, _ = cloudConn.CreateVPCHandler()
+	vpnGatewayHandler, _ = cloudConn.CreateVPNGatewayHandler()
+
+	peerConn, _ := (&mockdrv.MockDriver{}).ConnectCloud(idrv.ConnectionInfo{
+		CredentialInfo: idrv.CredentialInfo{MockName: "MockDriver-VPN-02"},
+	})
+	vpnPeerVPCHandler, _ = peerConn.CreateVPCHandler()
+	vpnPeerGatewayHandler, _ = peerConn.CreateVPNGatewayHandler()
+}
+
+func TestVPNGatewayTunnelPairing(t *testing.T) {
+	vpcInfo, err := vpnVPCHandler.CreateVPC(irs.VPCReqInfo{IId: irs.IID{NameId: "mock-vpn-vpc"}, IPv4_CIDR: "10.0.0.0/16"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	peerVPCInfo, err := vpnPeerVPCHandler.CreateVPC(irs.VPCReqInfo{IId: irs.IID{NameId: "mock-vpn-peer-vpc"}, IPv4_CIDR: "10.1.0.0/16"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	gwInfo, err := vpnGatewayHandler.CreateVPNGateway(irs.VPNGatewayReqInfo{IId: irs.IID{NameId: "mock-vpn-gw"}, VpcIID: vpcInfo.IId})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	peerGWInfo, err := vpnPeerGatewayHandler.CreateVPNGateway(irs.VPNGatewayReqInfo{IId: irs.IID{NameId: "mock-vpn-peer-gw"}, VpcIID: peerVPCInfo.IId})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if gwInfo.PublicIP == "" || gwInfo.PublicIP == peerGWInfo.PublicIP {
+		t.Errorf("unexpected public IPs: %s, %s", gwInfo.PublicIP, peerGWInfo.PublicIP)
+	}
+
+	// a second gateway for the same VPC is rejected
+	if _, err := vpnGatewayHandler.CreateVPNGateway(irs.VPNGatewayReqInfo{IId: irs.IID{NameId: "mock-vpn-gw-2"}, VpcIID: vpcInfo.IId}); err == nil {
+		t.Error("expected an error for the second VPN Gateway of the same VPC")
+	}
+
+	// one side only: Pending
+	tunnelInfo, err := vpnGatewayHandler.CreateVPNTunnel(irs.VPNTunnelReqInfo{
+		IId:           irs.IID{NameId: "mock-vpn-tunnel"},
+		VPNGatewayIID: gwInfo.IId,
+		PeerPublicIP:  peerGWInfo.PublicIP,
+		PeerCIDRList:  []string{peerVPCInfo.IPv4_CIDR},
+		PreSharedKey:  "mock-psk",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if tunnelInfo.Status != irs.VPNTunnelPending {
+		t.Errorf("Status: expected %s, got %s", irs.VPNTunnelPending, tunnelInfo.Status)
+	}
+
+	// both sides with the same key: Up
+	peerTunnelInfo, err := vpnPeerGatewayHandler.CreateVPNTunnel(irs.VPNTunnelReqInfo{
+		IId:           irs.IID{NameId: "mock-vpn-peer-tunnel"},
+		VPNGatewayIID: peerGWInfo.IId,
+		PeerPublicIP:  gwInfo.PublicIP,
+		PeerCIDRList:  []string{vpcInfo.IPv4_CIDR},
+		PreSharedKey:  "mock-psk",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if peerTunnelInfo.Status != irs.VPNTunnelUp {
+		t.Errorf("Status: expected %s, got %s", irs.VPNTunnelUp, peerTunnelInfo.Status)
+	}
+	tunnelInfo, err = vpnGatewayHandler.GetVPNTunnel(tunnelInfo.IId)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if tunnelInfo.Status != irs.VPNTunnelUp {
+		t.Errorf("Status: expected %s, got %s", irs.VPNTunnelUp, tunnelInfo.Status)
+	}
+
+	tunnelList, err := vpnGatewayHandler.ListVPNTunnel(gwInfo.IId)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(tunnelList) != 1 {
+		t.Errorf("ListVPNTunnel: expected 1, got %d", len(tunnelList))
+	}
+
+	// the gateway cannot be deleted while it has a tunnel
+	if _, err := vpnGatewayHandler.DeleteVPNGateway(gwInfo.IId); err == nil {
+		t.Error("expected an error for deleting a VPN Gateway in use")
+	}
+
+	for _, h := range []struct {
+		handler   irs.VPNGatewayHandler
+		tunnelIID irs.IID
+		gwIID     irs.IID
+	}{
+		{vpnGatewayHandler, tunnelInfo.IId, gwInfo.IId},
+		{vpnPeerGatewayHandler, peerTunnelInfo.IId, peerGWInfo.IId},
+	} {
+		if _, err := h.handler.DeleteVPNTunnel(h.tunnelIID); err != nil {
+			t.Error(err.Error())
+		}
+		if _, err := h.handler.DeleteVPNGateway(h.gwIID); err != nil {
+			t.Error(err.Error())
+		}
+	}
+
+	iidList, err := vpnGatewayHandler.ListIID()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(iidList) != 0 {
+		t.Errorf("ListIID: expected 0, got %d", len(iidList))
+	}
+}
+
+func TestVPNTunnelPreSharedKeyMismatch(t *testing.T) {
+	vpcInfo, err := vpnVPCHandler.CreateVPC(irs.VPCReqInfo{IId: irs.IID{NameId: "mock-vpn-psk-vpc"}, IPv4_CIDR: "10.2.0.0/16"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	peerVPCInfo, err := vpnPeerVPCHandler.CreateVPC(irs.VPCReqInfo{IId: irs.IID{NameId: "mock-vpn-psk-peer-vpc"}, IPv4_CIDR: "10.3.0.0/16"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	gwInfo, err := vpnGatewayHandler.CreateVPNGateway(irs.VPNGatewayReqInfo{IId: irs.IID{NameId: "mock-vpn-psk-gw"}, VpcIID: vpcInfo.IId})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	peerGWInfo, err := vpnPeerGatewayHandler.CreateVPNGateway(irs.VPNGatewayReqInfo{IId: irs.IID{NameId: "mock-vpn-psk-peer-gw"}, VpcIID: peerVPCInfo.IId})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	_, err = vpnGatewayHandler.CreateVPNTunnel(irs.VPNTunnelReqInfo{
+		IId: irs.IID{NameId: "mock-vpn-psk-tunnel"}, VPNGatewayIID: gwInfo.IId,
+		PeerPublicIP: peerGWInfo.PublicIP, PeerCIDRList: []string{peerVPCInfo.IPv4_CIDR}, PreSharedKey: "key-a",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	peerTunnelInfo, err := vpnPeerGatewayHandler.CreateVPNTunnel(irs.VPNTunnelReqInfo{
+		IId: irs.IID{NameId: "mock-vpn-psk-peer-tunnel"}, VPNGatewayIID: peerGWInfo.IId,
+		PeerPublicIP: gwInfo.PublicIP, PeerCIDRList: []string{vpcInfo.IPv4_CIDR}, PreSharedKey: "key-b",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if peerTunnelInfo.Status != irs.VPNTunnelDown {
+		t.Errorf("Status: expected %s, got %s", irs.VPNTunnelDown, peerTunnelInfo.Status)
+	}
+}
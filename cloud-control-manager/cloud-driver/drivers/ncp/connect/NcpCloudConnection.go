@@ -159,3 +159,39 @@ func (cloudConn *NcpCloudConnection) Close() error {
 
 	return nil
 }
+
+func (cloudConn *NcpCloudConnection) CreateVPCPeeringHandler() (irs.VPCPeeringHandler, error) {
+	cblogger.Info("NCP Cloud Driver: called CreateVPCPeeringHandler()!")
+
+	return nil, fmt.Errorf("NCP Cloud Driver does not support CreateVPCPeeringHandler yet.")
+}
+
+func (cloudConn *NcpCloudConnection) CreateRouteTableHandler() (irs.RouteTableHandler, error) {
+	cblogger.Info("NCP Cloud Driver: called CreateRouteTableHandler()!")
+
+	return nil, fmt.Errorf("NCP Cloud Driver does not support CreateRouteTableHandler yet.")
+}
+
+func (cloudConn *NcpCloudConnection) CreateNATGatewayHandler() (irs.NATGatewayHandler, error) {
+	cblogger.Info("NCP Cloud Driver: called CreateNATGatewayHandler()!")
+
+	return nil, fmt.Errorf("NCP Cloud Driver does not support CreateNATGatewayHandler yet.")
+}
+
+func (cloudConn *NcpCloudConnection) CreateVPNGatewayHandler() (irs.VPNGatewayHandler, error) {
+	cblogger.Info("NCP Cloud Driver: called CreateVPNGatewayHandler()!")
+
+	return nil, fmt.Errorf("NCP Cloud Driver does not support CreateVPNGatewayHandler yet.")
+}
+
+func (cloudConn *NcpCloudConnection) CreateDNSHandler() (irs.DNSHandler, error) {
+	cblogger.Info("NCP Cloud Driver: called CreateDNSHandler()!")
+
+	return nil, fmt.Errorf("NCP Cloud Driver does not support CreateDNSHandler yet.")
+}
+
+func (cloudConn *NcpCloudConnection) CreateALBHandler() (irs.ALBHandler, error) {
+	cblogger.Info("NCP Cloud Driver: called CreateALBHandler()!")
+
+	return nil, fmt.Errorf("NCP Cloud Driver does not support CreateALBHandler yet.")
+}
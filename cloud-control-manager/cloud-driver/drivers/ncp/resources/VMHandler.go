@@ -2071,3 +2071,11 @@ func (vmHandler *NcpVMHandler) getVMSubnetZone(vmId string) (string, error) {
 	}
 	return reqZoneId, nil
 }
+
+func (vmHandler *NcpVMHandler) ChangeVMSpec(vmIID irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	return irs.VMInfo{}, errors.New("Does not support ChangeVMSpec() yet!!")
+}
+
+func (vmHandler *NcpVMHandler) GetConsoleOutput(vmIID irs.IID) (string, error) {
+	return "", errors.New("Does not support GetConsoleOutput() yet!!")
+}
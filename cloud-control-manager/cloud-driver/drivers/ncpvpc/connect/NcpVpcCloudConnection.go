@@ -169,3 +169,27 @@ func (cloudConn *NcpVpcCloudConnection) Close() error {
 func (cloudConn *NcpVpcCloudConnection) CreateTagHandler() (irs.TagHandler, error) {
 	return nil, fmt.Errorf("NCP VPC Cloud Driver: not implemented")
 }
+
+func (cloudConn *NcpVpcCloudConnection) CreateVPCPeeringHandler() (irs.VPCPeeringHandler, error) {
+	return nil, fmt.Errorf("NCP VPC Cloud Driver: not implemented")
+}
+
+func (cloudConn *NcpVpcCloudConnection) CreateRouteTableHandler() (irs.RouteTableHandler, error) {
+	return nil, fmt.Errorf("NCP VPC Cloud Driver: not implemented")
+}
+
+func (cloudConn *NcpVpcCloudConnection) CreateNATGatewayHandler() (irs.NATGatewayHandler, error) {
+	return nil, fmt.Errorf("NCP VPC Cloud Driver: not implemented")
+}
+
+func (cloudConn *NcpVpcCloudConnection) CreateVPNGatewayHandler() (irs.VPNGatewayHandler, error) {
+	return nil, fmt.Errorf("NCP VPC Cloud Driver: not implemented")
+}
+
+func (cloudConn *NcpVpcCloudConnection) CreateDNSHandler() (irs.DNSHandler, error) {
+	return nil, fmt.Errorf("NCP VPC Cloud Driver: not implemented")
+}
+
+func (cloudConn *NcpVpcCloudConnection) CreateALBHandler() (irs.ALBHandler, error) {
+	return nil, fmt.Errorf("NCP VPC Cloud Driver: not implemented")
+}
@@ -156,3 +156,27 @@ func (cloudConn *NhnCloudConnection) Close() error {
 func (cloudConn *NhnCloudConnection) CreateTagHandler() (irs.TagHandler, error) {
 	return nil, errors.New("NHN Cloud Driver: not implemented")
 }
+
+func (cloudConn *NhnCloudConnection) CreateVPCPeeringHandler() (irs.VPCPeeringHandler, error) {
+	return nil, errors.New("NHN Cloud Driver: not implemented")
+}
+
+func (cloudConn *NhnCloudConnection) CreateRouteTableHandler() (irs.RouteTableHandler, error) {
+	return nil, errors.New("NHN Cloud Driver: not implemented")
+}
+
+func (cloudConn *NhnCloudConnection) CreateNATGatewayHandler() (irs.NATGatewayHandler, error) {
+	return nil, errors.New("NHN Cloud Driver: not implemented")
+}
+
+func (cloudConn *NhnCloudConnection) CreateVPNGatewayHandler() (irs.VPNGatewayHandler, error) {
+	return nil, errors.New("NHN Cloud Driver: not implemented")
+}
+
+func (cloudConn *NhnCloudConnection) CreateDNSHandler() (irs.DNSHandler, error) {
+	return nil, errors.New("NHN Cloud Driver: not implemented")
+}
+
+func (cloudConn *NhnCloudConnection) CreateALBHandler() (irs.ALBHandler, error) {
+	return nil, errors.New("NHN Cloud Driver: not implemented")
+}
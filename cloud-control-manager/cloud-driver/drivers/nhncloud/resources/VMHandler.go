@@ -1349,3 +1349,11 @@ func (vmHandler *NhnCloudVMHandler) ListIID() ([]*irs.IID, error) {
 
 	return iidList, nil
 }
+
+func (vmHandler *NhnCloudVMHandler) ChangeVMSpec(vmIID irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	return irs.VMInfo{}, errors.New("Does not support ChangeVMSpec() yet!!")
+}
+
+func (vmHandler *NhnCloudVMHandler) GetConsoleOutput(vmIID irs.IID) (string, error) {
+	return "", errors.New("Does not support GetConsoleOutput() yet!!")
+}
@@ -189,3 +189,27 @@ func (cloudConn *OpenStackCloudConnection) CreateTagHandler() (irs.TagHandler, e
 	}
 	return &tagHandler, nil
 }
+
+func (cloudConn *OpenStackCloudConnection) CreateVPCPeeringHandler() (irs.VPCPeeringHandler, error) {
+	return nil, errors.New("OpenStack Driver: not implemented")
+}
+
+func (cloudConn *OpenStackCloudConnection) CreateRouteTableHandler() (irs.RouteTableHandler, error) {
+	return nil, errors.New("OpenStack Driver: not implemented")
+}
+
+func (cloudConn *OpenStackCloudConnection) CreateNATGatewayHandler() (irs.NATGatewayHandler, error) {
+	return nil, errors.New("OpenStack Driver: not implemented")
+}
+
+func (cloudConn *OpenStackCloudConnection) CreateVPNGatewayHandler() (irs.VPNGatewayHandler, error) {
+	return nil, errors.New("OpenStack Driver: not implemented")
+}
+
+func (cloudConn *OpenStackCloudConnection) CreateDNSHandler() (irs.DNSHandler, error) {
+	return nil, errors.New("OpenStack Driver: not implemented")
+}
+
+func (cloudConn *OpenStackCloudConnection) CreateALBHandler() (irs.ALBHandler, error) {
+	return nil, errors.New("OpenStack Driver: not implemented")
+}
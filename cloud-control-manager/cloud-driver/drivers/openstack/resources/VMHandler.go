@@ -1377,3 +1377,11 @@ func (vmHandler *OpenStackVMHandler) ListIID() ([]*irs.IID, error) {
 
 	return iidList, nil
 }
+
+func (vmHandler *OpenStackVMHandler) ChangeVMSpec(vmIID irs.IID, vmSpecName string) (irs.VMInfo, error) {
+	return irs.VMInfo{}, errors.New("Does not support ChangeVMSpec() yet!!")
+}
+
+func (vmHandler *OpenStackVMHandler) GetConsoleOutput(vmIID irs.IID) (string, error) {
+	return "", errors.New("Does not support GetConsoleOutput() yet!!")
+}
@@ -31,6 +31,12 @@ type DriverCapabilityInfo struct {
 	MyImageHandler    bool // support: true, do not support: false
 	ClusterHandler    bool // support: true, do not support: false
 	TagHandler        bool // support: true, do not support: false
+	VPCPeeringHandler bool // support: true, do not support: false
+	RouteTableHandler bool // support: true, do not support: false
+	NATGatewayHandler bool // support: true, do not support: false
+	DNSHandler        bool // support: true, do not support: false
+	ALBHandler        bool // support: true, do not support: false
+	VPNGatewayHandler bool // support: true, do not support: false
 
 	// ex) {ires.ALL, ires.VPC, ires.SUBNET, ires.SG, ires.KEY, ires.VM, ires.NLB, ires.DISK, ires.MYIMAGE, ires.CLUSTER}
 	TagSupportResourceType []ires.RSType // support: VPC, SUBNET, etc.,.
@@ -38,6 +44,17 @@ type DriverCapabilityInfo struct {
 	VPC_CIDR          bool // support: true, do not support: false
 	SINGLE_VPC        bool // support: true, do not support: false
 	FIXED_SUBNET_CIDR bool // support: true, do not support: false
+
+	USER_DATA      bool // VMReqInfo.UserData, support: true, do not support: false
+	SPOT_VM        bool // VM PurchaseOption Spot, support: true, do not support: false
+	PREEMPTIBLE_VM bool // VM PurchaseOption Preemptible, support: true, do not support: false
+	CONSOLE_OUTPUT bool // VMHandler.GetConsoleOutput(), support: true, do not support: false
+	VM_SPEC_CHANGE bool // VMHandler.ChangeVMSpec(), support: true, do not support: false
+
+	SG_REFERENCE_RULE bool // SecurityRuleInfo.SecurityGroupIID, support: true, do not support: false
+	SG_RULE_PRIORITY  bool // SecurityRuleInfo.Priority, support: true, do not support: false
+	SG_DENY_RULE      bool // SecurityRuleInfo.Action deny, support: true, do not support: false
+	IPV6              bool // dual-stack: IPv6_CIDR of VPC and Subnet, IPv6Address of VM, IPv6 CIDR of SG rule, support: true, do not support: false
 }
 
 type CredentialInfo struct {
@@ -24,7 +24,14 @@ type CloudConnection interface {
 	CreateKeyPairHandler() (irs.KeyPairHandler, error)
 	CreateVMHandler() (irs.VMHandler, error)
 
+	CreateVPCPeeringHandler() (irs.VPCPeeringHandler, error)
+	CreateRouteTableHandler() (irs.RouteTableHandler, error)
+	CreateNATGatewayHandler() (irs.NATGatewayHandler, error)
+	CreateVPNGatewayHandler() (irs.VPNGatewayHandler, error)
+	CreateDNSHandler() (irs.DNSHandler, error)
+
 	CreateNLBHandler() (irs.NLBHandler, error)
+	CreateALBHandler() (irs.ALBHandler, error)
 	CreateDiskHandler() (irs.DiskHandler, error)
 	CreateMyImageHandler() (irs.MyImageHandler, error)
 
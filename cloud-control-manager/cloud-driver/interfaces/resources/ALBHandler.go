@@ -0,0 +1,103 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Resouces interfaces of Cloud Driver.
+//
+// by CB-Spider Team, 2024.10.
+
+package resources
+
+import "time"
+
+// -------- Info Structure
+// ALBInfo represents the details of an Application Load Balancer (ALB).
+// Requests are routed by Listener Rules(host/path) to the Target Groups referenced by name.
+// @description Application Load Balancer (ALB) Information
+type ALBInfo struct {
+	IId    IID `json:"IId" validate:"required"`
+	VpcIID IID `json:"VpcIID" validate:"required"` // Owner VPC IID
+
+	Type string `json:"Type" validate:"required" example:"PUBLIC"` // PUBLIC(V) | INTERNAL
+
+	//------ Frontend
+	Listeners []ALBListenerInfo `json:"Listeners" validate:"required"`
+	IP        string            `json:"IP,omitempty" validate:"omitempty" example:"192.168.0.1"`
+	DNSName   string            `json:"DNSName,omitempty" validate:"omitempty" example:"alb.example.com"`
+
+	//------ Backend
+	TargetGroups []TargetGroupInfo `json:"TargetGroups" validate:"required"`
+
+	CreatedTime  time.Time  `json:"CreatedTime" validate:"omitempty" example:"2024-10-01T10:00:00Z"`
+	TagList      []KeyValue `json:"TagList,omitempty" validate:"omitempty"`
+	KeyValueList []KeyValue `json:"KeyValueList,omitempty" validate:"omitempty"`
+}
+
+// ALBListenerInfo represents a frontend listener of an ALB.
+// @description Listener Information for an Application Load Balancer (ALB)
+type ALBListenerInfo struct {
+	Protocol       string `json:"Protocol" validate:"required" example:"HTTPS"`                                 // HTTP|HTTPS
+	Port           string `json:"Port" validate:"required" example:"443"`                                       // 1-65535
+	CertificateRef string `json:"CertificateRef,omitempty" validate:"omitempty" example:"arn:aws:acm:...:cert"` // CSP's TLS certificate ID, required for HTTPS
+
+	DefaultTargetGroup string        `json:"DefaultTargetGroup" validate:"required" example:"web-tg"` // Target Group when no Rule is matched
+	Rules              []ALBRuleInfo `json:"Rules,omitempty" validate:"omitempty"`
+
+	CspID        string     `json:"CspID,omitempty" validate:"omitempty"`
+	KeyValueList []KeyValue `json:"KeyValueList,omitempty" validate:"omitempty"`
+}
+
+// ALBRuleInfo represents a host/path based routing rule of a listener.
+// A request matched with all of the specified conditions is forwarded to the TargetGroup.
+// @description Routing Rule Information for an Application Load Balancer (ALB)
+type ALBRuleInfo struct {
+	Priority    int    `json:"Priority" validate:"required" example:"10"`                     // 1-50000, lower is evaluated first
+	Host        string `json:"Host,omitempty" validate:"omitempty" example:"api.example.com"` // Host header, wildcard(*.example.com) allowed
+	PathPattern string `json:"PathPattern,omitempty" validate:"omitempty" example:"/api/*"`   // URL path, wildcard(*) allowed
+	TargetGroup string `json:"TargetGroup" validate:"required" example:"api-tg"`              // name of the Target Group
+
+	CspID        string     `json:"CspID,omitempty" validate:"omitempty"`
+	KeyValueList []KeyValue `json:"KeyValueList,omitempty" validate:"omitempty"`
+}
+
+// TargetGroupInfo represents a backend VM group of an ALB.
+// @description Target Group Information for an Application Load Balancer (ALB)
+type TargetGroupInfo struct {
+	Name     string `json:"Name" validate:"required" example:"web-tg"`   // unique in the ALB
+	Protocol string `json:"Protocol" validate:"required" example:"HTTP"` // HTTP|HTTPS
+	Port     string `json:"Port" validate:"required" example:"8080"`     // 1-65535
+	VMs      *[]IID `json:"VMs" validate:"required"`
+
+	HealthChecker HealthCheckerInfo `json:"HealthChecker" validate:"required"`
+	StickySession StickySessionInfo `json:"StickySession" validate:"omitempty"`
+
+	CspID        string     `json:"CspID,omitempty" validate:"omitempty"`
+	KeyValueList []KeyValue `json:"KeyValueList,omitempty" validate:"omitempty"`
+}
+
+// StickySessionInfo represents the session affinity of a Target Group.
+// @description Sticky Session Information for an Application Load Balancer (ALB)
+type StickySessionInfo struct {
+	Enabled    bool   `json:"Enabled" validate:"omitempty" example:"true"`
+	Type       string `json:"Type,omitempty" validate:"omitempty" example:"LB_COOKIE"`       // LB_COOKIE(V) | APP_COOKIE
+	CookieName string `json:"CookieName,omitempty" validate:"omitempty" example:"SESSIONID"` // application cookie name, required for APP_COOKIE
+	Duration   int    `json:"Duration,omitempty" validate:"omitempty" example:"86400"`       // secs, cookie expiration for LB_COOKIE
+}
+
+// -------- API
+type ALBHandler interface {
+
+	//------ ALB Management
+	ListIID() ([]*IID, error)
+	CreateALB(albReqInfo ALBInfo) (ALBInfo, error)
+	ListALB() ([]*ALBInfo, error)
+	GetALB(albIID IID) (ALBInfo, error)
+	DeleteALB(albIID IID) (bool, error)
+
+	//------ Target Group Control
+	GetTargetGroupHealthInfo(albIID IID, targetGroupName string) (HealthInfo, error)
+	AddTargetVMs(albIID IID, targetGroupName string, vmIIDs *[]IID) (TargetGroupInfo, error)
+	RemoveTargetVMs(albIID IID, targetGroupName string, vmIIDs *[]IID) (bool, error)
+}
@@ -0,0 +1,76 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Resouces interfaces of Cloud Driver.
+//
+// by CB-Spider Team, 2024.10.
+
+package resources
+
+import "time"
+
+// DNSRecordType represents the type of a DNS record.
+type DNSRecordType string
+
+const (
+	DNSRecordA     DNSRecordType = "A"
+	DNSRecordAAAA  DNSRecordType = "AAAA"
+	DNSRecordCNAME DNSRecordType = "CNAME"
+	DNSRecordTXT   DNSRecordType = "TXT"
+)
+
+// -------- Info Structure
+// DNSZoneReqInfo represents the request to create a (public) hosted zone.
+// @description DNS Zone Request Information
+type DNSZoneReqInfo struct {
+	IId        IID    `json:"IId" validate:"required"`
+	DomainName string `json:"DomainName" validate:"required" example:"example.com"`
+
+	TagList []KeyValue `json:"TagList,omitempty" validate:"omitempty"`
+}
+
+// DNSZoneInfo represents the details of a hosted zone.
+// @description DNS Zone Information
+type DNSZoneInfo struct {
+	IId         IID      `json:"IId" validate:"required"`
+	DomainName  string   `json:"DomainName" validate:"required" example:"example.com"`
+	NameServers []string `json:"NameServers,omitempty" validate:"omitempty" example:"ns-1.example-dns.com"` // delegate the domain to these name servers
+	RecordCount int      `json:"RecordCount" validate:"omitempty" example:"3"`
+
+	CreatedTime  time.Time  `json:"CreatedTime" validate:"omitempty" example:"2024-10-01T10:00:00Z"`
+	TagList      []KeyValue `json:"TagList,omitempty" validate:"omitempty"`
+	KeyValueList []KeyValue `json:"KeyValueList,omitempty" validate:"omitempty"`
+}
+
+// DNSRecordInfo represents a record set of a hosted zone.
+// A record is identified by Name and Type.
+// @description DNS Record Information
+type DNSRecordInfo struct {
+	Name   string        `json:"Name" validate:"required" example:"www.example.com"` // FQDN without the trailing dot
+	Type   DNSRecordType `json:"Type" validate:"required" example:"A"`               // A | AAAA | CNAME | TXT
+	TTL    int           `json:"TTL" validate:"required" example:"300"`              // seconds
+	Values []string      `json:"Values" validate:"required" example:"3.34.100.10"`   // CNAME has only one value
+
+	KeyValueList []KeyValue `json:"KeyValueList,omitempty" validate:"omitempty"`
+}
+
+// -------- DNS API
+type DNSHandler interface {
+
+	//------ Zone Management
+	CreateZone(zoneReqInfo DNSZoneReqInfo) (DNSZoneInfo, error)
+	ListZone() ([]*DNSZoneInfo, error)
+	GetZone(zoneIID IID) (DNSZoneInfo, error)
+	DeleteZone(zoneIID IID) (bool, error) // records except the CSP managed ones(SOA, NS) are deleted together
+
+	//------ Record Management
+	ListRecord(zoneIID IID) ([]*DNSRecordInfo, error)
+	GetRecord(zoneIID IID, name string, recordType DNSRecordType) (DNSRecordInfo, error)
+	UpsertRecord(zoneIID IID, record DNSRecordInfo) (DNSRecordInfo, error) // create or replace the record with the same Name and Type
+	DeleteRecord(zoneIID IID, name string, recordType DNSRecordType) (bool, error)
+
+	ListIID() ([]*IID, error)
+}
@@ -0,0 +1,65 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Resouces interfaces of Cloud Driver.
+//
+// by CB-Spider Team, 2024.10.
+
+package resources
+
+import "time"
+
+// NATGatewayStatus represents the lifecycle status of a NAT Gateway.
+type NATGatewayStatus string
+
+const (
+	NATGatewayPending   NATGatewayStatus = "Pending"
+	NATGatewayAvailable NATGatewayStatus = "Available"
+	NATGatewayDeleting  NATGatewayStatus = "Deleting"
+	NATGatewayFailed    NATGatewayStatus = "Failed"
+)
+
+// -------- Info Structure
+// NATGatewayReqInfo represents the request to create a NAT Gateway.
+// The NAT Gateway is placed in a (public) Subnet and a public IP is allocated for it by the driver.
+// @description NAT Gateway Request Information
+type NATGatewayReqInfo struct {
+	IId       IID `json:"IId" validate:"required"`
+	VpcIID    IID `json:"VpcIID" validate:"required"`
+	SubnetIID IID `json:"SubnetIID" validate:"required"` // Subnet where the NAT Gateway is placed
+
+	TagList []KeyValue `json:"TagList,omitempty" validate:"omitempty"`
+}
+
+// NATGatewayInfo represents the details of a NAT Gateway.
+// @description NAT Gateway Information
+type NATGatewayInfo struct {
+	IId       IID `json:"IId" validate:"required"`
+	VpcIID    IID `json:"VpcIID" validate:"required"`
+	SubnetIID IID `json:"SubnetIID" validate:"required"`
+
+	PublicIP  string `json:"PublicIP" validate:"required" example:"3.34.100.10"` // allocated public IP for outbound traffic
+	PrivateIP string `json:"PrivateIP,omitempty" validate:"omitempty" example:"10.0.1.10"`
+
+	Status NATGatewayStatus `json:"Status" validate:"required" example:"Available"`
+
+	CreatedTime  time.Time  `json:"CreatedTime" validate:"omitempty" example:"2024-10-01T10:00:00Z"`
+	TagList      []KeyValue `json:"TagList,omitempty" validate:"omitempty"`
+	KeyValueList []KeyValue `json:"KeyValueList,omitempty" validate:"omitempty"`
+}
+
+// -------- NAT Gateway API
+type NATGatewayHandler interface {
+
+	//------ NAT Gateway Management
+	// The allocated public IP is released when the NAT Gateway is deleted.
+	CreateNATGateway(natReqInfo NATGatewayReqInfo) (NATGatewayInfo, error)
+	ListNATGateway() ([]*NATGatewayInfo, error)
+	GetNATGateway(natIID IID) (NATGatewayInfo, error)
+	DeleteNATGateway(natIID IID) (bool, error)
+
+	ListIID() ([]*IID, error)
+}
@@ -30,6 +30,12 @@ const (
 	MYIMAGE   RSType = "myimage"
 	CLUSTER   RSType = "cluster"
 	NODEGROUP RSType = "nodegroup"
+
+	VPCPEERING RSType = "vpcpeering"
+	NATGATEWAY RSType = "natgateway"
+	DNSZONE    RSType = "dnszone"
+	ALB        RSType = "alb"
+	VPNGATEWAY RSType = "vpngateway"
 )
 
 func RSTypeString(rsType RSType) string {
@@ -58,6 +64,16 @@ func RSTypeString(rsType RSType) string {
 		return "Kubernetes Cluster"
 	case NODEGROUP:
 		return "Kubernetes NodeGroup"
+	case VPCPEERING:
+		return "VPC Peering"
+	case NATGATEWAY:
+		return "NAT Gateway"
+	case DNSZONE:
+		return "DNS Zone"
+	case ALB:
+		return "Application Load Balancer"
+	case VPNGATEWAY:
+		return "VPN Gateway"
 	default:
 		return string(rsType) + " is not supported Resource!!"
 
@@ -93,6 +109,16 @@ func StringToRSType(str string) (RSType, error) {
 		return CLUSTER, nil
 	case "nodegroup":
 		return NODEGROUP, nil
+	case "vpcpeering":
+		return VPCPEERING, nil
+	case "natgateway":
+		return NATGATEWAY, nil
+	case "dnszone":
+		return DNSZONE, nil
+	case "alb":
+		return ALB, nil
+	case "vpngateway":
+		return VPNGATEWAY, nil
 	default:
 		return "", fmt.Errorf("%s is not a valid resource type", str)
 	}
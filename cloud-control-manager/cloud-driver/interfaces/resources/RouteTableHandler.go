@@ -0,0 +1,55 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Resouces interfaces of Cloud Driver.
+//
+// by CB-Spider Team, 2024.10.
+
+package resources
+
+// RouteTargetType represents the type of the next hop of a route.
+type RouteTargetType string
+
+const (
+	RouteTargetLocal           RouteTargetType = "Local"           // VPC internal route, can not be added or removed
+	RouteTargetInternetGateway RouteTargetType = "InternetGateway" // Internet Gateway of the VPC, TargetIID is not used
+	RouteTargetNATGateway      RouteTargetType = "NATGateway"
+	RouteTargetVPCPeering      RouteTargetType = "VPCPeering"
+	RouteTargetVM              RouteTargetType = "VM"
+)
+
+// -------- Info Structure
+// RouteInfo represents a route entry of a Route Table.
+// @description Route Information
+type RouteInfo struct {
+	DestinationCIDR string          `json:"DestinationCIDR" validate:"required" example:"0.0.0.0/0"`
+	TargetType      RouteTargetType `json:"TargetType" validate:"required" example:"InternetGateway"` // Local | InternetGateway | NATGateway | VPCPeering | VM
+	TargetIID       IID             `json:"TargetIID" validate:"omitempty"`                           // empty for Local and InternetGateway
+
+	KeyValueList []KeyValue `json:"KeyValueList,omitempty" validate:"omitempty"`
+}
+
+// RouteTableInfo represents the details of a Route Table of a VPC.
+// @description Route Table Information
+type RouteTableInfo struct {
+	IId        IID         `json:"IId" validate:"required"` // {NameId, SystemId}, NameId is same with SystemId
+	VpcIID     IID         `json:"VpcIID" validate:"required"`
+	IsMain     bool        `json:"IsMain" validate:"required" example:"true"` // the main(default) Route Table of the VPC
+	SubnetIIDs []IID       `json:"SubnetIIDs" validate:"omitempty"`           // explicitly associated subnets
+	Routes     []RouteInfo `json:"Routes" validate:"required"`
+
+	KeyValueList []KeyValue `json:"KeyValueList,omitempty" validate:"omitempty"`
+}
+
+// -------- Route Table API
+// The empty subnetIID means the main Route Table of the VPC.
+type RouteTableHandler interface {
+	ListRouteTable(vpcIID IID) ([]*RouteTableInfo, error)
+	GetRouteTable(vpcIID IID, subnetIID IID) (RouteTableInfo, error)
+
+	AddRoute(vpcIID IID, subnetIID IID, route RouteInfo) (RouteTableInfo, error)
+	RemoveRoute(vpcIID IID, subnetIID IID, route RouteInfo) (bool, error)
+}
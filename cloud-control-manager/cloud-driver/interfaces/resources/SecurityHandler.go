@@ -26,9 +26,22 @@ type SecurityRuleInfo struct {
 	IPProtocol string `json:"IPProtocol" validate:"required" example:"TCP"`            // TCP, UDP, ICMP, ALL
 	FromPort   string `json:"FromPort" validate:"required" example:"22"`               // TCP, UDP: 1~65535, ICMP, ALL: -1
 	ToPort     string `json:"ToPort" validate:"required" example:"22"`                 // TCP, UDP: 1~65535, ICMP, ALL: -1
-	CIDR       string `json:"CIDR,omitempty" validate:"omitempty" example:"0.0.0.0/0"` // if not specified, defaults to 0.0.0.0/0
+	CIDR       string `json:"CIDR,omitempty" validate:"omitempty" example:"0.0.0.0/0"` // if not specified, defaults to 0.0.0.0/0, IPv6 CIDR(ex: ::/0) for dual-stack
+
+	Description string `json:"Description,omitempty" validate:"omitempty" example:"allow ssh"`
+	Priority    string `json:"Priority,omitempty" validate:"omitempty" example:"100"` // optional, a lower number is evaluated first, only for the drivers with SG_RULE_PRIORITY
+	Action      string `json:"Action,omitempty" validate:"omitempty" example:"allow"` // allow(default) or deny, deny only for the drivers with SG_DENY_RULE
+
+	// source(inbound) or destination(outbound) security group instead of CIDR, optional
+	SecurityGroupIID *IID `json:"SecurityGroupIID,omitempty" validate:"omitempty"` // {NameId, SystemId}, request with NameId
 }
 
+// Action of a SecurityRuleInfo
+const (
+	RuleAllow = "allow"
+	RuleDeny  = "deny"
+)
+
 type SecurityInfo struct {
 	IId IID `json:"IId" validate:"required"` // {NameId, SystemId}
 
@@ -12,6 +12,8 @@
 package resources
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 )
 
@@ -50,9 +52,74 @@ type VMReqInfo struct {
 	VMUserPasswd string
 	WindowsType  bool
 
+	// cloud-init(#cloud-config) or shell script(#!/bin/bash), always plain text(Spider decodes base64 inputs).
+	// Drivers encode it as the CSP requires.
+	UserData string
+
+	PurchaseOption PurchaseOptionInfo // default: OnDemand
+
+	ReadinessProbe ReadinessProbeInfo // checked by Spider after StartVM, drivers do not need to handle it
+
 	TagList []KeyValue
 }
 
+// PurchaseOptionType represents the billing model of a VM.
+// @description The purchase option of a Virtual Machine (VM).
+// @enum string
+// @enum values [OnDemand, Spot, Preemptible]
+type PurchaseOptionType string
+
+const (
+	OnDemand    PurchaseOptionType = "OnDemand"    // default
+	Spot        PurchaseOptionType = "Spot"        // spare capacity with a max price, ex) AWS Spot, Azure Spot, Alibaba Spot, Tencent SPOTPAID
+	Preemptible PurchaseOptionType = "Preemptible" // spare capacity with a fixed discount, ex) GCP Preemptible(Spot VM)
+)
+
+// PurchaseOptionInfo represents the purchase option of a VM.
+// Spot and Preemptible VMs can be interrupted by the CSP, and then the VM status becomes Preempted.
+type PurchaseOptionInfo struct {
+	Type     PurchaseOptionType `json:"Type,omitempty" validate:"omitempty" example:"Spot"`       // OnDemand(default) | Spot | Preemptible
+	MaxPrice string             `json:"MaxPrice,omitempty" validate:"omitempty" example:"0.0125"` // USD per hour, only for Spot, "": up to the OnDemand price
+}
+
+// ReadinessProbeType represents how to check the readiness of a new VM.
+// @description The readiness probe type of a Virtual Machine (VM).
+// @enum string
+// @enum values [DEFAULT, NONE, PUBLIC_IP, TCP, SSH, HTTP]
+type ReadinessProbeType string
+
+const (
+	ReadinessDefault  ReadinessProbeType = "DEFAULT"   // PublicIP and SSHD(22) check, SSHD check is skipped for Windows
+	ReadinessNone     ReadinessProbeType = "NONE"      // no check
+	ReadinessPublicIP ReadinessProbeType = "PUBLIC_IP" // PublicIP is assigned
+	ReadinessTCP      ReadinessProbeType = "TCP"       // PublicIP:Port is connectable
+	ReadinessSSH      ReadinessProbeType = "SSH"       // SSH Command is succeeded
+	ReadinessHTTP     ReadinessProbeType = "HTTP"      // HTTP GET PublicIP:Port/Path returns 2xx or 3xx
+)
+
+// ReadinessProbeInfo represents the readiness check of a new VM.
+// TCP, SSH and HTTP probes are checked after the PublicIP is assigned.
+type ReadinessProbeInfo struct {
+	Type       ReadinessProbeType `json:"Type,omitempty" validate:"omitempty" example:"TCP"`                         // DEFAULT(default) | NONE | PUBLIC_IP | TCP | SSH | HTTP
+	Port       string             `json:"Port,omitempty" validate:"omitempty" example:"22"`                          // TCP, SSH(default: 22), HTTP(default: 80)
+	Path       string             `json:"Path,omitempty" validate:"omitempty" example:"/healthz"`                    // HTTP(default: /)
+	Command    string             `json:"Command,omitempty" validate:"omitempty" example:"cloud-init status --wait"` // SSH, run as cb-user
+	PrivateKey string             `json:"PrivateKey,omitempty" validate:"omitempty"`                                 // SSH, PrivateKey of the VM's KeyPair, not stored in Spider
+	Timeout    string             `json:"Timeout,omitempty" validate:"omitempty" example:"120"`                      // secs of the probe(10 ~ 1800), default: 240 for PUBLIC_IP, 120 for TCP, SSH and HTTP
+}
+
+// ReadinessStatus represents the result of the readiness probe.
+// @description The readiness status of a Virtual Machine (VM).
+// @enum string
+// @enum values [Ready, Timeout, Skipped]
+type ReadinessStatus string
+
+const (
+	Ready            ReadinessStatus = "Ready"
+	ReadinessTimeout ReadinessStatus = "Timeout" // the VM is created, but the probe is not passed in the timeout
+	ReadinessSkipped ReadinessStatus = "Skipped" // NONE probe
+)
+
 type VMStatusInfo struct {
 	IId      IID      `json:"IId" validate:"required" example:"` // {NameId: 'vm-01', SystemId: 'i-12345678'}"
 	VmStatus VMStatus `json:"VmStatus" validate:"required" example:"Running"`
@@ -61,7 +128,7 @@ type VMStatusInfo struct {
 // VMStatus represents the possible statuses of a VM.
 // @description The status of a Virtual Machine (VM).
 // @enum string
-// @enum values [Creating, Running, Suspending, Suspended, Resuming, Rebooting, Terminating, Terminated, NotExist, Failed]
+// @enum values [Creating, Running, Suspending, Suspended, Resuming, Rebooting, Terminating, Terminated, NotExist, Preempted, Failed]
 type VMStatus string
 
 const (
@@ -78,6 +145,8 @@ const (
 	Terminated  VMStatus = "Terminated"
 	NotExist    VMStatus = "NotExist" // VM does not exist
 
+	Preempted VMStatus = "Preempted" // Spot or Preemptible VM interrupted by the CSP
+
 	Failed VMStatus = "Failed"
 )
 
@@ -117,19 +186,38 @@ type VMInfo struct {
 	PublicDNS        string `json:"PublicDNS,omitempty" validate:"omitempty" example:"ec2-1-2-3-4.compute-1.amazonaws.com"`
 	PrivateIP        string `json:"PrivateIP" validate:"required" example:"192.168.1.1"`
 	PrivateDNS       string `json:"PrivateDNS,omitempty" validate:"omitempty" example:"ip-192-168-1-1.ec2.internal"`
+	IPv6Address      string `json:"IPv6Address,omitempty" validate:"omitempty" example:"2001:db8:1234:1a00::4"` // dual-stack only
 
 	Platform Platform `json:"Platform" validate:"required" example:"LINUX"` // LINUX | WINDOWS
 
 	SSHAccessPoint string `json:"SSHAccessPoint,omitempty" validate:"omitempty" example:"10.2.3.2:22"` // Deprecated
 	AccessPoint    string `json:"AccessPoint" validate:"required" example:"1.2.3.4:22"`                // 10.2.3.2:22, 123.456.789.123:432
 
+	UserDataHash string `json:"UserDataHash,omitempty" validate:"omitempty" example:"sha256:9f86d081884c7d65..."` // hash of the UserData used at creation, see GetUserDataHash()
+
+	PurchaseOption PurchaseOptionInfo `json:"PurchaseOption" validate:"omitempty"` // {Type: 'Spot', MaxPrice: '0.0125'}
+
+	ReadinessStatus ReadinessStatus `json:"ReadinessStatus,omitempty" validate:"omitempty" example:"Ready"` // result of the readiness probe, only set by StartVM
+
 	TagList      []KeyValue `json:"TagList,omitempty" validate:"omitempty"`      // example:"[{Key: 'Name', Value: 'MyVM'}]"
 	KeyValueList []KeyValue `json:"KeyValueList,omitempty" validate:"omitempty"` // example:"[{Key: 'Architecture', Value: 'x86_64'}]"
 }
 
+// GetUserDataHash returns the hash of a plain UserData, ex) "sha256:9f86d081884c7d65...".
+// The UserData itself is not exposed in VMInfo because it can include secrets.
+func GetUserDataHash(userData string) string {
+	if userData == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(userData))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
 type VMHandler interface {
 	ListIID() ([]*IID, error)
 
+	// vmReqInfo.UserData is passed to the VM at boot time by the drivers with USER_DATA,
+	// and VMInfo.UserDataHash should be set with GetUserDataHash().
 	StartVM(vmReqInfo VMReqInfo) (VMInfo, error)
 
 	SuspendVM(vmIID IID) (VMStatus, error)
@@ -142,4 +230,13 @@ type VMHandler interface {
 
 	ListVM() ([]*VMInfo, error)
 	GetVM(vmIID IID) (VMInfo, error)
+
+	// Changes the VMSpec(instance type) of the VM.
+	// If the CSP can not resize a running VM, the caller suspends the VM before and resumes it after.
+	// Optional: supported only when DriverCapabilityInfo.VM_SPEC_CHANGE is true.
+	ChangeVMSpec(vmIID IID, vmSpecName string) (VMInfo, error)
+
+	// Returns the serial console output(boot log) of the VM.
+	// Optional: supported only when DriverCapabilityInfo.CONSOLE_OUTPUT is true.
+	GetConsoleOutput(vmIID IID) (string, error)
 }
@@ -15,6 +15,7 @@ package resources
 type VPCReqInfo struct {
 	IId            IID // {NameId, SystemId}
 	IPv4_CIDR      string
+	IPv6_CIDR      string // optional, dual-stack
 	SubnetInfoList []SubnetInfo
 
 	TagList []KeyValue
@@ -23,6 +24,7 @@ type VPCReqInfo struct {
 type VPCInfo struct {
 	IId            IID          `json:"IId" validate:"required"` // {NameId, SystemId}
 	IPv4_CIDR      string       `json:"IPv4_CIDR" validate:"required" example:"10.0.0.0/16" description:"The IPv4 CIDR block for the VPC"`
+	IPv6_CIDR      string       `json:"IPv6_CIDR,omitempty" validate:"omitempty" example:"2001:db8:1234:1a00::/56" description:"The IPv6 CIDR block for the dual-stack VPC"`
 	SubnetInfoList []SubnetInfo `json:"SubnetInfoList" validate:"required" description:"A list of subnet information associated with this VPC"`
 
 	TagList      []KeyValue `json:"TagList,omitempty" validate:"omitempty" description:"A list of tags associated with this VPC"`
@@ -33,11 +35,29 @@ type SubnetInfo struct {
 	IId       IID    `json:"IId" validate:"required"` // {NameId, SystemId}
 	Zone      string `json:"Zone" validate:"required" example:"us-east-1a"`
 	IPv4_CIDR string `json:"IPv4_CIDR" validate:"required" example:"10.0.8.0/22" description:"The IPv4 CIDR block for the subnet"`
+	IPv6_CIDR string `json:"IPv6_CIDR,omitempty" validate:"omitempty" example:"2001:db8:1234:1a00::/64" description:"The IPv6 CIDR block for the dual-stack subnet"`
 
 	TagList      []KeyValue `json:"TagList,omitempty" validate:"omitempty" description:"A list of tags associated with this subnet"`
 	KeyValueList []KeyValue `json:"KeyValueList,omitempty" validate:"omitempty" description:"Additional key-value pairs associated with this subnet"`
 }
 
+// SubnetUsageInfo represents the IP address usage of a Subnet.
+type SubnetUsageInfo struct {
+	SubnetIID        IID    `json:"SubnetIID" validate:"required"` // {NameId, SystemId}
+	Zone             string `json:"Zone" validate:"required" example:"us-east-1a"`
+	IPv4_CIDR        string `json:"IPv4_CIDR" validate:"required" example:"10.0.8.0/22"`
+	TotalIPCount     int    `json:"TotalIPCount" validate:"required" example:"1024"`     // all addresses in the IPv4 CIDR
+	ReservedIPCount  int    `json:"ReservedIPCount" validate:"required" example:"5"`     // addresses reserved by the CSP, ex) AWS reserves 5 addresses
+	UsedIPCount      int    `json:"UsedIPCount" validate:"required" example:"12"`        // addresses assigned to VMs, NICs, gateways, etc.
+	AvailableIPCount int    `json:"AvailableIPCount" validate:"required" example:"1007"` // TotalIPCount - ReservedIPCount - UsedIPCount
+}
+
+// SubnetUpdateInfo represents the changes of a Subnet. Nil fields are not changed.
+type SubnetUpdateInfo struct {
+	AutoAssignPublicIP *bool `json:"AutoAssignPublicIP,omitempty" validate:"omitempty" example:"true"` // assign a public IP to VMs started in the Subnet
+	RouteTableIID      *IID  `json:"RouteTableIID,omitempty" validate:"omitempty"`                     // Route Table to associate the Subnet with
+}
+
 type VPCHandler interface {
 	ListIID() ([]*IID, error)
 	CreateVPC(vpcReqInfo VPCReqInfo) (VPCInfo, error)
@@ -47,4 +67,7 @@ type VPCHandler interface {
 
 	AddSubnet(vpcIID IID, subnetInfo SubnetInfo) (VPCInfo, error)
 	RemoveSubnet(vpcIID IID, subnetIID IID) (bool, error)
+
+	GetSubnetUsage(vpcIID IID, subnetIID IID) (SubnetUsageInfo, error)
+	UpdateSubnet(vpcIID IID, subnetIID IID, updateInfo SubnetUpdateInfo) (SubnetInfo, error)
 }
@@ -0,0 +1,77 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Resouces interfaces of Cloud Driver.
+//
+// by CB-Spider Team, 2024.10.
+
+package resources
+
+import "time"
+
+// VPCPeeringStatus represents the lifecycle status of a VPC Peering.
+type VPCPeeringStatus string
+
+const (
+	PeeringPendingAcceptance VPCPeeringStatus = "PendingAcceptance"
+	PeeringActive            VPCPeeringStatus = "Active"
+	PeeringRejected          VPCPeeringStatus = "Rejected"
+	PeeringDeleting          VPCPeeringStatus = "Deleting"
+	PeeringFailed            VPCPeeringStatus = "Failed"
+)
+
+// -------- Info Structure
+// VPCPeeringReqInfo represents the request to connect two VPCs.
+// The Requester VPC belongs to the connection of the handler,
+// the Accepter VPC can belong to another connection(region) of the same provider.
+// @description VPC Peering Request Information
+type VPCPeeringReqInfo struct {
+	IId             IID `json:"IId" validate:"required"`
+	RequesterVPCIID IID `json:"RequesterVPCIID" validate:"required"`
+	AccepterVPCIID  IID `json:"AccepterVPCIID" validate:"required"`
+
+	AccepterRegion string `json:"AccepterRegion,omitempty" validate:"omitempty" example:"ap-northeast-1"` // empty: same region with Requester
+
+	// route propagation: add routes to the peer VPC CIDR into the route tables of each side
+	RequesterRoutePropagation bool `json:"RequesterRoutePropagation" validate:"omitempty" example:"true"`
+	AccepterRoutePropagation  bool `json:"AccepterRoutePropagation" validate:"omitempty" example:"true"`
+
+	TagList []KeyValue `json:"TagList,omitempty" validate:"omitempty"`
+}
+
+// VPCPeeringInfo represents the details of a VPC Peering.
+// @description VPC Peering Information
+type VPCPeeringInfo struct {
+	IId             IID `json:"IId" validate:"required"`
+	RequesterVPCIID IID `json:"RequesterVPCIID" validate:"required"`
+	AccepterVPCIID  IID `json:"AccepterVPCIID" validate:"required"`
+
+	RequesterCIDR  string `json:"RequesterCIDR,omitempty" validate:"omitempty" example:"10.0.0.0/16"`
+	AccepterCIDR   string `json:"AccepterCIDR,omitempty" validate:"omitempty" example:"10.1.0.0/16"`
+	AccepterRegion string `json:"AccepterRegion,omitempty" validate:"omitempty" example:"ap-northeast-1"`
+
+	RequesterRoutePropagation bool `json:"RequesterRoutePropagation" validate:"omitempty" example:"true"`
+	AccepterRoutePropagation  bool `json:"AccepterRoutePropagation" validate:"omitempty" example:"true"`
+
+	Status VPCPeeringStatus `json:"Status" validate:"required" example:"Active"`
+
+	CreatedTime  time.Time  `json:"CreatedTime" validate:"omitempty" example:"2024-10-01T10:00:00Z"`
+	TagList      []KeyValue `json:"TagList,omitempty" validate:"omitempty"`
+	KeyValueList []KeyValue `json:"KeyValueList,omitempty" validate:"omitempty"`
+}
+
+// -------- VPC Peering API
+type VPCPeeringHandler interface {
+
+	//------ Peering Management
+	RequestVPCPeering(peeringReqInfo VPCPeeringReqInfo) (VPCPeeringInfo, error)
+	AcceptVPCPeering(peeringIID IID) (VPCPeeringInfo, error) // called with the handler of Accepter's connection
+	ListVPCPeering() ([]*VPCPeeringInfo, error)
+	GetVPCPeering(peeringIID IID) (VPCPeeringInfo, error)
+	DeleteVPCPeering(peeringIID IID) (bool, error)
+
+	ListIID() ([]*IID, error)
+}
@@ -0,0 +1,106 @@
+// Cloud Driver Interface of CB-Spider.
+// The CB-Spider is a sub-Framework of the Cloud-Barista Multi-Cloud Project.
+// The CB-Spider Mission is to connect all the clouds with a single interface.
+//
+//      * Cloud-Barista: https://github.com/cloud-barista
+//
+// This is Resouces interfaces of Cloud Driver.
+//
+// by CB-Spider Team, 2024.10.
+
+package resources
+
+import "time"
+
+// VPNGatewayStatus represents the lifecycle status of a VPN Gateway.
+type VPNGatewayStatus string
+
+const (
+	VPNGatewayPending   VPNGatewayStatus = "Pending"
+	VPNGatewayAvailable VPNGatewayStatus = "Available"
+	VPNGatewayDeleting  VPNGatewayStatus = "Deleting"
+	VPNGatewayFailed    VPNGatewayStatus = "Failed"
+)
+
+// VPNTunnelStatus represents the connectivity status of a VPN Tunnel.
+type VPNTunnelStatus string
+
+const (
+	VPNTunnelPending VPNTunnelStatus = "Pending" // waiting for the peer side
+	VPNTunnelUp      VPNTunnelStatus = "Up"
+	VPNTunnelDown    VPNTunnelStatus = "Down"
+)
+
+// -------- Info Structure
+// VPNGatewayReqInfo represents the request to create a site-to-site VPN Gateway of a VPC.
+// A public IP is allocated for the VPN Gateway by the driver.
+// @description VPN Gateway Request Information
+type VPNGatewayReqInfo struct {
+	IId       IID `json:"IId" validate:"required"`
+	VpcIID    IID `json:"VpcIID" validate:"required"`
+	SubnetIID IID `json:"SubnetIID,omitempty" validate:"omitempty"` // dedicated gateway Subnet, only for CSPs which require it (ex: Azure GatewaySubnet)
+
+	TagList []KeyValue `json:"TagList,omitempty" validate:"omitempty"`
+}
+
+// VPNGatewayInfo represents the details of a VPN Gateway.
+// @description VPN Gateway Information
+type VPNGatewayInfo struct {
+	IId    IID `json:"IId" validate:"required"`
+	VpcIID IID `json:"VpcIID" validate:"required"`
+
+	PublicIP string `json:"PublicIP" validate:"required" example:"3.34.100.20"` // tunnel endpoint for the peer side
+
+	Status VPNGatewayStatus `json:"Status" validate:"required" example:"Available"`
+
+	CreatedTime  time.Time  `json:"CreatedTime" validate:"omitempty" example:"2024-10-01T10:00:00Z"`
+	TagList      []KeyValue `json:"TagList,omitempty" validate:"omitempty"`
+	KeyValueList []KeyValue `json:"KeyValueList,omitempty" validate:"omitempty"`
+}
+
+// VPNTunnelReqInfo represents the request to create an IPsec Tunnel from a VPN Gateway to a peer gateway.
+// @description VPN Tunnel Request Information
+type VPNTunnelReqInfo struct {
+	IId           IID      `json:"IId" validate:"required"`
+	VPNGatewayIID IID      `json:"VPNGatewayIID" validate:"required"`
+	PeerPublicIP  string   `json:"PeerPublicIP" validate:"required" example:"20.41.100.30"`
+	PeerCIDRList  []string `json:"PeerCIDRList" validate:"required" example:"10.1.0.0/16"` // networks of the peer side routed into the Tunnel
+	PreSharedKey  string   `json:"PreSharedKey" validate:"required"`
+
+	TagList []KeyValue `json:"TagList,omitempty" validate:"omitempty"`
+}
+
+// VPNTunnelInfo represents the details of a VPN Tunnel.
+// The pre-shared key is write-only and is not returned by the driver.
+// @description VPN Tunnel Information
+type VPNTunnelInfo struct {
+	IId           IID      `json:"IId" validate:"required"`
+	VPNGatewayIID IID      `json:"VPNGatewayIID" validate:"required"`
+	PeerPublicIP  string   `json:"PeerPublicIP" validate:"required" example:"20.41.100.30"`
+	PeerCIDRList  []string `json:"PeerCIDRList" validate:"required" example:"10.1.0.0/16"`
+
+	Status VPNTunnelStatus `json:"Status" validate:"required" example:"Up"`
+
+	CreatedTime  time.Time  `json:"CreatedTime" validate:"omitempty" example:"2024-10-01T10:00:00Z"`
+	TagList      []KeyValue `json:"TagList,omitempty" validate:"omitempty"`
+	KeyValueList []KeyValue `json:"KeyValueList,omitempty" validate:"omitempty"`
+}
+
+// -------- VPN Gateway API
+type VPNGatewayHandler interface {
+
+	//------ VPN Gateway Management
+	// The allocated public IP is released when the VPN Gateway is deleted.
+	CreateVPNGateway(vpnReqInfo VPNGatewayReqInfo) (VPNGatewayInfo, error)
+	ListVPNGateway() ([]*VPNGatewayInfo, error)
+	GetVPNGateway(vpnIID IID) (VPNGatewayInfo, error)
+	DeleteVPNGateway(vpnIID IID) (bool, error) // fails if the VPN Gateway still has Tunnels
+
+	//------ VPN Tunnel Management
+	CreateVPNTunnel(tunnelReqInfo VPNTunnelReqInfo) (VPNTunnelInfo, error)
+	ListVPNTunnel(vpnIID IID) ([]*VPNTunnelInfo, error)
+	GetVPNTunnel(tunnelIID IID) (VPNTunnelInfo, error)
+	DeleteVPNTunnel(tunnelIID IID) (bool, error)
+
+	ListIID() ([]*IID, error)
+}
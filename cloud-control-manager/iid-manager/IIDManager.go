@@ -111,6 +111,15 @@ func New(cloudConnectName string, rsType string, uid string) (string, error) {
 	return convertDashOrUnderScore(cccInfo.ProviderName, spXID)
 }
 
+// GetIdMaxLength returns the max ID length of the rsType in cloudos_meta.yaml, 0 if not defined.
+func GetIdMaxLength(cloudConnectName string, rsType string) (int, error) {
+	cccInfo, err := ccim.GetConnectionConfig(cloudConnectName)
+	if err != nil {
+		return 0, err
+	}
+	return getIdMaxLength(cccInfo.ProviderName, rsType), nil
+}
+
 func getIdMaxLength(providerName string, rsType string) int {
 	// get Provider's Meta Info
 	cloudOSMetaInfo, err := cim.GetCloudOSMetaInfo(providerName)
@@ -23,6 +23,8 @@ type CloudOSMetaInfo struct {
 	DiskSize             []string `json:"DiskSize" validate:"required"`             // Supported additional disk sizes (in GB).
 	IdMaxLength          []string `json:"IdMaxLength" validate:"required"`          // Maximum allowed length for IDs in the cloud provider.
 	DefaultRegionToQuery []string `json:"DefaultRegionToQuery" validate:"required"` // Default region to use if none is specified for a query.
+	UserDataMaxSize      []string `json:"UserDataMaxSize" validate:"required"`      // Maximum allowed size of the plain UserData in bytes.
+	VMSpecChange         []string `json:"VMSpecChange" validate:"required"`         // STOP(the VM should be suspended to change the VMSpec) or ONLINE.
 }
 
 // struct for unmarshal
@@ -36,6 +38,8 @@ type YamlMetaInfo struct {
 	DiskSize             string
 	IdMaxLength          string
 	DefaultRegionToQuery string
+	UserDataMaxSize      string
+	VMSpecChange         string
 }
 
 // global variable to prevent file opereations
@@ -77,6 +81,8 @@ func GetCloudOSMetaInfo(cloudOS string) (CloudOSMetaInfo, error) {
 		DiskSize:             cloneSlice(mInfo.DiskSize),
 		IdMaxLength:          cloneSlice(mInfo.IdMaxLength),
 		DefaultRegionToQuery: cloneSlice(mInfo.DefaultRegionToQuery),
+		UserDataMaxSize:      cloneSlice(mInfo.UserDataMaxSize),
+		VMSpecChange:         cloneSlice(mInfo.VMSpecChange),
 	}
 	rwMutex.Unlock()
 	return ret, nil
@@ -138,6 +144,8 @@ func convertAndSetMetaInfo(yamlMetaInfo map[string]YamlMetaInfo) {
 			DiskSize:             splitAndTrim(v.DiskSize),
 			IdMaxLength:          splitAndTrim(v.IdMaxLength),
 			DefaultRegionToQuery: splitAndTrim(v.DefaultRegionToQuery),
+			UserDataMaxSize:      splitAndTrim(v.UserDataMaxSize),
+			VMSpecChange:         splitAndTrim(v.VMSpecChange),
 		}
 		metaInfo[k] = cloudOSMetaInfo
 	}